@@ -19,6 +19,7 @@ import (
 	"github.com/kimbasn/printly/internal/config"
 	"github.com/kimbasn/printly/internal/db"
 	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/repository"
 	"github.com/kimbasn/printly/internal/routes"
 	"github.com/kimbasn/printly/internal/service"
 
@@ -79,11 +80,45 @@ func main() {
 		logger.Fatal("Failed to initialize storage service", zap.Error(err))
 	}
 
+	// Initialize payment gateway
+	paymentGateway, err := service.GetPaymentGateway(cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize payment gateway", zap.Error(err))
+	}
+
+	// Initialize the malware scanner of uploaded files
+	scanner, err := service.GetScanner(cfg.Scanner)
+	if err != nil {
+		logger.Fatal("Failed to initialize malware scanner", zap.Error(err))
+	}
+
+	// Initialize pickup QR code signer
+	pickupSigner := service.NewPickupTokenSigner([]byte(cfg.Pickup.SigningSecret), cfg.Pickup.TokenTTL)
+
+	// Initialize deletion receipt signer
+	receiptSigner := service.NewDeletionReceiptSigner([]byte(cfg.Receipts.SigningSecret))
+
+	// Initialize the live order event bus, shared by every order status change
+	orderEvents := service.NewOrderEventBus(service.DefaultOrderEventHistory)
+
+	// Initialize notifications
+	notifier, err := initNotifier(cfg, dbConn, logger)
+	if err != nil {
+		logger.Fatal("Notifier initialization failed", zap.Error(err))
+	}
+
+	// Initialize background jobs
+	jobScheduler, err := initJobScheduler(cfg, dbConn, storageService, receiptSigner, orderEvents, notifier, logger)
+	if err != nil {
+		logger.Fatal("Job scheduler initialization failed", zap.Error(err))
+	}
+
 	// Setup server
-	server := setupServer(cfg, dbConn, firebaseApp, storageService, logger)
+	server := setupServer(cfg, dbConn, firebaseApp, storageService, scanner, paymentGateway, pickupSigner, receiptSigner, orderEvents, notifier, jobScheduler, logger)
 
 	// Start server with graceful shutdown
-	startServerWithGracefulShutdown(server, cfg, logger)
+	jobScheduler.Start()
+	startServerWithGracefulShutdown(server, jobScheduler, cfg, logger)
 }
 
 func initLogger() (*zap.Logger, error) {
@@ -120,10 +155,66 @@ func initFirebase(cfg *config.Config, logger *zap.Logger) (*firebase.App, error)
 	return firebaseApp, nil
 }
 
+func initNotifier(cfg *config.Config, dbConn *gorm.DB, logger *zap.Logger) (service.Notifier, error) {
+	logger.Info("Initializing notifier...")
+
+	senders, err := service.GetNotificationSenders(cfg.Notifications, logger)
+	if err != nil {
+		return nil, err
+	}
+	notifier := service.NewNotifier(repository.NewNotificationRepository(dbConn),
+		repository.NewOrderRepository(dbConn),
+		repository.NewUserRepository(dbConn),
+		repository.NewPrintCenterRepository(dbConn),
+		senders,
+		cfg.Notifications.DefaultLocale,
+		logger)
+
+	logger.Info("Notifier initialized successfully")
+	return notifier, nil
+}
+
+func initJobScheduler(cfg *config.Config, dbConn *gorm.DB, storageService service.StorageService, receiptSigner service.DeletionReceiptSigner, orderEvents service.OrderEventBus, notifier service.Notifier, logger *zap.Logger) (service.JobScheduler, error) {
+	logger.Info("Initializing job scheduler...")
+
+	jobRepo := repository.NewJobRepository(dbConn)
+	orderRepo := repository.NewOrderRepository(dbConn)
+	scheduler := service.NewJobScheduler(jobRepo, logger)
+
+	stateMachine := service.NewOrderStateMachine(orderRepo, orderEvents, notifier, logger)
+	purger := service.NewDocumentPurger(orderRepo, storageService, receiptSigner, logger)
+	expirer := service.NewOrderExpirer(orderRepo, stateMachine, purger, notifier, service.NewExpiryPolicy(cfg.OrderExpiry), cfg.OrderExpiry.WarnBefore, logger)
+	retention := service.NewDocumentRetention(orderRepo, purger, cfg.DocumentRetention.MaxAge, logger)
+	uploads := service.NewResumableUploadService(repository.NewResumableUploadRepository(dbConn), orderRepo, storageService, cfg.OrderExpiry.AwaitingDocumentTTL, cfg.Timeouts.Upload, logger)
+
+	jobs := []service.Job{
+		service.NewJobRunCleanupJob(jobRepo, service.JobRunRetention, logger),
+		service.NewOrderTimeoutJob(expirer),
+		service.NewDocumentRetentionJob(retention),
+		service.NewNotificationDeliveryJob(notifier),
+		service.NewResumableUploadCleanupJob(uploads),
+	}
+	for _, job := range jobs {
+		if err := scheduler.Register(job); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("Job scheduler initialized successfully")
+	return scheduler, nil
+}
+
 func setupServer(cfg *config.Config,
 	dbConn *gorm.DB,
 	firebaseApp *firebase.App,
 	storageService service.StorageService,
+	scanner service.Scanner,
+	paymentGateway service.PaymentGateway,
+	pickupSigner service.PickupTokenSigner,
+	receiptSigner service.DeletionReceiptSigner,
+	orderEvents service.OrderEventBus,
+	notifier service.Notifier,
+	jobScheduler service.JobScheduler,
 	logger *zap.Logger) *gin.Engine {
 	// Set Gin mode based on environment
 	if cfg.AppEnv == "production" {
@@ -156,24 +247,29 @@ func setupServer(cfg *config.Config,
 
 	// Register routes
 	routes.RegisterUserRoutes(api, dbConn, validate, firebaseApp)
-	routes.RegisterPrintCenterRoutes(api, dbConn, validate, firebaseApp)
-	routes.RegisterOrderRoutes(api, dbConn, validate, firebaseApp, logger, storageService)
+	routes.RegisterPrintCenterRoutes(api, dbConn, validate, firebaseApp, logger, notifier)
+	routes.RegisterOrderRoutes(api, dbConn, validate, firebaseApp, logger, storageService, scanner, pickupSigner, receiptSigner, paymentGateway, orderEvents, notifier, cfg.Tracking.OrderPrintTime, cfg.Timeouts.Upload)
+	routes.RegisterResumableUploadRoutes(api, dbConn, firebaseApp, logger, storageService, cfg.OrderExpiry.AwaitingDocumentTTL, cfg.Timeouts.Upload)
+	routes.RegisterPaymentRoutes(api, dbConn, firebaseApp, logger, paymentGateway, orderEvents, notifier)
+	routes.RegisterNotificationRoutes(api, dbConn, validate, firebaseApp, logger, notifier)
+	routes.RegisterJobRoutes(api, dbConn, firebaseApp, logger, jobScheduler)
+	routes.RegisterTaskRoutes(api, dbConn, cfg, logger, storageService, receiptSigner, orderEvents, notifier)
 
 	logger.Info("Server setup completed")
 	return server
 }
 
-func startServerWithGracefulShutdown(server *gin.Engine, cfg *config.Config, logger *zap.Logger) {
+func startServerWithGracefulShutdown(server *gin.Engine, jobScheduler service.JobScheduler, cfg *config.Config, logger *zap.Logger) {
 	serverAddress := cfg.GetServerAddress()
 
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:    serverAddress,
 		Handler: server,
-		// Add timeouts for security
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		// Add timeouts for security, upload routes extend them with middlewares.RequestDeadline
+		ReadTimeout:  cfg.Timeouts.Read,
+		WriteTimeout: cfg.Timeouts.Write,
+		IdleTimeout:  cfg.Timeouts.Idle,
 	}
 
 	// Start server in a goroutine
@@ -202,5 +298,10 @@ func startServerWithGracefulShutdown(server *gin.Engine, cfg *config.Config, log
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Let the running jobs return before exiting
+	if err := jobScheduler.Stop(ctx); err != nil {
+		logger.Error("Job scheduler forced to stop", zap.Error(err))
+	}
+
 	logger.Info("Server exited gracefully")
 }
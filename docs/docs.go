@@ -23,6 +23,108 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/centers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of the print centers, regardless of status unless filtered, by registration date by default. Requires admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get all print centers (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of centers to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "id, created_at, name or status, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return centers in these statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.PrintCenter"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch all centers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/admin/centers/pending": {
             "get": {
                 "security": [
@@ -30,7 +132,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all print centers awaiting approval. Requires admin role.",
+                "description": "Lists one page of the print centers awaiting approval, by registration date by default. Requires admin role.",
                 "produces": [
                     "application/json"
                 ],
@@ -38,14 +140,72 @@ const docTemplate = `{
                     "Admin"
                 ],
                 "summary": "Get all pending print centers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of centers to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "id, created_at, name or status, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.PrintCenter"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.PrintCenter"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -167,31 +327,209 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/orders": {
+        "/admin/jobs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all orders across the platform. Requires admin role.",
+                "description": "Lists the background jobs with their schedule, next run and last recorded run. Requires admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get all orders (admin)",
+                "summary": "List background jobs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entity.Order"
+                                "$ref": "#/definitions/dto.JobStatus"
                             }
                         }
                     },
+                    "500": {
+                        "description": "Failed to fetch jobs",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/jobs/{name}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a background job immediately. The job runs asynchronously; the returned run can be followed through the job list. Requires admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Run a background job now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.JobRun"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job already running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to trigger job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Job scheduler stopped, the server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of the orders across the platform, newest first by default, with their documents. Requires admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get all orders (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "id, created_at, updated_at, status or total_cost, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return orders in these statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return orders of this print center",
+                        "name": "center_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders of this user",
+                        "name": "user_uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Order"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Failed to fetch all orders",
                         "schema": {
@@ -369,7 +707,7 @@ const docTemplate = `{
         },
         "/centers": {
             "get": {
-                "description": "Retrieves a list of all approved print centers.",
+                "description": "Lists one page of the approved print centers, by registration date by default.",
                 "produces": [
                     "application/json"
                 ],
@@ -377,14 +715,72 @@ const docTemplate = `{
                     "Print Centers"
                 ],
                 "summary": "Get all public print centers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of centers to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "id, created_at, name or status, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return centers registered before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.PrintCenter"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.PrintCenter"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -555,7 +951,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all orders for a specific print center. Requires manager or admin role.",
+                "description": "Lists one page of the orders of a print center, newest first by default, with their documents. Only available to the managers of the center and admins.",
                 "produces": [
                     "application/json"
                 ],
@@ -570,20 +966,86 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "id, created_at, updated_at, status or total_cost, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return orders in these statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.Order"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Order"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid ID, filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a manager of this print center",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -602,7 +1064,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new order with one or more documents uploaded as files. Each document can have its own print mode and options. Requires authentication.",
+                "description": "Creates a new order with one or more documents uploaded as files. Each document can have its own print mode and options. The files are streamed to storage as they are received: up to 10 files of 50MB each, whose first bytes must match their content type. Requires authentication.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -640,7 +1102,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/dto.CreatedOrder"
                         }
                     },
                     "400": {
@@ -662,7 +1124,13 @@ const docTemplate = `{
                         }
                     },
                     "413": {
-                        "description": "File too large",
+                        "description": "File or form too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Malware detected in a file",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -672,44 +1140,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Files can not be scanned for malware right now",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/orders/status/{code}": {
+        "/centers/{id}/orders/stream": {
             "get": {
-                "description": "Retrieves the status of an order using its public pickup code.",
-                "produces": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of the orders of a print center: order.created, order.paid, order.status_changed and order.cancelled events, each carrying a dto.OrderEvent as JSON data and its ID. A reconnecting client sends the ID of the last event it received as the Last-Event-ID header (or the last_event_id parameter) to get the events it missed first, as long as the server still retains them. Comments are sent every 15 seconds to keep the stream open. Restricted to the managers of the center and to admins.",
+                "produces": [
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Orders"
+                    "Print Centers"
                 ],
-                "summary": "Get order status by pickup code",
+                "summary": "Stream live orders of a print center",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pickup Code",
-                        "name": "code",
+                        "description": "Print Center ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received, for clients that can not set headers",
+                        "name": "last_event_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/dto.OrderEvent"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
+                    "400": {
+                        "description": "Invalid ID or event ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to fetch order status",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a manager of this print center",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Print center not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -717,14 +1220,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "patch": {
+        "/centers/{id}/orders/uploads": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the status of an order. Requires manager or admin role.",
+                "description": "Creates an order from the metadata of its documents, without sending the files. Each document comes back with a signed URL valid for 10 minutes: PUT the file to it with the given headers, then confirm the uploads. The order waits in AWAITING_DOCUMENT until then and is priced from the declared page counts. Requires authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -732,48 +1235,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Print Centers"
                 ],
-                "summary": "Update an order's status",
+                "summary": "Create an order and upload its files straight to storage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Print Center ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "New status",
-                        "name": "status",
+                        "description": "Documents to upload with their print options",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/dto.DirectUploadOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Status updated",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/dto.DirectUploadOrder"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input or service not offered",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Print center not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Print center not operational",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update status",
+                        "description": "Failed to create order",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -781,41 +1296,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/centers/{id}/orders/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all users.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get all users",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.User"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to fetch users",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Registers a new user in the system.",
+                "description": "Checks the pickup code presented by a customer at the counter, typed in as ` + "`" + `code` + "`" + ` or scanned from the pickup QR code as ` + "`" + `token` + "`" + `. The order must belong to the center and be ready for pickup or awaiting its customer; it is then moved to its next status (completed or printing) and the verifying manager is recorded. Wrong codes are counted and throttled per center. Requires manager role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -823,25 +1311,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Print Centers"
                 ],
-                "summary": "Create a new user",
+                "summary": "Verify a pickup code",
                 "parameters": [
                     {
-                        "description": "User to create",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Print Center ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pickup code",
+                        "name": "pickup",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateUserRequest"
+                            "$ref": "#/definitions/dto.VerifyPickupRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.Order"
                         }
                     },
                     "400": {
@@ -850,14 +1345,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not a manager of this center",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid pickup code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "409": {
-                        "description": "User already exists",
+                        "description": "Order not ready for pickup or QR code expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to register user",
+                        "description": "Failed to verify pickup code",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -865,96 +1378,108 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/centers/{id}/quote": {
+            "post": {
+                "description": "Prices documents with the services of a print center before any upload or payment. Nothing is persisted, the prices are computed exactly as for order creation.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves the profile of the currently authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Print Centers"
+                ],
+                "summary": "Get a price quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Print Center ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Documents to price",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuoteRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current user's profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/dto.Quote"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid input or service not offered",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Print center not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to compute quote",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/deletion-receipts/verify": {
+            "post": {
+                "description": "Checks that a deletion receipt was issued by Printly and has not been altered. No authentication required.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Permanently deletes the account of the currently authenticated user from the system and Firebase.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Orders"
                 ],
-                "summary": "Delete current user's account",
-                "responses": {
-                    "200": {
-                        "description": "Account deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                "summary": "Verify a deletion receipt",
+                "parameters": [
+                    {
+                        "description": "Receipt to verify",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/entity.DeletionReceipt"
                         }
-                    },
-                    "404": {
-                        "description": "User not found",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.DeletionReceiptVerification"
                         }
                     },
-                    "500": {
-                        "description": "Failed to delete account",
+                    "400": {
+                        "description": "Invalid receipt",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Allows the currently authenticated user to update their profile information.",
+            }
+        },
+        "/mock/payments/{session_id}": {
+            "post": {
+                "description": "Development only. Makes the mock payment provider settle a session and deliver the signed webhook, as a real provider would.",
                 "consumes": [
                     "application/json"
                 ],
@@ -962,23 +1487,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Webhooks"
                 ],
-                "summary": "Update current user's profile",
+                "summary": "Settle a mock checkout session",
                 "parameters": [
                     {
-                        "description": "Profile data to update",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Checkout session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome of the payment",
+                        "name": "outcome",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                            "$ref": "#/definitions/dto.SimulatePaymentRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "Webhook processed",
                         "schema": {
                             "$ref": "#/definitions/dto.SuccessResponse"
                         }
@@ -989,20 +1520,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Payment not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update profile",
+                        "description": "Failed to simulate payment",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1010,26 +1535,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{uid}": {
+        "/orders/status/{code}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves a single user by their unique identifier.",
+                "description": "Retrieves the status of an order using its public pickup code. Only the status is disclosed, not the order content.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Orders"
                 ],
-                "summary": "Get a user by UID",
+                "summary": "Get order status by pickup code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UID",
-                        "name": "uid",
+                        "description": "Pickup Code",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -1038,30 +1558,77 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/dto.OrderStatusResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch user",
+                        "description": "Failed to fetch order status",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/orders/status/{code}/live": {
+            "get": {
+                "description": "WebSocket following an order with its pickup code and the tracking token issued when it was created. A dto.OrderTracking JSON message is sent on connection, then each time the status, pickup time or queue position of the order changes. Only status-level data is sent, never documents or customer details. The socket is closed with code 1000 once the order is completed, cancelled or failed, and with code 1013 when the client should reconnect.",
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Track an order live",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pickup code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tracking token of the order",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols, then one message per change",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderTracking"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing tracking token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown pickup code or tracking token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates a user's profile information. Only email and phone number can be updated.",
+                "description": "Cancels an order, refunds it if it was paid and deletes its documents from storage. Customers may cancel their own orders until printing starts. Managers may cancel paid orders of their center that were not printed yet, and admins any order that is not over; both must give a reason. The order stays cancelled when the refund or the deletion fails, the failures are listed in the response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1069,178 +1636,2915 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Orders"
                 ],
-                "summary": "Update a user's profile",
+                "summary": "Cancel an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UID",
-                        "name": "uid",
+                        "description": "Order ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User data to update",
-                        "name": "user",
+                        "description": "Cancellation reason",
+                        "name": "reason",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                            "$ref": "#/definitions/dto.CancelOrderRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/dto.CancelOrderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input or missing reason",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to cancel this order",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can not be cancelled in its current status",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update profile",
+                        "description": "Failed to cancel order",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/orders/{id}/documents/{docId}/deletion-receipt": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a user by their unique identifier.",
+                "description": "Returns the signed receipt issued when the file of a document was deleted from storage. It holds the order code, the SHA-256 of the file name and content, the storage backend and the deletion time, and can be checked with the public verify endpoint. Available to the order owner, the managers of its print center and admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Orders"
                 ],
-                "summary": "Delete a user",
+                "summary": "Get the deletion receipt of a document",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UID",
-                        "name": "uid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "docId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/entity.DeletionReceipt"
                         }
                     },
                     "400": {
-                        "description": "Missing user UID",
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to access this order",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Order, document or receipt not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Document not deleted yet",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete user",
+                        "description": "Failed to fetch deletion receipt",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreatePrintCenterRequest": {
-            "type": "object",
-            "required": [
-                "address",
-                "email",
-                "geo_coordinates",
-                "name",
-                "phone_number",
-                "working_hours"
-            ],
-            "properties": {
-                "address": {
-                    "$ref": "#/definitions/entity.Address"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "geo_coordinates": {
-                    "$ref": "#/definitions/entity.GeoPoint"
-                },
-                "name": {
-                    "type": "string",
-                    "minLength": 3
-                },
-                "phone_number": {
-                    "type": "string"
-                },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Service"
+        },
+        "/orders/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every status transition of an order, oldest first, with the actor and reason. Available to the order owner, the managers of its print center and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the status history of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.OrderStatusHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to access this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch order history",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of the emails and SMS sent or queued about an order, newest first by default, with their delivery status. Available to the order owner and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List the notifications of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of notifications to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "id, created_at or status, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return notifications in these statuses: PENDING, SENT or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return notifications created at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return notifications created before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Notification"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to access this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch notifications",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a checkout session with the payment gateway for an order awaiting payment. Returns the existing session if one is still pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Start the payment of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to initiate payment",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pickup-qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renders a QR code encoding a signed payload (pickup code, center, expiry) that managers can scan instead of typing the pickup code. The expiry is returned in the X-Expires-At header. Only available to the order owner while the order is active.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the pickup QR code of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to render QR code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/purge-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tells, for each document of an order, whether its file is deleted from storage. Files are deleted once printed, once the order is over, and in any case after a maximum age. Available to the order owner, the managers of its print center and admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the purge report of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DocumentPurgeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to access this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch purge report",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/schedule": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the pickup time and print mode of a paid order. The pickup time must fall within the working hours of the print center. PRE_PRINT orders move to READY_TO_PRINT, PRINT_UPON_ARRIVAL orders to AWAITING_USER. Only available to the order owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Schedule the pickup of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pickup time and print mode",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or pickup outside working hours",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order not paid",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to schedule order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an order to a new status. Only transitions allowed by the order lifecycle are accepted: managers may move orders of their center through printing and pickup, admins may apply any legal transition. Every change is recorded in the order history. Requires manager or admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update an order's status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Transition not allowed for this user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/uploads/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks the files uploaded with the signed URLs of an order against the declared size and type, reads their pages and prices the order again from them. The order then moves to PENDING_PAYMENT. A file that does not match is deleted and must be uploaded again. Only available to the order owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Confirm the uploads of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, or a file does not match its document or can not be read",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order not awaiting its documents, or a file is not uploaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Malware detected in a file, the order is failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to confirm uploads",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Files can not be scanned for malware right now",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumable-uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts the upload of a document of an order created with POST /centers/{id}/orders/uploads, following the creation extension of tus 1.0. Upload-Length must be the size declared for the document, and Upload-Metadata must hold its order_id and document_id. The URL of the upload is returned in the Location header. Only available to the order owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resumable Uploads"
+                ],
+                "summary": "Start a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protocol version, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of the file in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys and base64 values, with order_id and document_id",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the upload"
+                            },
+                            "Upload-Expires": {
+                                "type": "string",
+                                "description": "Expiry of the upload, RFC 7231 date"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid length or metadata, or the length is not the declared size",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or document not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order not awaiting its documents, or the document already has a file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported protocol version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create upload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "Tells the version, extensions and maximum size supported by the tus server, in the Tus-Version, Tus-Extension and Tus-Max-Size headers.",
+                "tags": [
+                    "Resumable Uploads"
+                ],
+                "summary": "Discover the resumable upload server",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "Tus-Extension": {
+                                "type": "string",
+                                "description": "creation,expiration,termination"
+                            },
+                            "Tus-Max-Size": {
+                                "type": "integer",
+                                "description": "Maximum upload size in bytes"
+                            },
+                            "Tus-Resumable": {
+                                "type": "string",
+                                "description": "1.0.0"
+                            },
+                            "Tus-Version": {
+                                "type": "string",
+                                "description": "1.0.0"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/resumable-uploads/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Abandons an upload and deletes the bytes received, following the termination extension of tus 1.0. A completed upload stays attached to its document. Only available to the owner of the upload.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resumable Uploads"
+                ],
+                "summary": "Terminate a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protocol version, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The upload is receiving another request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported protocol version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to terminate upload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tells how many bytes of the upload were received, in the Upload-Offset header, so the client resumes from there. Only available to the owner of the upload.",
+                "tags": [
+                    "Resumable Uploads"
+                ],
+                "summary": "Get the offset of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protocol version, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "Upload-Expires": {
+                                "type": "string",
+                                "description": "Expiry of the upload, RFC 7231 date"
+                            },
+                            "Upload-Length": {
+                                "type": "integer",
+                                "description": "Size of the file in bytes"
+                            },
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Upload not found or expired"
+                    },
+                    "412": {
+                        "description": "Unsupported protocol version"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appends the body to the upload, from the offset given in Upload-Offset, which must be the bytes received so far. When the connection drops, the bytes received are kept and the client resumes from the offset returned by HEAD. With the last byte the file is attached to its document, and the order is confirmed with POST /orders/{id}/uploads/confirm. Only available to the owner of the upload.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resumable Uploads"
+                ],
+                "summary": "Append to a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protocol version, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the body in the file",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "Upload-Expires": {
+                                "type": "string",
+                                "description": "Expiry of the upload, RFC 7231 date"
+                            },
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid offset, body past the upload length, or too many parts",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Offset does not match the bytes received, the upload is receiving another request, the order is not awaiting its documents, or the document already has a file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported protocol version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Content-Type is not application/offset+octet-stream",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to store upload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/notifications/deliver": {
+            "post": {
+                "description": "Sends the queued emails and SMS whose next attempt is due. Failed attempts are retried later with an exponential backoff, and given up after the last attempt. Also runs every minute in the background. Requires the task token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System Tasks"
+                ],
+                "summary": "Deliver notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shared task secret",
+                        "name": "X-Printly-Task-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationDeliveryReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid task token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to deliver notifications",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/order/cleanup": {
+            "post": {
+                "description": "Deletes from storage the files of printed documents, of orders that are over, and of orders older than the maximum age. Failed deletions are retried on the next run. Also runs periodically in the background. Requires the task token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System Tasks"
+                ],
+                "summary": "Purge documents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shared task secret",
+                        "name": "X-Printly-Task-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DocumentRetentionReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid task token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to purge documents",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/order/timeout": {
+            "post": {
+                "description": "Cancels the orders left too long awaiting their documents or their payment, and deletes their files from storage. Also runs periodically in the background. Requires the task token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System Tasks"
+                ],
+                "summary": "Expire unpaid orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shared task secret",
+                        "name": "X-Printly-Task-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderExpiryReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid task token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to expire orders",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/uploads/cleanup": {
+            "post": {
+                "description": "Deletes the expired resumable uploads, and from storage the parts of those never completed. Also runs periodically in the background. Requires the task token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System Tasks"
+                ],
+                "summary": "Purge resumable uploads",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shared task secret",
+                        "name": "X-Printly-Task-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResumableUploadPurgeReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid task token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to purge uploads",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{path}": {
+            "put": {
+                "description": "Receives the file of a document at an upload URL signed by the server, when files are stored locally. The Content-Type header must be the one the URL was issued for. A file is only accepted once, while its order awaits its documents. With cloud storage the signed URLs point to the bucket and this route does not exist.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Upload a file to a signed upload URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Storage path of the file",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry of the URL, in Unix seconds",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the URL",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid expiry",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Upload URL is invalid or has expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "File already uploaded, or order no longer awaiting its documents",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to store file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of users, by registration date by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "uid, created_at, email, last_name or role, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "manager",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Only return users with this role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users registered at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users registered before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch users",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a new user in the system.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User to create",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to register user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile of the currently authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user's profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently deletes the account of the currently authenticated user from the system and Firebase.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete current user's account",
+                "responses": {
+                    "200": {
+                        "description": "Account deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete account",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows the currently authenticated user to update their profile information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update current user's profile",
+                "parameters": [
+                    {
+                        "description": "Profile data to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update profile",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of the emails and SMS sent or queued for the authenticated user, newest first by default, with their delivery status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of notifications to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "id, created_at or status, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return notifications in these statuses: PENDING, SENT or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return notifications created at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return notifications created before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Notification"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch notifications",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists one page of the orders of the authenticated user, newest first by default, with their documents and their price at the current rates of the print center.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders to skip, ignored with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "id, created_at, updated_at, status or total_cost, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return orders in these statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created at or after this date or time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return orders created before this time, or on or before this date",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.Page"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.UserOrder"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch orders",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves an order of the authenticated user with its documents and their price at the current rates of the print center.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get one of my orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single user by their unique identifier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user by UID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a user's profile information. Only email and phone number can be updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a user's profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update profile",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a user by their unique identifier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing user UID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payment": {
+            "post": {
+                "description": "Receives asynchronous payment notifications. The payload must be signed by the provider in the X-Printly-Signature header. Each event is applied once: replayed events, and events that would move a payment back such as a success after a refund, are acknowledged and ignored. A payment that settles after its order was cancelled or failed is refunded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payload signature",
+                        "name": "X-Printly-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process webhook",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.CancelOrderRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.CancelOrderResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_documents": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "order": {
+                    "$ref": "#/definitions/entity.Order"
+                },
+                "refund": {
+                    "$ref": "#/definitions/entity.Payment"
+                }
+            }
+        },
+        "dto.CreatePrintCenterRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "email",
+                "geo_coordinates",
+                "name",
+                "phone_number",
+                "working_hours"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/entity.Address"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "geo_coordinates": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Service"
+                    }
+                },
+                "working_hours": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entity.WorkingHour"
+                    }
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreatedOrder": {
+            "type": "object",
+            "required": [
+                "code",
+                "print_center_id",
+                "status",
+                "user_uid"
+            ],
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "Audit fields",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO currency code",
+                    "type": "string"
+                },
+                "documents": {
+                    "description": "Relationships",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Document"
+                    }
+                },
+                "id": {
+                    "description": "gorm.Model is replaced to be explicit for swagger",
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "pickup_time": {
+                    "description": "Timestamps",
+                    "type": "string"
+                },
+                "print_center_id": {
+                    "type": "integer"
+                },
+                "print_mode": {
+                    "description": "Pickup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PrintMode"
+                        }
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                },
+                "total_cost": {
+                    "description": "Pricing",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "tracking_token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "user_uid": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "description": "pickup code checked at the counter",
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeletionReceiptVerification": {
+            "type": "object",
+            "properties": {
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.DirectUploadDocumentRequest": {
+            "type": "object",
+            "required": [
+                "file_name",
+                "mime_type",
+                "print_mode",
+                "print_options",
+                "size"
+            ],
+            "properties": {
+                "file_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "mime_type": {
+                    "type": "string",
+                    "enum": [
+                        "application/pdf",
+                        "application/msword",
+                        "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+                        "text/plain",
+                        "image/jpeg",
+                        "image/jpg",
+                        "image/png"
+                    ]
+                },
+                "page_count": {
+                    "description": "Prices the order until the file is inspected, the page count of the file is used once it is uploaded",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "print_mode": {
+                    "enum": [
+                        "PRE_PRINT",
+                        "PRINT_UPON_ARRIVAL"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PrintMode"
+                        }
+                    ]
+                },
+                "print_options": {
+                    "$ref": "#/definitions/entity.PrintOptions"
+                },
+                "size": {
+                    "description": "50MB",
+                    "type": "integer",
+                    "maximum": 52428800,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.DirectUploadOrder": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/dto.CreatedOrder"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DocumentUpload"
+                    }
+                }
+            }
+        },
+        "dto.DirectUploadOrderRequest": {
+            "type": "object",
+            "required": [
+                "documents"
+            ],
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.DirectUploadDocumentRequest"
+                    }
+                }
+            }
+        },
+        "dto.DocumentPurgeReport": {
+            "type": "object",
+            "properties": {
+                "all_purged": {
+                    "type": "boolean"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DocumentPurgeState"
+                    }
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                }
+            }
+        },
+        "dto.DocumentPurgeState": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "printed_at": {
+                    "type": "string"
+                },
+                "storage_deleted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DocumentRetentionReport": {
+            "type": "object",
+            "properties": {
+                "deleted_documents": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "purged_orders": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.DocumentUpload": {
+            "type": "object",
+            "properties": {
+                "document_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers the upload must be sent with",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "example": "PUT"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "A description of the error"
+                }
+            }
+        },
+        "dto.JobStatus": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "last_run": {
+                    "$ref": "#/definitions/entity.JobRun"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "job-runs-cleanup"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "running": {
+                    "description": "on this replica",
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "0 3 * * *"
+                }
+            }
+        },
+        "dto.NotificationDeliveryReport": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "notifications given up after their last attempt",
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "retried": {
+                    "description": "failed attempts that will be retried later",
+                    "type": "integer"
+                },
+                "sent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OrderEvent": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID increases with every event published, and resumes a stream as its Last-Event-ID",
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "previous_status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                },
+                "print_center_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                },
+                "total_cost": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/dto.OrderEventType"
+                }
+            }
+        },
+        "dto.OrderEventType": {
+            "type": "string",
+            "enum": [
+                "order.created",
+                "order.paid",
+                "order.status_changed",
+                "order.cancelled"
+            ],
+            "x-enum-varnames": [
+                "OrderEventCreated",
+                "OrderEventPaid",
+                "OrderEventStatusChanged",
+                "OrderEventCancelled"
+            ]
+        },
+        "dto.OrderExpiryReport": {
+            "type": "object",
+            "properties": {
+                "deleted_documents": {
+                    "type": "integer"
+                },
+                "expired_orders": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.OrderStatusResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "A1B2C3"
+                },
+                "pickup_time": {
+                    "type": "string"
+                },
+                "print_center_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.OrderStatus"
+                        }
+                    ],
+                    "example": "READY_FOR_PICKUP"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OrderTracking": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "A1B2C3"
+                },
+                "estimated_ready_at": {
+                    "description": "EstimatedReadyAt is when the order should be printed, while it waits in the queue or is being printed",
+                    "type": "string"
+                },
+                "pickup_time": {
+                    "type": "string"
+                },
+                "queue_position": {
+                    "description": "QueuePosition is 1 when the order is printed next. It is only set while the order waits to be printed.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.OrderStatus"
+                        }
+                    ],
+                    "example": "READY_TO_PRINT"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Page": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items is the slice of listed items"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page when passed as the cursor parameter, empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total counts the items matching the filters, on all pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.Quote": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuoteLine"
+                    }
+                },
+                "total": {
+                    "description": "in cents",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.QuoteDocumentRequest": {
+            "type": "object",
+            "required": [
+                "page_count",
+                "print_options"
+            ],
+            "properties": {
+                "file_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "page_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "print_options": {
+                    "$ref": "#/definitions/entity.PrintOptions"
+                }
+            }
+        },
+        "dto.QuoteLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "in cents",
+                    "type": "integer"
+                },
+                "color": {
+                    "$ref": "#/definitions/entity.ColorMode"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "double_sided": {
+                    "type": "boolean"
+                },
+                "estimated": {
+                    "description": "Set when the page count could not be read from the file and was estimated from its size",
+                    "type": "boolean"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "pages": {
+                    "description": "selected pages per copy",
+                    "type": "integer"
+                },
+                "paper_size": {
+                    "$ref": "#/definitions/entity.PaperSize"
+                },
+                "pricing_unit": {
+                    "$ref": "#/definitions/entity.PricingUnit"
+                },
+                "quantity": {
+                    "description": "charged units, sheets or sides",
+                    "type": "integer"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "sheets": {
+                    "description": "for all copies",
+                    "type": "integer"
+                },
+                "sides": {
+                    "description": "for all copies",
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "description": "in cents, after volume tiers",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.QuoteRequest": {
+            "type": "object",
+            "required": [
+                "documents"
+            ],
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.QuoteDocumentRequest"
+                    }
+                }
+            }
+        },
+        "dto.ResumableUploadPurgeReport": {
+            "type": "object",
+            "properties": {
+                "deleted_parts": {
+                    "type": "integer"
                 },
-                "working_hours": {
+                "deleted_uploads": {
+                    "type": "integer"
+                },
+                "failures": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/entity.WorkingHour"
+                        "type": "string"
                     }
                 }
             }
         },
-        "dto.CreateUserRequest": {
+        "dto.ScheduleOrderRequest": {
             "type": "object",
             "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "password"
+                "pickup_time",
+                "print_mode"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "last_name": {
-                    "type": "string"
+                "pickup_time": {
+                    "type": "string",
+                    "example": "2025-06-25T10:30:00Z"
                 },
-                "password": {
-                    "type": "string"
+                "print_mode": {
+                    "enum": [
+                        "PRE_PRINT",
+                        "PRINT_UPON_ARRIVAL"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PrintMode"
+                        }
+                    ],
+                    "example": "PRINT_UPON_ARRIVAL"
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.SimulatePaymentRequest": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "A description of the error"
+                "status": {
+                    "description": "Either SUCCEEDED or FAILED. Defaults to SUCCEEDED.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PaymentStatus"
+                        }
+                    ]
                 }
             }
         },
@@ -1259,6 +4563,10 @@ const docTemplate = `{
                 "status"
             ],
             "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "status": {
                     "$ref": "#/definitions/entity.OrderStatus"
                 }
@@ -1331,6 +4639,18 @@ const docTemplate = `{
                 },
                 "last_name": {
                     "type": "string"
+                },
+                "locale": {
+                    "description": "Locale is the language of the notifications",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "fr"
+                    ]
+                },
+                "phone_number": {
+                    "description": "PhoneNumber receives the SMS notifications, in E.164 format",
+                    "type": "string"
                 }
             }
         },
@@ -1350,6 +4670,105 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserOrder": {
+            "type": "object",
+            "required": [
+                "code",
+                "print_center_id",
+                "status",
+                "user_uid"
+            ],
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "Audit fields",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO currency code",
+                    "type": "string"
+                },
+                "documents": {
+                    "description": "Relationships",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Document"
+                    }
+                },
+                "id": {
+                    "description": "gorm.Model is replaced to be explicit for swagger",
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "pickup_time": {
+                    "description": "Timestamps",
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/dto.Quote"
+                },
+                "print_center_id": {
+                    "type": "integer"
+                },
+                "print_mode": {
+                    "description": "Pickup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PrintMode"
+                        }
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                },
+                "total_cost": {
+                    "description": "Pricing",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "user_uid": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "description": "pickup code checked at the counter",
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VerifyPickupRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "A1B2C3"
+                },
+                "token": {
+                    "description": "scanned QR code payload",
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
         "entity.Address": {
             "type": "object",
             "required": [
@@ -1364,85 +4783,327 @@ const docTemplate = `{
                     "maxLength": 50,
                     "minLength": 2
                 },
-                "number": {
+                "number": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ColorMode": {
+            "type": "string",
+            "enum": [
+                "COLOR",
+                "BLACK_AND_WHITE"
+            ],
+            "x-enum-varnames": [
+                "Color",
+                "BlackAndWhite"
+            ]
+        },
+        "entity.DeletionReceipt": {
+            "type": "object",
+            "required": [
+                "deleted_at",
+                "document_id",
+                "file_name_sha256",
+                "order_code",
+                "signature",
+                "storage_backend",
+                "version"
+            ],
+            "properties": {
+                "content_sha256": {
+                    "description": "empty for files uploaded before hashing",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "document_id": {
+                    "type": "integer"
+                },
+                "file_name_sha256": {
+                    "type": "string"
+                },
+                "order_code": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "storage_backend": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Document": {
+            "type": "object",
+            "required": [
+                "file_name",
+                "mime_type"
+            ],
+            "properties": {
+                "encrypted": {
+                    "type": "boolean"
+                },
+                "file_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "page_count": {
+                    "description": "Filled by inspecting the file at upload time. PageCount is 0 when it can not be known before printing.",
+                    "type": "integer"
+                },
+                "page_height": {
+                    "description": "in points, first page",
+                    "type": "number"
+                },
+                "page_width": {
+                    "description": "in points, first page",
+                    "type": "number"
+                },
+                "print_mode": {
+                    "$ref": "#/definitions/entity.PrintMode"
+                },
+                "print_options": {
+                    "$ref": "#/definitions/entity.PrintOptions"
+                },
+                "printed_at": {
+                    "type": "string"
+                },
+                "scan": {
+                    "description": "Malware scan of the uploaded file, before the document is accepted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ScanVerdict"
+                        }
+                    ]
+                },
+                "selected_pages": {
+                    "description": "Pages to print resolved from PrintOptions.Pages, empty when the page count is unknown",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "size": {
+                    "description": "50MB limit",
+                    "type": "integer",
+                    "maximum": 52428800,
+                    "minimum": 1
+                },
+                "storage_deleted_at": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "lng": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "entity.JobRun": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "street": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "finished_at": {
+                    "type": "string"
                 },
-                "type": {
+                "holder": {
+                    "description": "replica that ran the job",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_name": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.JobRunStatus"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/entity.JobTrigger"
+                },
+                "triggered_by": {
+                    "description": "UID of the admin for manual runs",
                     "type": "string"
                 }
             }
         },
-        "entity.ColorMode": {
+        "entity.JobRunStatus": {
             "type": "string",
             "enum": [
-                "COLOR",
-                "BLACK_AND_WHITE"
+                "RUNNING",
+                "SUCCEEDED",
+                "FAILED"
             ],
             "x-enum-varnames": [
-                "Color",
-                "BlackAndWhite"
+                "JobRunning",
+                "JobSucceeded",
+                "JobFailed"
             ]
         },
-        "entity.Document": {
-            "type": "object",
-            "required": [
-                "file_name",
-                "mime_type"
+        "entity.JobTrigger": {
+            "type": "string",
+            "enum": [
+                "SCHEDULE",
+                "MANUAL"
             ],
+            "x-enum-varnames": [
+                "TriggerSchedule",
+                "TriggerManual"
+            ]
+        },
+        "entity.Notification": {
+            "type": "object",
             "properties": {
-                "file_name": {
-                    "type": "string",
-                    "maxLength": 255
+                "attempts": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "channel": {
+                    "$ref": "#/definitions/entity.NotificationChannel"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/entity.NotificationEvent"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "mime_type": {
+                "last_error": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
                     "type": "string"
                 },
                 "order_id": {
                     "type": "integer"
                 },
-                "print_options": {
-                    "$ref": "#/definitions/entity.PrintOptions"
+                "print_center_id": {
+                    "type": "integer"
                 },
-                "printed_at": {
+                "recipient": {
+                    "description": "email address or phone number",
                     "type": "string"
                 },
-                "size": {
-                    "description": "StoragePath string     ` + "`" + `gorm:\"type:text\" json:\"-\"` + "`" + `                 // Internal storage path",
-                    "type": "integer",
-                    "maximum": 52428800,
-                    "minimum": 1
+                "sent_at": {
+                    "type": "string"
                 },
-                "storage_deleted_at": {
+                "status": {
+                    "description": "Delivery",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.NotificationStatus"
+                        }
+                    ]
+                },
+                "subject": {
                     "type": "string"
                 },
-                "uploaded_at": {
+                "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "entity.GeoPoint": {
-            "type": "object",
-            "properties": {
-                "lat": {
-                    "type": "number",
-                    "maximum": 90,
-                    "minimum": -90
                 },
-                "lng": {
-                    "type": "number",
-                    "maximum": 180,
-                    "minimum": -180
+                "user_uid": {
+                    "type": "string"
                 }
             }
         },
+        "entity.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "email",
+                "sms"
+            ],
+            "x-enum-varnames": [
+                "ChannelEmail",
+                "ChannelSMS"
+            ]
+        },
+        "entity.NotificationEvent": {
+            "type": "string",
+            "enum": [
+                "order_paid",
+                "order_ready_for_pickup",
+                "order_expiring_soon",
+                "order_cancelled",
+                "center_approved"
+            ],
+            "x-enum-varnames": [
+                "NotifyOrderPaid",
+                "NotifyOrderReadyForPickup",
+                "NotifyOrderExpiringSoon",
+                "NotifyOrderCancelled",
+                "NotifyCenterApproved"
+            ]
+        },
+        "entity.NotificationStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SENT",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "NotificationFailed": "given up after the last attempt"
+            },
+            "x-enum-varnames": [
+                "NotificationPending",
+                "NotificationSent",
+                "NotificationFailed"
+            ]
+        },
         "entity.Order": {
             "type": "object",
             "required": [
@@ -1490,6 +5151,14 @@ const docTemplate = `{
                 "print_center_id": {
                     "type": "integer"
                 },
+                "print_mode": {
+                    "description": "Pickup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PrintMode"
+                        }
+                    ]
+                },
                 "status": {
                     "$ref": "#/definitions/entity.OrderStatus"
                 },
@@ -1506,6 +5175,13 @@ const docTemplate = `{
                 },
                 "user_uid": {
                     "type": "string"
+                },
+                "verified_at": {
+                    "description": "pickup code checked at the counter",
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
                 }
             }
         },
@@ -1540,6 +5216,35 @@ const docTemplate = `{
                 "StatusFailed"
             ]
         },
+        "entity.OrderStatusHistory": {
+            "type": "object",
+            "properties": {
+                "actor_role": {
+                    "$ref": "#/definitions/entity.Role"
+                },
+                "actor_uid": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/entity.OrderStatus"
+                }
+            }
+        },
         "entity.PaperSize": {
             "type": "string",
             "enum": [
@@ -1555,6 +5260,104 @@ const docTemplate = `{
                 "A6"
             ]
         },
+        "entity.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "in cents",
+                    "type": "integer"
+                },
+                "checkout_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO currency code",
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "refund_id": {
+                    "type": "string"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.PaymentStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SUCCEEDED",
+                "FAILED",
+                "REFUND_PENDING",
+                "REFUNDED"
+            ],
+            "x-enum-varnames": [
+                "PaymentPending",
+                "PaymentSucceeded",
+                "PaymentFailed",
+                "PaymentRefundPending",
+                "PaymentRefunded"
+            ]
+        },
+        "entity.PriceTier": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "price": {
+                    "description": "in cents, per unit",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "entity.PricingUnit": {
+            "type": "string",
+            "enum": [
+                "PER_SHEET",
+                "PER_SIDE"
+            ],
+            "x-enum-comments": {
+                "PerSheet": "A duplex sheet costs the same as a simplex one",
+                "PerSide": "Every printed side is charged"
+            },
+            "x-enum-varnames": [
+                "PerSheet",
+                "PerSide"
+            ]
+        },
         "entity.PrintCenter": {
             "type": "object",
             "required": [
@@ -1570,6 +5373,10 @@ const docTemplate = `{
                     "description": "Expose creation time",
                     "type": "string"
                 },
+                "currency": {
+                    "description": "ISO currency code of the services prices",
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1627,6 +5434,17 @@ const docTemplate = `{
                 "StatusSuspended"
             ]
         },
+        "entity.PrintMode": {
+            "type": "string",
+            "enum": [
+                "PRE_PRINT",
+                "PRINT_UPON_ARRIVAL"
+            ],
+            "x-enum-varnames": [
+                "PrePrint",
+                "PrintUponArrival"
+            ]
+        },
         "entity.PrintOptions": {
             "type": "object",
             "required": [
@@ -1647,7 +5465,7 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "pages": {
-                    "description": "e.g., \"1-3,5\" - add custom validation",
+                    "description": "e.g. \"1-3,5\", \"all\", \"odd\", \"even\"",
                     "type": "string"
                 },
                 "paper_size": {
@@ -1660,14 +5478,45 @@ const docTemplate = `{
             "enum": [
                 "user",
                 "manager",
-                "admin"
+                "admin",
+                "system"
             ],
             "x-enum-varnames": [
                 "RoleUser",
                 "RoleManager",
-                "RoleAdmin"
+                "RoleAdmin",
+                "RoleSystem"
+            ]
+        },
+        "entity.ScanStatus": {
+            "type": "string",
+            "enum": [
+                "CLEAN",
+                "INFECTED"
+            ],
+            "x-enum-varnames": [
+                "ScanClean",
+                "ScanInfected"
             ]
         },
+        "entity.ScanVerdict": {
+            "type": "object",
+            "properties": {
+                "engine": {
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Name of the malware found",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.ScanStatus"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.Service": {
             "type": "object",
             "required": [
@@ -1675,6 +5524,18 @@ const docTemplate = `{
                 "paper_size"
             ],
             "properties": {
+                "color_mode": {
+                    "description": "Empty color mode means the service applies to both color and black and white prints",
+                    "enum": [
+                        "COLOR",
+                        "BLACK_AND_WHITE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ColorMode"
+                        }
+                    ]
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 500
@@ -1690,6 +5551,23 @@ const docTemplate = `{
                 "price": {
                     "type": "integer",
                     "minimum": 0
+                },
+                "pricing_unit": {
+                    "enum": [
+                        "PER_SHEET",
+                        "PER_SIDE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PricingUnit"
+                        }
+                    ]
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PriceTier"
+                    }
                 }
             }
         },
@@ -1715,6 +5593,14 @@ const docTemplate = `{
                 "last_name": {
                     "type": "string"
                 },
+                "locale": {
+                    "description": "Language of the notifications, the platform default when empty",
+                    "type": "string"
+                },
+                "phone_number": {
+                    "description": "E.164, where SMS notifications are sent",
+                    "type": "string"
+                },
                 "role": {
                     "description": "\"user\", \"manager\", \"admin\"",
                     "allOf": [
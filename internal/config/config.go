@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -28,6 +29,9 @@ type StorageConfig struct {
 type LocalStorageConfig struct {
 	BasePath string // Base directory for file storage (e.g., "./uploads")
 	BaseURL  string // Base URL for serving files (e.g., "http://localhost:8080/files")
+	// Base URL of the signed upload route (e.g., "http://localhost:8080/api/v1/uploads")
+	UploadURL     string
+	SigningSecret string // Secret used to sign the upload URLs
 }
 
 // GCSStorageConfig holds configuration for Google Cloud Storage
@@ -39,6 +43,125 @@ type GCSStorageConfig struct {
 	UseApplicationDefault bool   // Use application default credentials
 }
 
+// PaymentProvider represents the payment gateway backend
+type PaymentProvider string
+
+const (
+	// PaymentProviderNone disables payments, the payment routes answer 503 until a provider is configured
+	PaymentProviderNone PaymentProvider = "none"
+	// PaymentProviderMock never moves real money, for development
+	PaymentProviderMock PaymentProvider = "mock"
+)
+
+// PaymentConfig holds configuration for the payment gateway
+type PaymentConfig struct {
+	Provider        PaymentProvider
+	WebhookSecret   string // Shared secret used to sign webhook payloads
+	CheckoutBaseURL string // Base URL the checkout sessions redirect to
+}
+
+// NotificationProvider represents the backend sending the notifications of a channel
+type NotificationProvider string
+
+const (
+	// NotificationProviderNone disables the channel
+	NotificationProviderNone NotificationProvider = "none"
+	// NotificationProviderLog writes the notifications to a file or to the application log, for development
+	NotificationProviderLog  NotificationProvider = "log"
+	NotificationProviderSMTP NotificationProvider = "smtp"
+	NotificationProviderHTTP NotificationProvider = "http"
+)
+
+// NotificationConfig holds configuration for the email and SMS notifications
+type NotificationConfig struct {
+	DefaultLocale string               // Language of the notifications of users without one
+	EmailProvider NotificationProvider // "smtp", "log" or "none"
+	SMSProvider   NotificationProvider // "http", "log" or "none"
+	LogFile       string               // File the log provider appends to, the application log when empty
+	SMTP          SMTPConfig
+	SMS           SMSConfig
+}
+
+// SMTPConfig holds configuration for sending emails through an SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // Authentication is skipped when empty
+	Password string
+	From     string // Sender address of the emails
+}
+
+// SMSConfig holds configuration for a generic SMS HTTP API. Messages are posted as
+// JSON objects with "from", "to" and "message" fields.
+type SMSConfig struct {
+	URL    string
+	APIKey string // Sent as a bearer token
+	Sender string // Sender ID or number of the messages
+}
+
+// ScannerProvider represents the malware scanner uploaded files are checked with
+type ScannerProvider string
+
+const (
+	ScannerProviderClamd ScannerProvider = "clamd"
+	// ScannerProviderStub only flags the EICAR test file, for development and tests
+	ScannerProviderStub ScannerProvider = "stub"
+)
+
+// ScannerConfig holds configuration for the malware scanning of uploaded files
+type ScannerConfig struct {
+	Provider ScannerProvider // "clamd" or "stub"
+	Clamd    ClamdConfig
+}
+
+// ClamdConfig holds configuration for scanning files with a ClamAV daemon
+type ClamdConfig struct {
+	Network string        // "tcp" or "unix"
+	Address string        // host:port, or the path of the socket
+	Timeout time.Duration // Time to scan one file, connection included
+}
+
+// PickupConfig holds configuration for the pickup QR codes
+type PickupConfig struct {
+	SigningSecret string        // Secret used to sign the QR code payloads
+	TokenTTL      time.Duration // How long a QR code is accepted at the counter
+}
+
+// OrderExpiryConfig holds how long unpaid orders are kept in each status before being cancelled
+type OrderExpiryConfig struct {
+	AwaitingDocumentTTL time.Duration
+	PendingPaymentTTL   time.Duration
+	WarnBefore          time.Duration // Customers are notified this long before their order expires
+}
+
+// TrackingConfig holds configuration for the live order tracking
+type TrackingConfig struct {
+	OrderPrintTime time.Duration // Average time a center takes to print an order, used to estimate ready times
+}
+
+// DocumentRetentionConfig holds how long uploaded files may be kept in storage
+type DocumentRetentionConfig struct {
+	MaxAge time.Duration // Files of orders older than this are deleted, whatever the order status
+}
+
+// ReceiptConfig holds configuration for the document deletion receipts
+type ReceiptConfig struct {
+	SigningSecret string // Secret used to sign the receipts
+}
+
+// TimeoutConfig holds the timeouts of the HTTP server
+type TimeoutConfig struct {
+	Read   time.Duration // Time to read a request, headers and body
+	Write  time.Duration // Time to handle a request and write its response
+	Idle   time.Duration // Time a keep-alive connection waits for the next request
+	Upload time.Duration // Read and write time of the routes receiving files, replacing Read and Write
+}
+
+// TasksConfig holds configuration for the internal task endpoints
+type TasksConfig struct {
+	Secret string // Shared secret expected in the X-Printly-Task-Token header, the endpoints are disabled when empty
+}
+
 type Config struct {
 	AppEnv                  string
 	DBDriver                string // "sqlite", "postgres", etc.
@@ -46,7 +169,17 @@ type Config struct {
 	Host                    string
 	Port                    string
 	FirebaseCredentialsFile string
+	Timeouts                TimeoutConfig
 	Storage                 StorageConfig
+	Payment                 PaymentConfig
+	Pickup                  PickupConfig
+	OrderExpiry             OrderExpiryConfig
+	Tracking                TrackingConfig
+	DocumentRetention       DocumentRetentionConfig
+	Receipts                ReceiptConfig
+	Tasks                   TasksConfig
+	Notifications           NotificationConfig
+	Scanner                 ScannerConfig
 }
 
 func getEnv(key, fallback string) string {
@@ -71,6 +204,20 @@ func getEnvBool(key string, fallback bool) bool {
 	return boolVal
 }
 
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(val)
+	if err != nil {
+		log.Printf("⚠️ Invalid duration value for %s: %s, using fallback: %s", key, val, fallback)
+		return fallback
+	}
+	return duration
+}
+
 func Load() *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️ No .env file found, loading from system ENV only")
@@ -84,6 +231,43 @@ func Load() *Config {
 		Port:                    getEnv("PORT", "8080"),
 		FirebaseCredentialsFile: getEnv("FIREBASE_CREDENTIALS_FILE", "FIREBASE_CREDENTIALS_FILE_NOT_FOUND"),
 		Storage:                 loadStorageConfig(),
+		Payment:                 loadPaymentConfig(),
+		Timeouts: TimeoutConfig{
+			Read:   getEnvDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+			Write:  getEnvDuration("SERVER_WRITE_TIMEOUT", 15*time.Second),
+			Idle:   getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			Upload: getEnvDuration("SERVER_UPLOAD_TIMEOUT", 10*time.Minute),
+		},
+		Pickup: PickupConfig{
+			SigningSecret: getEnv("PICKUP_SIGNING_SECRET", ""),
+			TokenTTL:      getEnvDuration("PICKUP_TOKEN_TTL", 24*time.Hour),
+		},
+		OrderExpiry: OrderExpiryConfig{
+			AwaitingDocumentTTL: getEnvDuration("ORDER_AWAITING_DOCUMENT_TTL", 2*time.Hour),
+			PendingPaymentTTL:   getEnvDuration("ORDER_PENDING_PAYMENT_TTL", 24*time.Hour),
+			WarnBefore:          getEnvDuration("ORDER_EXPIRY_WARNING", 2*time.Hour),
+		},
+		Tracking: TrackingConfig{
+			OrderPrintTime: getEnvDuration("ORDER_PRINT_TIME", 5*time.Minute),
+		},
+		DocumentRetention: DocumentRetentionConfig{
+			MaxAge: getEnvDuration("DOCUMENT_MAX_AGE", 7*24*time.Hour),
+		},
+		Receipts: ReceiptConfig{
+			SigningSecret: getEnv("RECEIPT_SIGNING_SECRET", ""),
+		},
+		Tasks: TasksConfig{
+			Secret: getEnv("TASKS_SECRET", ""),
+		},
+		Notifications: loadNotificationConfig(),
+		Scanner: ScannerConfig{
+			Provider: ScannerProvider(getEnv("SCANNER_PROVIDER", "stub")),
+			Clamd: ClamdConfig{
+				Network: getEnv("CLAMD_NETWORK", "tcp"),
+				Address: getEnv("CLAMD_ADDRESS", "localhost:3310"),
+				Timeout: getEnvDuration("CLAMD_TIMEOUT", 2*time.Minute),
+			},
+		},
 	}
 
 	return cfg
@@ -98,10 +282,7 @@ func loadStorageConfig() StorageConfig {
 
 	switch config.Type {
 	case StorageTypeLocal:
-		config.Local = LocalStorageConfig{
-			BasePath: getEnv("STORAGE_LOCAL_BASE_PATH", "./uploads"),
-			BaseURL:  getEnv("STORAGE_LOCAL_BASE_URL", "http://localhost:8080/files"),
-		}
+		config.Local = loadLocalStorageConfig()
 	case StorageTypeGCS:
 		config.GCS = GCSStorageConfig{
 			BucketName:            getEnv("STORAGE_GCS_BUCKET_NAME", ""),
@@ -113,15 +294,50 @@ func loadStorageConfig() StorageConfig {
 	default:
 		log.Printf("⚠️ Unsupported storage type: %s, defaulting to local", storageType)
 		config.Type = StorageTypeLocal
-		config.Local = LocalStorageConfig{
-			BasePath: getEnv("STORAGE_LOCAL_BASE_PATH", "./uploads"),
-			BaseURL:  getEnv("STORAGE_LOCAL_BASE_URL", "http://localhost:8080/files"),
-		}
+		config.Local = loadLocalStorageConfig()
 	}
 
 	return config
 }
 
+func loadLocalStorageConfig() LocalStorageConfig {
+	return LocalStorageConfig{
+		BasePath:      getEnv("STORAGE_LOCAL_BASE_PATH", "./uploads"),
+		BaseURL:       getEnv("STORAGE_LOCAL_BASE_URL", "http://localhost:8080/files"),
+		UploadURL:     getEnv("STORAGE_LOCAL_UPLOAD_URL", "http://localhost:8080/api/v1/uploads"),
+		SigningSecret: getEnv("STORAGE_LOCAL_SIGNING_SECRET", ""),
+	}
+}
+
+func loadPaymentConfig() PaymentConfig {
+	return PaymentConfig{
+		Provider:        PaymentProvider(getEnv("PAYMENT_PROVIDER", "mock")),
+		WebhookSecret:   getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		CheckoutBaseURL: getEnv("PAYMENT_CHECKOUT_BASE_URL", "http://localhost:8080/api/v1/mock/payments"),
+	}
+}
+
+func loadNotificationConfig() NotificationConfig {
+	return NotificationConfig{
+		DefaultLocale: getEnv("NOTIFY_DEFAULT_LOCALE", "en"),
+		EmailProvider: NotificationProvider(getEnv("NOTIFY_EMAIL_PROVIDER", "log")),
+		SMSProvider:   NotificationProvider(getEnv("NOTIFY_SMS_PROVIDER", "log")),
+		LogFile:       getEnv("NOTIFY_LOG_FILE", ""),
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		SMS: SMSConfig{
+			URL:    getEnv("SMS_API_URL", ""),
+			APIKey: getEnv("SMS_API_KEY", ""),
+			Sender: getEnv("SMS_SENDER", "Printly"),
+		},
+	}
+}
+
 // ValidateConfig validates the loaded configuration
 func (c *Config) ValidateConfig() error {
 	// Validate storage configuration
@@ -133,6 +349,12 @@ func (c *Config) ValidateConfig() error {
 		if c.Storage.Local.BaseURL == "" {
 			return fmt.Errorf("local storage base URL is required")
 		}
+		if c.Storage.Local.UploadURL == "" {
+			return fmt.Errorf("local storage upload URL is required")
+		}
+		if c.Storage.Local.SigningSecret == "" {
+			return fmt.Errorf("local storage signing secret is required")
+		}
 	case StorageTypeGCS:
 		if c.Storage.GCS.BucketName == "" {
 			return fmt.Errorf("GCS bucket name is required")
@@ -145,6 +367,96 @@ func (c *Config) ValidateConfig() error {
 		}
 	}
 
+	// Validate payment configuration
+	switch c.Payment.Provider {
+	case PaymentProviderNone:
+	case PaymentProviderMock:
+		if c.IsProduction() {
+			return fmt.Errorf("mock payment provider cannot be used in production, use %q to disable payments", PaymentProviderNone)
+		}
+		if c.Payment.WebhookSecret == "" {
+			return fmt.Errorf("payment webhook secret is required")
+		}
+	default:
+		return fmt.Errorf("unsupported payment provider: %s", c.Payment.Provider)
+	}
+
+	// Validate pickup configuration
+	if c.Pickup.SigningSecret == "" {
+		return fmt.Errorf("pickup signing secret is required")
+	}
+	if c.Pickup.TokenTTL <= 0 {
+		return fmt.Errorf("pickup token TTL must be positive")
+	}
+
+	// Validate order expiry configuration
+	if c.OrderExpiry.AwaitingDocumentTTL <= 0 || c.OrderExpiry.PendingPaymentTTL <= 0 {
+		return fmt.Errorf("order expiry TTLs must be positive")
+	}
+	if c.OrderExpiry.WarnBefore < 0 {
+		return fmt.Errorf("order expiry warning must not be negative")
+	}
+
+	// Validate server timeouts
+	if c.Timeouts.Read <= 0 || c.Timeouts.Write <= 0 || c.Timeouts.Idle <= 0 || c.Timeouts.Upload <= 0 {
+		return fmt.Errorf("server timeouts must be positive")
+	}
+
+	// Validate tracking configuration
+	if c.Tracking.OrderPrintTime <= 0 {
+		return fmt.Errorf("order print time must be positive")
+	}
+
+	// Validate document retention configuration
+	if c.DocumentRetention.MaxAge <= 0 {
+		return fmt.Errorf("document max age must be positive")
+	}
+
+	// Validate receipt configuration
+	if c.Receipts.SigningSecret == "" {
+		return fmt.Errorf("receipt signing secret is required")
+	}
+
+	// Validate notification configuration
+	switch c.Notifications.EmailProvider {
+	case NotificationProviderNone, NotificationProviderLog:
+	case NotificationProviderSMTP:
+		if c.Notifications.SMTP.Host == "" || c.Notifications.SMTP.From == "" {
+			return fmt.Errorf("SMTP host and sender address are required")
+		}
+	default:
+		return fmt.Errorf("unsupported email provider: %s", c.Notifications.EmailProvider)
+	}
+	switch c.Notifications.SMSProvider {
+	case NotificationProviderNone, NotificationProviderLog:
+	case NotificationProviderHTTP:
+		if c.Notifications.SMS.URL == "" {
+			return fmt.Errorf("SMS API URL is required")
+		}
+	default:
+		return fmt.Errorf("unsupported SMS provider: %s", c.Notifications.SMSProvider)
+	}
+
+	// Validate scanner configuration
+	switch c.Scanner.Provider {
+	case ScannerProviderStub:
+		if c.IsProduction() {
+			return fmt.Errorf("stub malware scanner cannot be used in production")
+		}
+	case ScannerProviderClamd:
+		if c.Scanner.Clamd.Network != "tcp" && c.Scanner.Clamd.Network != "unix" {
+			return fmt.Errorf("unsupported clamd network: %s", c.Scanner.Clamd.Network)
+		}
+		if c.Scanner.Clamd.Address == "" {
+			return fmt.Errorf("clamd address is required")
+		}
+		if c.Scanner.Clamd.Timeout <= 0 {
+			return fmt.Errorf("clamd timeout must be positive")
+		}
+	default:
+		return fmt.Errorf("unsupported malware scanner: %s", c.Scanner.Provider)
+	}
+
 	// Validate other configuration fields
 	if c.Port == "" {
 		return fmt.Errorf("port is required")
@@ -195,6 +507,7 @@ func (c *Config) PrintConfig() {
 	case StorageTypeLocal:
 		log.Printf("  Local Storage Path: %s", c.Storage.Local.BasePath)
 		log.Printf("  Local Storage URL: %s", c.Storage.Local.BaseURL)
+		log.Printf("  Local Storage Upload URL: %s", c.Storage.Local.UploadURL)
 	case StorageTypeGCS:
 		log.Printf("  GCS Bucket: %s", c.Storage.GCS.BucketName)
 		log.Printf("  GCS Project ID: %s", c.Storage.GCS.ProjectID)
@@ -206,4 +519,12 @@ func (c *Config) PrintConfig() {
 			log.Printf("  GCS Credentials JSON: [PROVIDED]")
 		}
 	}
+
+	log.Printf("  Payment Provider: %s", c.Payment.Provider)
+	log.Printf("  Email Provider: %s", c.Notifications.EmailProvider)
+	log.Printf("  SMS Provider: %s", c.Notifications.SMSProvider)
+	log.Printf("  Malware Scanner: %s", c.Scanner.Provider)
+	if c.Scanner.Provider == ScannerProviderClamd {
+		log.Printf("  Clamd Address: %s://%s", c.Scanner.Clamd.Network, c.Scanner.Clamd.Address)
+	}
 }
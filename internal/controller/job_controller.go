@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+type JobController interface {
+	ListJobs(ctx *gin.Context)
+	TriggerJob(ctx *gin.Context)
+}
+
+type jobController struct {
+	scheduler service.JobScheduler
+	logger    *zap.Logger
+}
+
+// NewJobController creates a new instance of JobController.
+func NewJobController(scheduler service.JobScheduler, logger *zap.Logger) JobController {
+	return &jobController{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// ListJobs godoc
+// @Summary      List background jobs
+// @Description  Lists the background jobs with their schedule, next run and last recorded run. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dto.JobStatus
+// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch jobs"
+// @Router       /admin/jobs [get]
+func (c *jobController) ListJobs(ctx *gin.Context) {
+	jobs, err := c.scheduler.Jobs()
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch jobs")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobs)
+}
+
+// TriggerJob godoc
+// @Summary      Run a background job now
+// @Description  Starts a background job immediately. The job runs asynchronously; the returned run can be followed through the job list. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Job name"
+// @Success      202   {object}  entity.JobRun
+// @Failure      401   {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404   {object}  dto.ErrorResponse "Job not found"
+// @Failure      409   {object}  dto.ErrorResponse "Job already running"
+// @Failure      500   {object}  dto.ErrorResponse "Failed to trigger job"
+// @Failure      503   {object}  dto.ErrorResponse "Job scheduler stopped, the server is shutting down"
+// @Router       /admin/jobs/{name}/run [post]
+func (c *jobController) TriggerJob(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	run, err := c.scheduler.Trigger(ctx.Param("name"), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to trigger job")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, run)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kimbasn/printly/internal/dto"
+)
+
+// dateLayout is the layout of creation dates given without a time of day
+const dateLayout = "2006-01-02"
+
+// bindListRequest reads the pagination, sorting and filtering parameters of a list endpoint.
+// Statuses may be repeated or comma-separated. Dates are RFC 3339 timestamps or plain dates,
+// a plain created_to date including the whole day.
+func bindListRequest(ctx *gin.Context, validate *validator.Validate) (dto.ListRequest, error) {
+	var req dto.ListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return req, fmt.Errorf("invalid query parameters: %w", err)
+	}
+
+	var statuses []string
+	for _, value := range req.Status {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	req.Status = statuses
+
+	var err error
+	if req.CreatedFrom, err = parseListDate(ctx.Query("created_from"), false); err != nil {
+		return req, fmt.Errorf("invalid created_from: %w", err)
+	}
+	if req.CreatedTo, err = parseListDate(ctx.Query("created_to"), true); err != nil {
+		return req, fmt.Errorf("invalid created_to: %w", err)
+	}
+
+	return req, validate.Struct(req)
+}
+
+// parseListDate parses an optional date parameter. A plain date ending a range
+// is moved to the next day, as ranges exclude their end.
+func parseListDate(value string, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, errors.New("expected a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+)
+
+const (
+	MAX_FILES_PER_ORDER = 10
+	// MAX_FIELD_SIZE bounds the form fields read in memory, such as document_configs
+	MAX_FIELD_SIZE = 1 << 20
+	// MAX_REQUEST_SIZE bounds a whole multipart order, files and fields
+	MAX_REQUEST_SIZE = MAX_FILES_PER_ORDER*MAX_FILE_SIZE + MAX_FIELD_SIZE
+)
+
+// sniffLen is the number of leading bytes http.DetectContentType looks at
+const sniffLen = 512
+
+// allowedExtensions lists the file extensions accepted for upload
+var allowedExtensions = []string{".pdf", ".doc", ".docx", ".txt", ".jpg", ".jpeg", ".png"}
+
+// sniffedTypes lists the accepted content types, each with the types http.DetectContentType
+// reports for it. Word documents are not recognized by the detection and are left to the inspector.
+var sniffedTypes = map[string][]string{
+	"application/pdf":    {"application/pdf"},
+	"application/msword": {"application/octet-stream"},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": {"application/zip"},
+	"text/plain": {"text/plain"},
+	"image/jpeg": {"image/jpeg"},
+	"image/jpg":  {"image/jpeg"},
+	"image/png":  {"image/png"},
+}
+
+// errFileTooLarge is returned while streaming a file over MAX_FILE_SIZE
+var errFileTooLarge = fmt.Errorf("file too large (max %dMB)", MAX_FILE_SIZE_MB)
+
+// sizeLimitedReader counts the bytes read and fails with errFileTooLarge past its limit,
+// where io.LimitReader would silently truncate the file.
+type sizeLimitedReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// validateFilePart checks the file name and content type sent with a file part
+func validateFilePart(filename, contentType string) error {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !slices.Contains(allowedExtensions, ext) {
+		return fmt.Errorf("unsupported file type %s", ext)
+	}
+
+	if contentType == "" {
+		return fmt.Errorf("missing content type")
+	}
+	if _, ok := sniffedTypes[contentType]; !ok {
+		return fmt.Errorf("unsupported content type %s", contentType)
+	}
+
+	return nil
+}
+
+// sniffMatches reports whether the leading bytes of a file are consistent with its declared content type
+func sniffMatches(contentType string, head []byte) bool {
+	detected, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return false
+	}
+	return slices.Contains(sniffedTypes[contentType], detected)
+}
+
+// readFormField reads a form field part, up to MAX_FIELD_SIZE
+func readFormField(part *multipart.Part) (string, error) {
+	value, err := io.ReadAll(io.LimitReader(part, MAX_FIELD_SIZE+1))
+	if err != nil {
+		return "", err
+	}
+	if len(value) > MAX_FIELD_SIZE {
+		return "", fmt.Errorf("field %s too large", part.FormName())
+	}
+	return string(value), nil
+}
+
+// streamFile validates a file part and streams it to storage as it is received, so the file
+// is never held in memory. Its first bytes are checked against the declared content type
+// before anything is stored. A copy is kept in a temporary file for the inspector, which needs
+// random access, scans the file for malware and checks the whole content against the declared type,
+// and the stored file is deleted if the inspection fails. The document takes the type detected by the inspector.
+// The returned status tells how to answer when the file is rejected.
+func (c *orderController) streamFile(part *multipart.Part, userUID string, centerID uint64) (*dto.CreateDocumentRequest, int, error) {
+	filename := part.FileName()
+	contentType := part.Header.Get("Content-Type")
+	if err := validateFilePart(filename, contentType); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	// 1. Sniff the first bytes before anything is stored
+	limited := &sizeLimitedReader{r: part, limit: MAX_FILE_SIZE}
+	buffered := bufio.NewReaderSize(limited, sniffLen)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, readErrorStatus(err), fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("file is empty")
+	}
+	if !sniffMatches(contentType, head) {
+		return nil, http.StatusBadRequest, fmt.Errorf("content does not match content type %s", contentType)
+	}
+
+	// 2. Stream the file to storage, keeping a copy for the inspector
+	tmp, err := os.CreateTemp("", "printly-upload-*")
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	storagePath, err := c.storageService.UploadFromReader(io.TeeReader(buffered, tmp), normalizeFileName(filename), userUID)
+	if err != nil {
+		status := readErrorStatus(err)
+		if status == http.StatusRequestEntityTooLarge {
+			return nil, status, errFileTooLarge
+		}
+		return nil, status, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	// 3. Read the page count and dimensions of the stored file
+	info, err := c.inspector.Inspect(tmp, limited.n, contentType)
+	if err != nil {
+		if info != nil && info.Scan.Infected() {
+			// No order exists yet, so unlike confirmed direct uploads there is no document to record
+			// the verdict on and no order to fail. The rejection is logged with its user instead.
+			c.logger.Warn("uploaded file rejected, malware detected",
+				zap.String("user_uid", userUID),
+				zap.Uint64("center_id", centerID),
+				zap.String("filename", filename),
+				zap.String("storage_path", storagePath),
+				zap.String("signature", info.Scan.Signature),
+				zap.String("engine", info.Scan.Engine))
+		}
+		if deleteErr := c.storageService.DeleteFile(storagePath); deleteErr != nil {
+			c.logger.Error("failed to cleanup file",
+				zap.String("storage_path", storagePath),
+				zap.Error(deleteErr))
+		}
+		return nil, inspectErrorStatus(err), err
+	}
+
+	return &dto.CreateDocumentRequest{
+		FileName:    filename,
+		MimeType:    info.MimeType,
+		Size:        limited.n,
+		StoragePath: storagePath,
+		PageCount:   info.PageCount,
+		PageWidth:   info.PageWidth,
+		PageHeight:  info.PageHeight,
+		Encrypted:   info.Encrypted,
+		SHA256:      info.SHA256,
+		Scan:        info.Scan,
+	}, http.StatusOK, nil
+}
+
+// inspectErrorStatus tells how to answer a file rejected by the inspector
+func inspectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ierrors.ErrMalwareDetected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ierrors.ErrScannerUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// readErrorStatus tells how to answer a failure while reading an uploaded body
+func readErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, errFileTooLarge), errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The client went away or sent a truncated form
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
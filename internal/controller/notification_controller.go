@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+type NotificationController interface {
+	GetMyNotifications(ctx *gin.Context)
+	GetOrderNotifications(ctx *gin.Context)
+}
+
+type notificationController struct {
+	notifier service.Notifier
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewNotificationController creates a new instance of NotificationController.
+func NewNotificationController(notifier service.Notifier, validate *validator.Validate, logger *zap.Logger) NotificationController {
+	return &notificationController{
+		notifier: notifier,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// GetMyNotifications godoc
+// @Summary      List my notifications
+// @Description  Lists one page of the emails and SMS sent or queued for the authenticated user, newest first by default, with their delivery status.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit         query     int       false  "Page size, at most 100" default(20)
+// @Param        offset        query     int       false  "Number of notifications to skip, ignored with a cursor"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Param        sort          query     string    false  "id, created_at or status, prefixed with - for descending order" default(-created_at)
+// @Param        status        query     []string  false  "Only return notifications in these statuses: PENDING, SENT or FAILED" collectionFormat(csv)
+// @Param        created_from  query     string    false  "Only return notifications created at or after this date or time"
+// @Param        created_to    query     string    false  "Only return notifications created before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.Notification}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch notifications"
+// @Router       /users/me/notifications [get]
+func (c *notificationController) GetMyNotifications(ctx *gin.Context) {
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	notifications, info, err := c.notifier.GetUserNotifications(actor.UID, req)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch notifications")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Page{Items: notifications, PageInfo: info})
+}
+
+// GetOrderNotifications godoc
+// @Summary      List the notifications of an order
+// @Description  Lists one page of the emails and SMS sent or queued about an order, newest first by default, with their delivery status. Available to the order owner and admins.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path      string    true   "Order ID"
+// @Param        limit         query     int       false  "Page size, at most 100" default(20)
+// @Param        offset        query     int       false  "Number of notifications to skip, ignored with a cursor"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Param        sort          query     string    false  "id, created_at or status, prefixed with - for descending order" default(-created_at)
+// @Param        status        query     []string  false  "Only return notifications in these statuses: PENDING, SENT or FAILED" collectionFormat(csv)
+// @Param        created_from  query     string    false  "Only return notifications created at or after this date or time"
+// @Param        created_to    query     string    false  "Only return notifications created before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.Notification}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid ID, filter, sort or cursor"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403           {object}  dto.ErrorResponse "Not allowed to access this order"
+// @Failure      404           {object}  dto.ErrorResponse "Order not found"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch notifications"
+// @Router       /orders/{id}/notifications [get]
+func (c *notificationController) GetOrderNotifications(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	notifications, info, err := c.notifier.GetOrderNotifications(uint(id), actor, req)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch notifications")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Page{Items: notifications, PageInfo: info})
+}
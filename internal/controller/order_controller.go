@@ -1,14 +1,17 @@
 package controller
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"mime/multipart"
+	"io"
 	"net/http"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -17,100 +20,66 @@ import (
 	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	"github.com/kimbasn/printly/internal/service"
+	"github.com/kimbasn/printly/internal/validators"
 )
 
 type OrderController interface {
 	CreateOrder(ctx *gin.Context)
+	CreateDirectUploadOrder(ctx *gin.Context)
+	ConfirmDocumentUploads(ctx *gin.Context)
+	QuoteOrder(ctx *gin.Context)
 	GetOrderByID(ctx *gin.Context)
 	GetOrderByCode(ctx *gin.Context)
+	VerifyPickup(ctx *gin.Context)
+	GetPickupQR(ctx *gin.Context)
+	ScheduleOrder(ctx *gin.Context)
 	GetOrdersForCenter(ctx *gin.Context)
+	StreamCenterOrders(ctx *gin.Context)
+	GetMyOrders(ctx *gin.Context)
+	GetMyOrder(ctx *gin.Context)
 	GetAllOrders(ctx *gin.Context)
 	UpdateOrderStatus(ctx *gin.Context)
+	CancelOrder(ctx *gin.Context)
+	GetOrderHistory(ctx *gin.Context)
+	GetPurgeReport(ctx *gin.Context)
+	GetDeletionReceipt(ctx *gin.Context)
+	VerifyDeletionReceipt(ctx *gin.Context)
 	DeleteOrder(ctx *gin.Context)
 }
 
 type orderController struct {
 	service        service.OrderService
 	storageService service.StorageService
+	inspector      service.DocumentInspector
 	validate       *validator.Validate
 	logger         *zap.Logger
 }
 
-func NewOrderController(service service.OrderService, storageService service.StorageService, validate *validator.Validate, logger *zap.Logger) OrderController {
+func NewOrderController(service service.OrderService, storageService service.StorageService, inspector service.DocumentInspector, validate *validator.Validate, logger *zap.Logger) OrderController {
+	validate.RegisterValidation("page-range", validators.ValidatePageRange)
 	return &orderController{
 		service:        service,
 		storageService: storageService,
+		inspector:      inspector,
 		validate:       validate,
 		logger:         logger,
 	}
 }
 
+// orderStreamHeartbeat is the interval of the comments keeping idle order streams open through proxies
+const orderStreamHeartbeat = 15 * time.Second
+
 const (
 	MAX_FILE_SIZE_MB = 50
-	MAX_FORM_SIZE    = MAX_FILE_SIZE_MB << 20
+	MAX_FILE_SIZE    = MAX_FILE_SIZE_MB << 20
 )
 
-func (c *orderController) validateFile(fileHeader *multipart.FileHeader) error {
-	// Check file size
-	maxSize := int64(MAX_FILE_SIZE_MB << 20)
-	if fileHeader.Size > maxSize {
-		return fmt.Errorf("file too large (max %dMB)", MAX_FILE_SIZE_MB)
-	}
-
-	// Check file extension
-	allowedExtensions := []string{".pdf", ".doc", ".docx", ".txt", ".jpg", ".jpeg", ".png"}
-	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-
-	isAllowed := false
-	for _, allowedExt := range allowedExtensions {
-		if ext == allowedExt {
-			isAllowed = true
-			break
-		}
-	}
-
-	if !isAllowed {
-		return fmt.Errorf("unsupported file type %s", ext)
-	}
-
-	// Check MIME type
-	contentType := fileHeader.Header.Get("Content-Type")
-	if contentType == "" {
-		return fmt.Errorf("missing content type")
-	}
-
-	// Additional MIME type validation
-	allowedMimeTypes := []string{
-		"application/pdf",
-		"application/msword",
-		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-		"text/plain",
-		"image/jpeg",
-		"image/jpg",
-		"image/png",
-	}
-
-	isAllowedMime := false
-	for _, allowedMime := range allowedMimeTypes {
-		if contentType == allowedMime {
-			isAllowedMime = true
-			break
-		}
-	}
-
-	if !isAllowedMime {
-		return fmt.Errorf("unsupported content type %s", contentType)
-	}
-
-	return nil
-}
-
 // isValidPrintMode validates the print mode value
 func isValidPrintMode(mode string) bool {
 	return mode == string(entity.PrePrint) || mode == string(entity.PrintUponArrival)
 }
 
-// cleanupUploadedFiles removes uploaded files if order creation fails
+// cleanupUploadedFiles removes uploaded files if the request is rejected before the order is created
 func (c *orderController) cleanupUploadedFiles(documents []dto.CreateDocumentRequest) {
 	var cleanupErrors []error
 
@@ -151,7 +120,7 @@ func normalizeFileName(fileName string) string {
 
 // CreateOrder godoc
 // @Summary      Create a new order with file uploads
-// @Description  Creates a new order with one or more documents uploaded as files. Each document can have its own print mode and options. Requires authentication.
+// @Description  Creates a new order with one or more documents uploaded as files. Each document can have its own print mode and options. The files are streamed to storage as they are received: up to 10 files of 50MB each, whose first bytes must match their content type. Requires authentication.
 // @Tags         Print Centers
 // @Accept       multipart/form-data
 // @Produce      json
@@ -159,12 +128,14 @@ func normalizeFileName(fileName string) string {
 // @Param        id           path      string                  true  "Print Center ID"
 // @Param        files        formData  file                    true  "Document files (multiple files allowed)"
 // @Param        document_configs formData string               true  "JSON array of document configurations (print_mode and print_options for each file)"
-// @Success      201          {object}  entity.Order
+// @Success      201          {object}  dto.CreatedOrder
 // @Failure      400          {object}  dto.ErrorResponse "Invalid input"
 // @Failure      401          {object}  dto.ErrorResponse "Unauthorized"
 // @Failure      404          {object}  dto.ErrorResponse "Print center not found"
-// @Failure      413          {object}  dto.ErrorResponse "File too large"
+// @Failure      413          {object}  dto.ErrorResponse "File or form too large"
+// @Failure      422          {object}  dto.ErrorResponse "Malware detected in a file"
 // @Failure      500          {object}  dto.ErrorResponse "Failed to create order"
+// @Failure      503          {object}  dto.ErrorResponse "Files can not be scanned for malware right now"
 // @Router       /centers/{id}/orders [post]
 func (c *orderController) CreateOrder(ctx *gin.Context) {
 	userUID, exists := ctx.Get("userUID")
@@ -181,32 +152,83 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
-	// Parse multipart form
-	if err := ctx.Request.ParseMultipartForm(MAX_FORM_SIZE); err != nil {
-		c.logger.Error("failed to parse multipart form", zap.Error(err))
+	// Read the form part by part, each file is streamed to storage as it is received
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MAX_REQUEST_SIZE)
+	reader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		c.logger.Error("failed to read multipart form", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to parse multipart form"})
 		return
 	}
 
-	// Get files
-	form, err := ctx.MultipartForm()
-	if err != nil {
-		c.logger.Error("failed to get multipart form", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to get multipart form"})
-		return
+	var documentRequests []dto.CreateDocumentRequest
+	var documentConfigsJSON string
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			c.logger.Error("failed to read multipart form", zap.Error(err))
+			c.cleanupUploadedFiles(documentRequests)
+			status := readErrorStatus(err)
+			if status == http.StatusInternalServerError {
+				status = http.StatusBadRequest
+			}
+			ctx.JSON(status, dto.ErrorResponse{Error: "failed to parse multipart form"})
+			return
+		}
+
+		switch part.FormName() {
+		case "document_configs":
+			// Print mode + print options for each file
+			documentConfigsJSON, err = readFormField(part)
+			if err != nil {
+				c.logger.Error("failed to read document_configs", zap.Error(err))
+				c.cleanupUploadedFiles(documentRequests)
+				ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read document_configs"})
+				return
+			}
+
+		case "files":
+			if len(documentRequests) == MAX_FILES_PER_ORDER {
+				c.cleanupUploadedFiles(documentRequests)
+				ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
+					Error: fmt.Sprintf("at most %d files can be sent with an order", MAX_FILES_PER_ORDER),
+				})
+				return
+			}
+
+			doc, status, err := c.streamFile(part, userUID.(string), centerID)
+			if err != nil {
+				c.logger.Error("file upload failed",
+					zap.Int("file_index", len(documentRequests)),
+					zap.String("filename", part.FileName()),
+					zap.Error(err))
+				c.cleanupUploadedFiles(documentRequests)
+				message := err.Error()
+				if status == http.StatusInternalServerError {
+					message = "failed to upload file"
+				}
+				ctx.JSON(status, dto.ErrorResponse{
+					Error: fmt.Sprintf("file %d (%s): %s", len(documentRequests)+1, part.FileName(), message),
+				})
+				return
+			}
+			documentRequests = append(documentRequests, *doc)
+		}
+		part.Close()
 	}
 
-	files := form.File["files"]
-	if len(files) == 0 {
+	if len(documentRequests) == 0 {
 		c.logger.Error("no files provided")
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "at least one file is required"})
 		return
 	}
 
-	// Get document configurations (print mode + print options for each file)
-	documentConfigsJSON := ctx.PostForm("document_configs")
 	if documentConfigsJSON == "" {
 		c.logger.Error("document_configs not provided")
+		c.cleanupUploadedFiles(documentRequests)
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: "document_configs is required",
 		})
@@ -216,6 +238,7 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 	var documentConfigs []dto.DocumentPrintRequest
 	if err := json.Unmarshal([]byte(documentConfigsJSON), &documentConfigs); err != nil {
 		c.logger.Error("failed to parse document_configs JSON", zap.Error(err))
+		c.cleanupUploadedFiles(documentRequests)
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: "invalid document_configs JSON",
 		})
@@ -223,90 +246,43 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 	}
 
 	// Validate that we have configurations for each file
-	if len(documentConfigs) != len(files) {
+	if len(documentConfigs) != len(documentRequests) {
 		c.logger.Error("document_configs count mismatch",
 			zap.Int("configs_count", len(documentConfigs)),
-			zap.Int("files_count", len(files)))
+			zap.Int("files_count", len(documentRequests)))
+		c.cleanupUploadedFiles(documentRequests)
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error: fmt.Sprintf("document_configs count (%d) must match files count (%d)", len(documentConfigs), len(files)),
+			Error: fmt.Sprintf("document_configs count (%d) must match files count (%d)", len(documentConfigs), len(documentRequests)),
 		})
 		return
 	}
 
-	// Process files and create document requests
-	var documentRequests []dto.CreateDocumentRequest
-
-	for i, fileHeader := range files {
-		// Validate file
-		if err := c.validateFile(fileHeader); err != nil {
-			c.logger.Error("file validation failed",
-				zap.Int("file_index", i),
-				zap.String("filename", fileHeader.Filename),
-				zap.Error(err))
-			ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error: fmt.Sprintf("file %d (%s): %s", i+1, fileHeader.Filename, err.Error()),
-			})
-			return
-		}
-
-		// Validate document configuration for this file
+	// Apply the print mode and options of each file
+	for i := range documentRequests {
 		if err := c.validate.Struct(documentConfigs[i]); err != nil {
 			c.logger.Error("document config validation failed",
 				zap.Int("config_index", i),
 				zap.Error(err))
+			c.cleanupUploadedFiles(documentRequests)
 			ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
 				Error: fmt.Sprintf("document_configs[%d]: %s", i, err.Error()),
 			})
 			return
 		}
 
-		// Validate print mode
 		if !isValidPrintMode(documentConfigs[i].PrintMode) {
 			c.logger.Error("invalid print mode",
 				zap.Int("config_index", i),
 				zap.String("print_mode", documentConfigs[i].PrintMode))
+			c.cleanupUploadedFiles(documentRequests)
 			ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
 				Error: fmt.Sprintf("document_configs[%d]: invalid print_mode '%s'", i, documentConfigs[i].PrintMode),
 			})
 			return
 		}
 
-		// Open and process the file
-		file, err := fileHeader.Open()
-		if err != nil {
-			c.logger.Error("failed to open file",
-				zap.String("filename", fileHeader.Filename),
-				zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: fmt.Sprintf("failed to open file %s", fileHeader.Filename),
-			})
-			return
-		}
-
-		// Upload file to storage
-		normalizedFileName := normalizeFileName(fileHeader.Filename)
-		storagePath, err := c.storageService.UploadFile(file, normalizedFileName, userUID.(string))
-		file.Close() // Close immediately after use to prevent memory leaks
-
-		if err != nil {
-			c.logger.Error("failed to upload file",
-				zap.String("filename", fileHeader.Filename),
-				zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: fmt.Sprintf("failed to upload file %s", fileHeader.Filename),
-			})
-			return
-		}
-
-		// Create document request with individual print mode and options
-		documentRequests = append(documentRequests, dto.CreateDocumentRequest{
-			FileName:     fileHeader.Filename,
-			MimeType:     fileHeader.Header.Get("Content-Type"),
-			Size:         fileHeader.Size,
-			StoragePath:  storagePath,
-			PrintMode:    entity.PrintMode(documentConfigs[i].PrintMode),
-			PrintOptions: documentConfigs[i].PrintOptions,
-		})
+		documentRequests[i].PrintMode = entity.PrintMode(documentConfigs[i].PrintMode)
+		documentRequests[i].PrintOptions = documentConfigs[i].PrintOptions
 	}
 
 	req := dto.CreateOrderRequest{
@@ -316,16 +292,16 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 	// Validate the complete request
 	if err := c.validate.Struct(req); err != nil {
 		c.logger.Error("order request validation failed", zap.Error(err))
+		c.cleanupUploadedFiles(documentRequests)
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
-	// Create the order
+	// Create the order, the service removes the uploaded files if it fails
 	order, err := c.service.CreateOrder(userUID.(string), uint(centerID), req)
 	if err != nil {
-		c.cleanupUploadedFiles(documentRequests)
 		HandleServiceError(ctx, err, "failed to create order")
 		return
 	}
@@ -335,7 +311,144 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 		zap.Uint64("center_id", centerID),
 		zap.Uint("order_id", order.ID))
 
-	ctx.JSON(http.StatusCreated, order)
+	ctx.JSON(http.StatusCreated, dto.CreatedOrder{Order: *order, TrackingToken: order.TrackingToken})
+}
+
+// CreateDirectUploadOrder godoc
+// @Summary      Create an order and upload its files straight to storage
+// @Description  Creates an order from the metadata of its documents, without sending the files. Each document comes back with a signed URL valid for 10 minutes: PUT the file to it with the given headers, then confirm the uploads. The order waits in AWAITING_DOCUMENT until then and is priced from the declared page counts. Requires authentication.
+// @Tags         Print Centers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string                        true  "Print Center ID"
+// @Param        order  body      dto.DirectUploadOrderRequest  true  "Documents to upload with their print options"
+// @Success      201    {object}  dto.DirectUploadOrder
+// @Failure      400    {object}  dto.ErrorResponse "Invalid input or service not offered"
+// @Failure      401    {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404    {object}  dto.ErrorResponse "Print center not found"
+// @Failure      409    {object}  dto.ErrorResponse "Print center not operational"
+// @Failure      500    {object}  dto.ErrorResponse "Failed to create order"
+// @Router       /centers/{id}/orders/uploads [post]
+func (c *orderController) CreateDirectUploadOrder(ctx *gin.Context) {
+	centerID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid print center ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid print center ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	var req dto.DirectUploadOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := c.service.CreateDirectUploadOrder(actor.UID, uint(centerID), req)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to create order")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// ConfirmDocumentUploads godoc
+// @Summary      Confirm the uploads of an order
+// @Description  Checks the files uploaded with the signed URLs of an order against the declared size and type, reads their pages and prices the order again from them. The order then moves to PENDING_PAYMENT. A file that does not match is deleted and must be uploaded again. Only available to the order owner.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Order ID"
+// @Success      200  {object}  entity.Order
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID, or a file does not match its document or can not be read"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order not found"
+// @Failure      409  {object}  dto.ErrorResponse "Order not awaiting its documents, or a file is not uploaded yet"
+// @Failure      422  {object}  dto.ErrorResponse "Malware detected in a file, the order is failed"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to confirm uploads"
+// @Failure      503  {object}  dto.ErrorResponse "Files can not be scanned for malware right now"
+// @Router       /orders/{id}/uploads/confirm [post]
+func (c *orderController) ConfirmDocumentUploads(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	order, err := c.service.ConfirmDocumentUploads(uint(id), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to confirm uploads")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// QuoteOrder godoc
+// @Summary      Get a price quote
+// @Description  Prices documents with the services of a print center before any upload or payment. Nothing is persisted, the prices are computed exactly as for order creation.
+// @Tags         Print Centers
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string            true  "Print Center ID"
+// @Param        quote  body      dto.QuoteRequest  true  "Documents to price"
+// @Success      200    {object}  dto.Quote
+// @Failure      400    {object}  dto.ErrorResponse "Invalid input or service not offered"
+// @Failure      404    {object}  dto.ErrorResponse "Print center not found"
+// @Failure      500    {object}  dto.ErrorResponse "Failed to compute quote"
+// @Router       /centers/{id}/quote [post]
+func (c *orderController) QuoteOrder(ctx *gin.Context) {
+	centerID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid print center ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid print center ID"})
+		return
+	}
+
+	var req dto.QuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	quote, err := c.service.QuoteOrder(uint(centerID), req)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to compute quote")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quote)
 }
 
 // GetOrderByID godoc
@@ -369,11 +482,11 @@ func (c *orderController) GetOrderByID(ctx *gin.Context) {
 
 // GetOrderByCode godoc
 // @Summary      Get order status by pickup code
-// @Description  Retrieves the status of an order using its public pickup code.
+// @Description  Retrieves the status of an order using its public pickup code. Only the status is disclosed, not the order content.
 // @Tags         Orders
 // @Produce      json
 // @Param        code path      string       true  "Pickup Code"
-// @Success      200  {object}  entity.Order
+// @Success      200  {object}  dto.OrderStatusResponse
 // @Failure      404  {object}  dto.ErrorResponse "Order not found"
 // @Failure      500  {object}  dto.ErrorResponse "Failed to fetch order status"
 // @Router       /orders/status/{code} [get]
@@ -391,19 +504,197 @@ func (c *orderController) GetOrderByCode(ctx *gin.Context) {
 		return
 	}
 
+	ctx.JSON(http.StatusOK, dto.OrderStatusResponse{
+		Code:          order.Code,
+		Status:        order.Status,
+		PrintCenterID: order.PrintCenterID,
+		PickupTime:    order.PickupTime,
+		UpdatedAt:     order.UpdatedAt,
+	})
+}
+
+// VerifyPickup godoc
+// @Summary      Verify a pickup code
+// @Description  Checks the pickup code presented by a customer at the counter, typed in as `code` or scanned from the pickup QR code as `token`. The order must belong to the center and be ready for pickup or awaiting its customer; it is then moved to its next status (completed or printing) and the verifying manager is recorded. Wrong codes are counted and throttled per center. Requires manager role.
+// @Tags         Print Centers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string                   true  "Print Center ID"
+// @Param        pickup  body      dto.VerifyPickupRequest  true  "Pickup code"
+// @Success      200     {object}  entity.Order
+// @Failure      400     {object}  dto.ErrorResponse "Invalid input"
+// @Failure      403     {object}  dto.ErrorResponse "Not a manager of this center"
+// @Failure      404     {object}  dto.ErrorResponse "Invalid pickup code"
+// @Failure      409     {object}  dto.ErrorResponse "Order not ready for pickup or QR code expired"
+// @Failure      429     {object}  dto.ErrorResponse "Too many failed attempts"
+// @Failure      500     {object}  dto.ErrorResponse "Failed to verify pickup code"
+// @Router       /centers/{id}/orders/verify [post]
+func (c *orderController) VerifyPickup(ctx *gin.Context) {
+	centerID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid print center ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid print center ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	var req dto.VerifyPickupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	order, err := c.service.VerifyPickup(uint(centerID), req, actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to verify pickup code")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// ScheduleOrder godoc
+// @Summary      Schedule the pickup of an order
+// @Description  Sets the pickup time and print mode of a paid order. The pickup time must fall within the working hours of the print center. PRE_PRINT orders move to READY_TO_PRINT, PRINT_UPON_ARRIVAL orders to AWAITING_USER. Only available to the order owner.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                    true  "Order ID"
+// @Param        schedule  body      dto.ScheduleOrderRequest  true  "Pickup time and print mode"
+// @Success      200       {object}  entity.Order
+// @Failure      400       {object}  dto.ErrorResponse "Invalid input or pickup outside working hours"
+// @Failure      401       {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403       {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404       {object}  dto.ErrorResponse "Order not found"
+// @Failure      409       {object}  dto.ErrorResponse "Order not paid"
+// @Failure      500       {object}  dto.ErrorResponse "Failed to schedule order"
+// @Router       /orders/{id}/schedule [post]
+func (c *orderController) ScheduleOrder(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	var req dto.ScheduleOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	order, err := c.service.ScheduleOrder(uint(id), req, actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to schedule order")
+		return
+	}
+
 	ctx.JSON(http.StatusOK, order)
 }
 
+// GetPickupQR godoc
+// @Summary      Get the pickup QR code of an order
+// @Description  Renders a QR code encoding a signed payload (pickup code, center, expiry) that managers can scan instead of typing the pickup code. The expiry is returned in the X-Expires-At header. Only available to the order owner while the order is active.
+// @Tags         Orders
+// @Produce      png
+// @Produce      image/svg+xml
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Order ID"
+// @Param        format  query     string  false  "Image format" Enums(png, svg) default(png)
+// @Success      200     {file}    binary
+// @Failure      400     {object}  dto.ErrorResponse "Invalid ID or format"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403     {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404     {object}  dto.ErrorResponse "Order not found"
+// @Failure      409     {object}  dto.ErrorResponse "Order no longer active"
+// @Failure      500     {object}  dto.ErrorResponse "Failed to render QR code"
+// @Router       /orders/{id}/pickup-qr [get]
+func (c *orderController) GetPickupQR(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	format := service.QRFormat(strings.ToLower(ctx.DefaultQuery("format", string(service.QRFormatPNG))))
+	if format != service.QRFormatPNG && format != service.QRFormatSVG {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "format must be png or svg"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	token, expiresAt, err := c.service.GetPickupToken(uint(id), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to render QR code")
+		return
+	}
+
+	image, err := service.RenderQRCode(token, format)
+	if err != nil {
+		c.logger.Error("failed to render QR code", zap.Uint64("order_id", id), zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to render QR code"})
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Header("X-Expires-At", expiresAt.UTC().Format(time.RFC3339))
+	ctx.Data(http.StatusOK, format.ContentType(), image)
+}
+
 // GetOrdersForCenter godoc
 // @Summary      Get orders for a print center
-// @Description  Retrieves all orders for a specific print center. Requires manager or admin role.
+// @Description  Lists one page of the orders of a print center, newest first by default, with their documents. Only available to the managers of the center and admins.
 // @Tags         Print Centers
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string       true  "Print Center ID"
-// @Success      200  {array}   entity.Order
-// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
-// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch orders"
+// @Param        id            path      string    true   "Print Center ID"
+// @Param        limit         query     int       false  "Page size, at most 100" default(20)
+// @Param        offset        query     int       false  "Number of orders to skip, ignored with a cursor"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Param        sort          query     string    false  "id, created_at, updated_at, status or total_cost, prefixed with - for descending order" default(-created_at)
+// @Param        status        query     []string  false  "Only return orders in these statuses" collectionFormat(csv)
+// @Param        created_from  query     string    false  "Only return orders created at or after this date or time"
+// @Param        created_to    query     string    false  "Only return orders created before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.Order}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid ID, filter, sort or cursor"
+// @Failure      403           {object}  dto.ErrorResponse "Not a manager of this print center"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch orders"
 // @Router       /centers/{id}/orders [get]
 func (c *orderController) GetOrdersForCenter(ctx *gin.Context) {
 	centerID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
@@ -413,37 +704,241 @@ func (c *orderController) GetOrdersForCenter(ctx *gin.Context) {
 		return
 	}
 
-	orders, err := c.service.GetOrdersForCenter(uint(centerID))
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	orders, info, err := c.service.GetOrdersForCenter(uint(centerID), req, actor)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch orders for center")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, orders)
+	ctx.JSON(http.StatusOK, dto.Page{Items: orders, PageInfo: info})
+}
+
+// StreamCenterOrders godoc
+// @Summary      Stream live orders of a print center
+// @Description  Server-Sent Events stream of the orders of a print center: order.created, order.paid, order.status_changed and order.cancelled events, each carrying a dto.OrderEvent as JSON data and its ID. A reconnecting client sends the ID of the last event it received as the Last-Event-ID header (or the last_event_id parameter) to get the events it missed first, as long as the server still retains them. Comments are sent every 15 seconds to keep the stream open. Restricted to the managers of the center and to admins.
+// @Tags         Print Centers
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        id             path      string  true   "Print Center ID"
+// @Param        Last-Event-ID  header    int     false  "ID of the last event received"
+// @Param        last_event_id  query     int     false  "ID of the last event received, for clients that can not set headers"
+// @Success      200            {object}  dto.OrderEvent
+// @Failure      400            {object}  dto.ErrorResponse "Invalid ID or event ID"
+// @Failure      401            {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403            {object}  dto.ErrorResponse "Not a manager of this print center"
+// @Failure      404            {object}  dto.ErrorResponse "Print center not found"
+// @Router       /centers/{id}/orders/stream [get]
+func (c *orderController) StreamCenterOrders(ctx *gin.Context) {
+	centerID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid print center ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid print center ID"})
+		return
+	}
+
+	var lastEventID uint64
+	if value := cmp.Or(ctx.GetHeader("Last-Event-ID"), ctx.Query("last_event_id")); value != "" {
+		if lastEventID, err = strconv.ParseUint(value, 10, 64); err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid last event ID"})
+			return
+		}
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	sub, err := c.service.SubscribeCenterOrders(uint(centerID), lastEventID, actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to open order stream")
+		return
+	}
+	defer sub.Close()
+
+	// The stream outlives the write timeout of the server
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.logger.Warn("failed to clear the write deadline of an order stream", zap.Error(err))
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	for _, event := range sub.Missed {
+		if err := writeOrderEvent(ctx.Writer, event); err != nil {
+			return
+		}
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(orderStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			// Closed when the client falls behind, it resumes from its last event
+			if !ok {
+				return
+			}
+			err = writeOrderEvent(ctx.Writer, event)
+		case <-heartbeat.C:
+			_, err = io.WriteString(ctx.Writer, ": heartbeat\n\n")
+		}
+		if err != nil {
+			return
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// writeOrderEvent writes an order event in the Server-Sent Events format.
+func writeOrderEvent(w io.Writer, event dto.OrderEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+// GetMyOrders godoc
+// @Summary      List my orders
+// @Description  Lists one page of the orders of the authenticated user, newest first by default, with their documents and their price at the current rates of the print center.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit         query     int       false  "Page size, at most 100" default(20)
+// @Param        offset        query     int       false  "Number of orders to skip, ignored with a cursor"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Param        sort          query     string    false  "id, created_at, updated_at, status or total_cost, prefixed with - for descending order" default(-created_at)
+// @Param        status        query     []string  false  "Only return orders in these statuses" collectionFormat(csv)
+// @Param        created_from  query     string    false  "Only return orders created at or after this date or time"
+// @Param        created_to    query     string    false  "Only return orders created before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]dto.UserOrder}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch orders"
+// @Router       /users/me/orders [get]
+func (c *orderController) GetMyOrders(ctx *gin.Context) {
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	orders, info, err := c.service.GetOrdersForUser(actor.UID, req)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch orders")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Page{Items: orders, PageInfo: info})
+}
+
+// GetMyOrder godoc
+// @Summary      Get one of my orders
+// @Description  Retrieves an order of the authenticated user with its documents and their price at the current rates of the print center.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Order ID"
+// @Success      200  {object}  dto.UserOrder
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order not found"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch order"
+// @Router       /users/me/orders/{id} [get]
+func (c *orderController) GetMyOrder(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	order, err := c.service.GetUserOrder(uint(id), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
 }
 
 // GetAllOrders godoc
 // @Summary      Get all orders (admin)
-// @Description  Retrieves a list of all orders across the platform. Requires admin role.
+// @Description  Lists one page of the orders across the platform, newest first by default, with their documents. Requires admin role.
 // @Tags         Admin
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   entity.Order
-// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch all orders"
+// @Param        limit         query     int       false  "Page size, at most 100" default(20)
+// @Param        offset        query     int       false  "Number of orders to skip, ignored with a cursor"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Param        sort          query     string    false  "id, created_at, updated_at, status or total_cost, prefixed with - for descending order" default(-created_at)
+// @Param        status        query     []string  false  "Only return orders in these statuses" collectionFormat(csv)
+// @Param        center_id     query     int       false  "Only return orders of this print center"
+// @Param        user_uid      query     string    false  "Only return orders of this user"
+// @Param        created_from  query     string    false  "Only return orders created at or after this date or time"
+// @Param        created_to    query     string    false  "Only return orders created before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.Order}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch all orders"
 // @Router       /admin/orders [get]
 func (c *orderController) GetAllOrders(ctx *gin.Context) {
-	orders, err := c.service.GetAllOrders()
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	orders, info, err := c.service.GetAllOrders(req)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch all orders")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, orders)
+	ctx.JSON(http.StatusOK, dto.Page{Items: orders, PageInfo: info})
 }
 
 // UpdateOrderStatus godoc
 // @Summary      Update an order's status
-// @Description  Updates the status of an order. Requires manager or admin role.
+// @Description  Moves an order to a new status. Only transitions allowed by the order lifecycle are accepted: managers may move orders of their center through printing and pickup, admins may apply any legal transition. Every change is recorded in the order history. Requires manager or admin role.
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
@@ -452,7 +947,9 @@ func (c *orderController) GetAllOrders(ctx *gin.Context) {
 // @Param        status  body      dto.UpdateOrderStatusRequest  true  "New status"
 // @Success      200     {object}  dto.SuccessResponse "Status updated"
 // @Failure      400     {object}  dto.ErrorResponse   "Invalid input"
+// @Failure      403     {object}  dto.ErrorResponse   "Transition not allowed for this user"
 // @Failure      404     {object}  dto.ErrorResponse   "Order not found"
+// @Failure      409     {object}  dto.ErrorResponse   "Invalid status transition"
 // @Failure      500     {object}  dto.ErrorResponse   "Failed to update status"
 // @Router       /orders/{id}/status [patch]
 func (c *orderController) UpdateOrderStatus(ctx *gin.Context) {
@@ -463,10 +960,10 @@ func (c *orderController) UpdateOrderStatus(ctx *gin.Context) {
 		return
 	}
 
-	userUID, exists := ctx.Get("userUID")
+	actor, exists := actorFromContext(ctx)
 	if !exists {
-		c.logger.Error("user UID not found in context")
-		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user UID not found in context"})
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
 		return
 	}
 
@@ -483,7 +980,7 @@ func (c *orderController) UpdateOrderStatus(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.UpdateOrderStatus(uint(id), req.Status, userUID.(string)); err != nil {
+	if err := c.service.UpdateOrderStatus(uint(id), req.Status, actor, req.Reason); err != nil {
 		HandleServiceError(ctx, err, "failed to update order status")
 		return
 	}
@@ -491,11 +988,215 @@ func (c *orderController) UpdateOrderStatus(ctx *gin.Context) {
 	c.logger.Info("order status updated",
 		zap.Uint64("order_id", id),
 		zap.String("new_status", string(req.Status)),
-		zap.String("updated_by", userUID.(string)))
+		zap.String("updated_by", actor.UID))
 
 	ctx.JSON(http.StatusOK, dto.SuccessResponse{Message: "status updated"})
 }
 
+// CancelOrder godoc
+// @Summary      Cancel an order
+// @Description  Cancels an order, refunds it if it was paid and deletes its documents from storage. Customers may cancel their own orders until printing starts. Managers may cancel paid orders of their center that were not printed yet, and admins any order that is not over; both must give a reason. The order stays cancelled when the refund or the deletion fails, the failures are listed in the response.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string                  true   "Order ID"
+// @Param        reason  body      dto.CancelOrderRequest  false  "Cancellation reason"
+// @Success      200     {object}  dto.CancelOrderResponse
+// @Failure      400     {object}  dto.ErrorResponse   "Invalid input or missing reason"
+// @Failure      401     {object}  dto.ErrorResponse   "Unauthorized"
+// @Failure      403     {object}  dto.ErrorResponse   "Not allowed to cancel this order"
+// @Failure      404     {object}  dto.ErrorResponse   "Order not found"
+// @Failure      409     {object}  dto.ErrorResponse   "Order can not be cancelled in its current status"
+// @Failure      500     {object}  dto.ErrorResponse   "Failed to cancel order"
+// @Router       /orders/{id}/cancel [post]
+func (c *orderController) CancelOrder(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	// The body is optional
+	var req dto.CancelOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := c.service.CancelOrder(uint(id), actor, strings.TrimSpace(req.Reason))
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to cancel order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetOrderHistory godoc
+// @Summary      Get the status history of an order
+// @Description  Lists every status transition of an order, oldest first, with the actor and reason. Available to the order owner, the managers of its print center and admins.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Order ID"
+// @Success      200  {array}   entity.OrderStatusHistory
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not allowed to access this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order not found"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch order history"
+// @Router       /orders/{id}/history [get]
+func (c *orderController) GetOrderHistory(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	history, err := c.service.GetOrderHistory(uint(id), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch order history")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// GetPurgeReport godoc
+// @Summary      Get the purge report of an order
+// @Description  Tells, for each document of an order, whether its file is deleted from storage. Files are deleted once printed, once the order is over, and in any case after a maximum age. Available to the order owner, the managers of its print center and admins.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Order ID"
+// @Success      200  {object}  dto.DocumentPurgeReport
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not allowed to access this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order not found"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch purge report"
+// @Router       /orders/{id}/purge-report [get]
+func (c *orderController) GetPurgeReport(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	report, err := c.service.GetPurgeReport(uint(id), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch purge report")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetDeletionReceipt godoc
+// @Summary      Get the deletion receipt of a document
+// @Description  Returns the signed receipt issued when the file of a document was deleted from storage. It holds the order code, the SHA-256 of the file name and content, the storage backend and the deletion time, and can be checked with the public verify endpoint. Available to the order owner, the managers of its print center and admins.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true  "Order ID"
+// @Param        docId  path      string  true  "Document ID"
+// @Success      200  {object}  entity.DeletionReceipt
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not allowed to access this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order, document or receipt not found"
+// @Failure      409  {object}  dto.ErrorResponse "Document not deleted yet"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch deletion receipt"
+// @Router       /orders/{id}/documents/{docId}/deletion-receipt [get]
+func (c *orderController) GetDeletionReceipt(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+	docID, err := strconv.ParseUint(ctx.Param("docId"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid document ID", zap.String("docId", ctx.Param("docId")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid document ID"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	receipt, err := c.service.GetDeletionReceipt(uint(id), uint(docID), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to fetch deletion receipt")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, receipt)
+}
+
+// VerifyDeletionReceipt godoc
+// @Summary      Verify a deletion receipt
+// @Description  Checks that a deletion receipt was issued by Printly and has not been altered. No authentication required.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        receipt  body      entity.DeletionReceipt  true  "Receipt to verify"
+// @Success      200  {object}  dto.DeletionReceiptVerification
+// @Failure      400  {object}  dto.ErrorResponse "Invalid receipt"
+// @Router       /deletion-receipts/verify [post]
+func (c *orderController) VerifyDeletionReceipt(ctx *gin.Context) {
+	var receipt entity.DeletionReceipt
+	if err := ctx.ShouldBindJSON(&receipt); err != nil {
+		c.logger.Error("failed to bind request", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := c.validate.Struct(receipt); err != nil {
+		c.logger.Error("request validation failed", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.DeletionReceiptVerification{Valid: c.service.VerifyDeletionReceipt(&receipt)})
+}
+
 // DeleteOrder godoc
 // @Summary      Delete an order (admin)
 // @Description  Deletes an order. Requires admin role.
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+const (
+	PAYMENT_SIGNATURE_HEADER = "X-Printly-Signature"
+	MAX_WEBHOOK_BODY_SIZE    = 1 << 20
+)
+
+type PaymentController interface {
+	InitiatePayment(ctx *gin.Context)
+	HandleWebhook(ctx *gin.Context)
+	SimulatePayment(ctx *gin.Context)
+}
+
+type paymentController struct {
+	service   service.PaymentService
+	simulator service.PaymentSimulator
+	logger    *zap.Logger
+}
+
+// NewPaymentController creates a payment controller. The simulator is optional and
+// only used by the development route that fakes provider callbacks.
+func NewPaymentController(service service.PaymentService, simulator service.PaymentSimulator, logger *zap.Logger) PaymentController {
+	return &paymentController{
+		service:   service,
+		simulator: simulator,
+		logger:    logger,
+	}
+}
+
+// InitiatePayment godoc
+// @Summary      Start the payment of an order
+// @Description  Opens a checkout session with the payment gateway for an order awaiting payment. Returns the existing session if one is still pending.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Order ID"
+// @Success      201  {object}  entity.Payment
+// @Failure      400  {object}  dto.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order not found"
+// @Failure      409  {object}  dto.ErrorResponse "Order is not awaiting payment"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to initiate payment"
+// @Failure      503  {object}  dto.ErrorResponse "Payments are disabled"
+// @Router       /orders/{id}/pay [post]
+func (c *paymentController) InitiatePayment(ctx *gin.Context) {
+	userUID, exists := ctx.Get("userUID")
+	if !exists {
+		c.logger.Error("user UID not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user UID not found in context"})
+		return
+	}
+
+	orderID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		c.logger.Error("invalid order ID", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid order ID"})
+		return
+	}
+
+	payment, err := c.service.InitiatePayment(uint(orderID), userUID.(string))
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to initiate payment")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, payment)
+}
+
+// HandleWebhook godoc
+// @Summary      Payment provider webhook
+// @Description  Receives asynchronous payment notifications. The payload must be signed by the provider in the X-Printly-Signature header. Each event is applied once: replayed events, and events that would move a payment back such as a success after a refund, are acknowledged and ignored. A payment that settles after its order was cancelled or failed is refunded.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Printly-Signature  header    string  true  "Payload signature"
+// @Success      200  {object}  dto.SuccessResponse "Webhook processed"
+// @Failure      400  {object}  dto.ErrorResponse "Invalid payload"
+// @Failure      401  {object}  dto.ErrorResponse "Invalid signature"
+// @Failure      404  {object}  dto.ErrorResponse "Payment not found"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to process webhook"
+// @Failure      503  {object}  dto.ErrorResponse "Payments are disabled"
+// @Router       /webhooks/payment [post]
+func (c *paymentController) HandleWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, MAX_WEBHOOK_BODY_SIZE))
+	if err != nil {
+		c.logger.Error("failed to read webhook body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+
+	if err := c.service.HandleWebhook(payload, ctx.GetHeader(PAYMENT_SIGNATURE_HEADER)); err != nil {
+		c.logger.Error("failed to process payment webhook", zap.Error(err))
+		HandleServiceError(ctx, err, "failed to process webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.SuccessResponse{Message: "webhook processed"})
+}
+
+// SimulatePayment godoc
+// @Summary      Settle a mock checkout session
+// @Description  Development only. Makes the mock payment provider settle a session and deliver the signed webhook, as a real provider would.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        session_id  path      string                         true  "Checkout session ID"
+// @Param        outcome     body      dto.SimulatePaymentRequest     false "Outcome of the payment"
+// @Success      200  {object}  dto.SuccessResponse "Webhook processed"
+// @Failure      400  {object}  dto.ErrorResponse "Invalid input"
+// @Failure      404  {object}  dto.ErrorResponse "Payment not found"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to simulate payment"
+// @Router       /mock/payments/{session_id} [post]
+func (c *paymentController) SimulatePayment(ctx *gin.Context) {
+	// The body is optional, an empty one settles the payment successfully
+	var req dto.SimulatePaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	status := entity.PaymentSucceeded
+	if req.Status != "" {
+		status = req.Status
+	}
+
+	payload, signature, err := c.simulator.SimulatePayment(ctx.Param("session_id"), status)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to simulate payment")
+		return
+	}
+
+	if err := c.service.HandleWebhook(payload, signature); err != nil {
+		HandleServiceError(ctx, err, "failed to process webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.SuccessResponse{Message: "webhook processed"})
+}
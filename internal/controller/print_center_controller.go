@@ -110,19 +110,32 @@ func (c *printCenterController) GetPrintCenterByID(ctx *gin.Context) {
 
 // GetAllPublicPrintCenters godoc
 // @Summary      Get all public print centers
-// @Description  Retrieves a list of all approved print centers.
+// @Description  Lists one page of the approved print centers, by registration date by default.
 // @Tags         Print Centers
 // @Produce      json
-// @Success      200  {array}   entity.PrintCenter
-// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch print centers"
+// @Param        limit         query     int     false  "Page size, at most 100" default(20)
+// @Param        offset        query     int     false  "Number of centers to skip, ignored with a cursor"
+// @Param        cursor        query     string  false  "next_cursor of the previous page"
+// @Param        sort          query     string  false  "id, created_at, name or status, prefixed with - for descending order" default(created_at)
+// @Param        created_from  query     string  false  "Only return centers registered at or after this date or time"
+// @Param        created_to    query     string  false  "Only return centers registered before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.PrintCenter}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch print centers"
 // @Router       /centers [get]
 func (c *printCenterController) GetAllPublicPrintCenters(ctx *gin.Context) {
-	centers, err := c.service.GetApproved()
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	centers, info, err := c.service.GetApproved(req)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch print centers")
 		return
 	}
-	ctx.JSON(http.StatusOK, centers)
+	ctx.JSON(http.StatusOK, dto.Page{Items: centers, PageInfo: info})
 }
 
 // UpdatePrintCenter godoc
@@ -201,35 +214,65 @@ func (c *printCenterController) DeletePrintCenter(ctx *gin.Context) {
 
 // GetPendingPrintCenters godoc
 // @Summary      Get all pending print centers
-// @Description  Retrieves a list of all print centers awaiting approval. Requires admin role.
+// @Description  Lists one page of the print centers awaiting approval, by registration date by default. Requires admin role.
 // @Tags         Admin
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   entity.PrintCenter
-// @Failure      500  {object}  dto.ErrorResponse "Failed to fetch pending centers"
+// @Param        limit         query     int     false  "Page size, at most 100" default(20)
+// @Param        offset        query     int     false  "Number of centers to skip, ignored with a cursor"
+// @Param        cursor        query     string  false  "next_cursor of the previous page"
+// @Param        sort          query     string  false  "id, created_at, name or status, prefixed with - for descending order" default(created_at)
+// @Param        created_from  query     string  false  "Only return centers registered at or after this date or time"
+// @Param        created_to    query     string  false  "Only return centers registered before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.PrintCenter}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch pending centers"
 // @Router       /admin/centers/pending [get]
 func (c *printCenterController) GetPendingPrintCenters(ctx *gin.Context) {
-	centers, err := c.service.GetPending()
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	centers, info, err := c.service.GetPending(req)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch pending centers")
 		return
 	}
-	ctx.JSON(http.StatusOK, centers)
+	ctx.JSON(http.StatusOK, dto.Page{Items: centers, PageInfo: info})
 }
 
 // GetAllPrintCenters godoc
 // @Summary      Get all print centers (admin)
-// @Description  Retrieves a list of all print centers, regardless of status. Requires admin role.
+// @Description  Lists one page of the print centers, regardless of status unless filtered, by registration date by default. Requires admin role.
 // @Tags         Admin
 // @Produce      json
 // @Security     BearerAuth
+// @Param        limit         query     int     false  "Page size, at most 100" default(20)
+// @Param        offset        query     int     false  "Number of centers to skip, ignored with a cursor"
+// @Param        cursor        query     string  false  "next_cursor of the previous page"
+// @Param        sort          query     string  false  "id, created_at, name or status, prefixed with - for descending order" default(created_at)
+// @Param        status        query     []string  false  "Only return centers in these statuses" collectionFormat(csv)
+// @Param        created_from  query     string  false  "Only return centers registered at or after this date or time"
+// @Param        created_to    query     string  false  "Only return centers registered before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.PrintCenter}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch all centers"
+// @Router       /admin/centers [get]
 func (c *printCenterController) GetAllPrintCenters(ctx *gin.Context) {
-	centers, err := c.service.GetAll()
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	centers, info, err := c.service.GetAll(req)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch all centers")
 		return
 	}
-	ctx.JSON(http.StatusOK, centers)
+	ctx.JSON(http.StatusOK, dto.Page{Items: centers, PageInfo: info})
 }
 
 // UpdatePrintCenterStatus godoc
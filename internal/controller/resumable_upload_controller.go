@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+// Headers and values of the tus resumable upload protocol, see https://tus.io/protocols/resumable-upload
+const (
+	TUS_VERSION      = "1.0.0"
+	TUS_EXTENSIONS   = "creation,expiration,termination"
+	TUS_CONTENT_TYPE = "application/offset+octet-stream"
+)
+
+type ResumableUploadController interface {
+	GetUploadOptions(ctx *gin.Context)
+	CreateUpload(ctx *gin.Context)
+	GetUploadOffset(ctx *gin.Context)
+	AppendUpload(ctx *gin.Context)
+	TerminateUpload(ctx *gin.Context)
+}
+
+type resumableUploadController struct {
+	service service.ResumableUploadService
+	logger  *zap.Logger
+}
+
+// NewResumableUploadController creates a new instance of ResumableUploadController.
+func NewResumableUploadController(service service.ResumableUploadService, logger *zap.Logger) ResumableUploadController {
+	return &resumableUploadController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetUploadOptions godoc
+// @Summary      Discover the resumable upload server
+// @Description  Tells the version, extensions and maximum size supported by the tus server, in the Tus-Version, Tus-Extension and Tus-Max-Size headers.
+// @Tags         Resumable Uploads
+// @Success      204
+// @Header       204  {string}  Tus-Resumable  "1.0.0"
+// @Header       204  {string}  Tus-Version    "1.0.0"
+// @Header       204  {string}  Tus-Extension  "creation,expiration,termination"
+// @Header       204  {integer} Tus-Max-Size   "Maximum upload size in bytes"
+// @Router       /resumable-uploads [options]
+func (c *resumableUploadController) GetUploadOptions(ctx *gin.Context) {
+	ctx.Header("Tus-Resumable", TUS_VERSION)
+	ctx.Header("Tus-Version", TUS_VERSION)
+	ctx.Header("Tus-Extension", TUS_EXTENSIONS)
+	ctx.Header("Tus-Max-Size", strconv.Itoa(MAX_FILE_SIZE))
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateUpload godoc
+// @Summary      Start a resumable upload
+// @Description  Starts the upload of a document of an order created with POST /centers/{id}/orders/uploads, following the creation extension of tus 1.0. Upload-Length must be the size declared for the document, and Upload-Metadata must hold its order_id and document_id. The URL of the upload is returned in the Location header. Only available to the order owner.
+// @Tags         Resumable Uploads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Tus-Resumable    header  string   true  "Protocol version, 1.0.0"
+// @Param        Upload-Length    header  integer  true  "Size of the file in bytes"
+// @Param        Upload-Metadata  header  string   true  "Comma separated keys and base64 values, with order_id and document_id"
+// @Success      201
+// @Header       201  {string}  Location        "URL of the upload"
+// @Header       201  {string}  Upload-Expires  "Expiry of the upload, RFC 7231 date"
+// @Failure      400  {object}  dto.ErrorResponse "Invalid length or metadata, or the length is not the declared size"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  dto.ErrorResponse "Not the owner of this order"
+// @Failure      404  {object}  dto.ErrorResponse "Order or document not found"
+// @Failure      409  {object}  dto.ErrorResponse "Order not awaiting its documents, or the document already has a file"
+// @Failure      412  {object}  dto.ErrorResponse "Unsupported protocol version"
+// @Failure      413  {object}  dto.ErrorResponse "File too large"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to create upload"
+// @Router       /resumable-uploads [post]
+func (c *resumableUploadController) CreateUpload(ctx *gin.Context) {
+	if !checkTusVersion(ctx) {
+		return
+	}
+
+	if ctx.GetHeader("Upload-Defer-Length") != "" {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Upload-Defer-Length is not supported"})
+		return
+	}
+	length, err := strconv.ParseInt(ctx.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid Upload-Length header"})
+		return
+	}
+	if length > MAX_FILE_SIZE {
+		ctx.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{Error: fmt.Sprintf("file too large (max %dMB)", MAX_FILE_SIZE_MB)})
+		return
+	}
+
+	metadata, err := parseUploadMetadata(ctx.GetHeader("Upload-Metadata"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	orderID, err := strconv.ParseUint(metadata["order_id"], 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid or missing order_id metadata"})
+		return
+	}
+	documentID, err := strconv.ParseUint(metadata["document_id"], 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid or missing document_id metadata"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	upload, err := c.service.CreateUpload(uint(orderID), uint(documentID), length, actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to create upload")
+		return
+	}
+
+	ctx.Header("Location", strings.TrimRight(ctx.Request.URL.Path, "/")+"/"+upload.ID)
+	setUploadHeaders(ctx, upload)
+	ctx.Status(http.StatusCreated)
+}
+
+// GetUploadOffset godoc
+// @Summary      Get the offset of a resumable upload
+// @Description  Tells how many bytes of the upload were received, in the Upload-Offset header, so the client resumes from there. Only available to the owner of the upload.
+// @Tags         Resumable Uploads
+// @Security     BearerAuth
+// @Param        id             path    string  true  "Upload ID"
+// @Param        Tus-Resumable  header  string  true  "Protocol version, 1.0.0"
+// @Success      200
+// @Header       200  {integer}  Upload-Offset   "Bytes received"
+// @Header       200  {integer}  Upload-Length   "Size of the file in bytes"
+// @Header       200  {string}   Upload-Expires  "Expiry of the upload, RFC 7231 date"
+// @Failure      401  "Unauthorized"
+// @Failure      404  "Upload not found or expired"
+// @Failure      412  "Unsupported protocol version"
+// @Router       /resumable-uploads/{id} [head]
+func (c *resumableUploadController) GetUploadOffset(ctx *gin.Context) {
+	if !checkTusVersion(ctx) {
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	upload, err := c.service.GetUpload(ctx.Param("id"), actor)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to get upload")
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	setUploadHeaders(ctx, upload)
+	ctx.Status(http.StatusOK)
+}
+
+// AppendUpload godoc
+// @Summary      Append to a resumable upload
+// @Description  Appends the body to the upload, from the offset given in Upload-Offset, which must be the bytes received so far. When the connection drops, the bytes received are kept and the client resumes from the offset returned by HEAD. With the last byte the file is attached to its document, and the order is confirmed with POST /orders/{id}/uploads/confirm. Only available to the owner of the upload.
+// @Tags         Resumable Uploads
+// @Accept       application/offset+octet-stream
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path    string   true  "Upload ID"
+// @Param        Tus-Resumable  header  string   true  "Protocol version, 1.0.0"
+// @Param        Upload-Offset  header  integer  true  "Offset of the body in the file"
+// @Success      204
+// @Header       204  {integer}  Upload-Offset   "Bytes received"
+// @Header       204  {string}   Upload-Expires  "Expiry of the upload, RFC 7231 date"
+// @Failure      400  {object}  dto.ErrorResponse "Invalid offset, body past the upload length, or too many parts"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Upload not found or expired"
+// @Failure      409  {object}  dto.ErrorResponse "Offset does not match the bytes received, the upload is receiving another request, the order is not awaiting its documents, or the document already has a file"
+// @Failure      412  {object}  dto.ErrorResponse "Unsupported protocol version"
+// @Failure      415  {object}  dto.ErrorResponse "Content-Type is not application/offset+octet-stream"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to store upload"
+// @Router       /resumable-uploads/{id} [patch]
+func (c *resumableUploadController) AppendUpload(ctx *gin.Context) {
+	if !checkTusVersion(ctx) {
+		return
+	}
+
+	if ctx.ContentType() != TUS_CONTENT_TYPE {
+		ctx.JSON(http.StatusUnsupportedMediaType, dto.ErrorResponse{Error: "Content-Type must be " + TUS_CONTENT_TYPE})
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid Upload-Offset header"})
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	upload, err := c.service.AppendUpload(ctx.Param("id"), offset, ctx.Request.Body, actor)
+	if err != nil {
+		c.logger.Warn("Resumable upload rejected", zap.String("uploadID", ctx.Param("id")), zap.Error(err))
+		HandleServiceError(ctx, err, "failed to store upload")
+		return
+	}
+
+	setUploadHeaders(ctx, upload)
+	ctx.Status(http.StatusNoContent)
+}
+
+// TerminateUpload godoc
+// @Summary      Terminate a resumable upload
+// @Description  Abandons an upload and deletes the bytes received, following the termination extension of tus 1.0. A completed upload stays attached to its document. Only available to the owner of the upload.
+// @Tags         Resumable Uploads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path    string  true  "Upload ID"
+// @Param        Tus-Resumable  header  string  true  "Protocol version, 1.0.0"
+// @Success      204
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Upload not found"
+// @Failure      409  {object}  dto.ErrorResponse "The upload is receiving another request"
+// @Failure      412  {object}  dto.ErrorResponse "Unsupported protocol version"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to terminate upload"
+// @Router       /resumable-uploads/{id} [delete]
+func (c *resumableUploadController) TerminateUpload(ctx *gin.Context) {
+	if !checkTusVersion(ctx) {
+		return
+	}
+
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		c.logger.Error("user not found in context")
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "user not found in context"})
+		return
+	}
+
+	if err := c.service.TerminateUpload(ctx.Param("id"), actor); err != nil {
+		HandleServiceError(ctx, err, "failed to terminate upload")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkTusVersion answers with 412 Precondition Failed when the request does not use the supported
+// version of the protocol. Every answer carries the version of the server.
+func checkTusVersion(ctx *gin.Context) bool {
+	ctx.Header("Tus-Resumable", TUS_VERSION)
+	if ctx.GetHeader("Tus-Resumable") != TUS_VERSION {
+		ctx.Header("Tus-Version", TUS_VERSION)
+		ctx.JSON(http.StatusPreconditionFailed, dto.ErrorResponse{Error: "unsupported Tus-Resumable version, expected " + TUS_VERSION})
+		return false
+	}
+	return true
+}
+
+// setUploadHeaders sets the progress and expiry of an upload
+func setUploadHeaders(ctx *gin.Context, upload *entity.ResumableUpload) {
+	ctx.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	ctx.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata decodes an Upload-Metadata header, made of comma separated pairs of a key
+// and a base64 value. The value may be left out.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, fmt.Errorf("invalid Upload-Metadata header")
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Upload-Metadata value for %s", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/service"
+)
+
+type TaskController interface {
+	ExpireOrders(ctx *gin.Context)
+	PurgeDocuments(ctx *gin.Context)
+	DeliverNotifications(ctx *gin.Context)
+	PurgeUploads(ctx *gin.Context)
+}
+
+type taskController struct {
+	expirer   service.OrderExpirer
+	retention service.DocumentRetention
+	notifier  service.Notifier
+	uploads   service.ResumableUploadService
+	logger    *zap.Logger
+}
+
+// NewTaskController creates a new instance of TaskController.
+func NewTaskController(expirer service.OrderExpirer, retention service.DocumentRetention, notifier service.Notifier, uploads service.ResumableUploadService, logger *zap.Logger) TaskController {
+	return &taskController{
+		expirer:   expirer,
+		retention: retention,
+		notifier:  notifier,
+		uploads:   uploads,
+		logger:    logger,
+	}
+}
+
+// ExpireOrders godoc
+// @Summary      Expire unpaid orders
+// @Description  Cancels the orders left too long awaiting their documents or their payment, and deletes their files from storage. Also runs periodically in the background. Requires the task token.
+// @Tags         System Tasks
+// @Produce      json
+// @Param        X-Printly-Task-Token  header    string  true  "Shared task secret"
+// @Success      200  {object}  dto.OrderExpiryReport
+// @Failure      401  {object}  dto.ErrorResponse "Invalid task token"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to expire orders"
+// @Router       /tasks/order/timeout [post]
+func (c *taskController) ExpireOrders(ctx *gin.Context) {
+	report, err := c.expirer.ExpireStaleOrders(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error("failed to expire orders", zap.Error(err))
+		HandleServiceError(ctx, err, "failed to expire orders")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// PurgeDocuments godoc
+// @Summary      Purge documents
+// @Description  Deletes from storage the files of printed documents, of orders that are over, and of orders older than the maximum age. Failed deletions are retried on the next run. Also runs periodically in the background. Requires the task token.
+// @Tags         System Tasks
+// @Produce      json
+// @Param        X-Printly-Task-Token  header    string  true  "Shared task secret"
+// @Success      200  {object}  dto.DocumentRetentionReport
+// @Failure      401  {object}  dto.ErrorResponse "Invalid task token"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to purge documents"
+// @Router       /tasks/order/cleanup [post]
+func (c *taskController) PurgeDocuments(ctx *gin.Context) {
+	report, err := c.retention.PurgeExpiredDocuments(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error("failed to purge documents", zap.Error(err))
+		HandleServiceError(ctx, err, "failed to purge documents")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// DeliverNotifications godoc
+// @Summary      Deliver notifications
+// @Description  Sends the queued emails and SMS whose next attempt is due. Failed attempts are retried later with an exponential backoff, and given up after the last attempt. Also runs every minute in the background. Requires the task token.
+// @Tags         System Tasks
+// @Produce      json
+// @Param        X-Printly-Task-Token  header    string  true  "Shared task secret"
+// @Success      200  {object}  dto.NotificationDeliveryReport
+// @Failure      401  {object}  dto.ErrorResponse "Invalid task token"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to deliver notifications"
+// @Router       /tasks/notifications/deliver [post]
+func (c *taskController) DeliverNotifications(ctx *gin.Context) {
+	report, err := c.notifier.DeliverDue(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error("failed to deliver notifications", zap.Error(err))
+		HandleServiceError(ctx, err, "failed to deliver notifications")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// PurgeUploads godoc
+// @Summary      Purge resumable uploads
+// @Description  Deletes the expired resumable uploads, and from storage the parts of those never completed. Also runs periodically in the background. Requires the task token.
+// @Tags         System Tasks
+// @Produce      json
+// @Param        X-Printly-Task-Token  header    string  true  "Shared task secret"
+// @Success      200  {object}  dto.ResumableUploadPurgeReport
+// @Failure      401  {object}  dto.ErrorResponse "Invalid task token"
+// @Failure      500  {object}  dto.ErrorResponse "Failed to purge uploads"
+// @Router       /tasks/uploads/cleanup [post]
+func (c *taskController) PurgeUploads(ctx *gin.Context) {
+	report, err := c.uploads.PurgeExpiredUploads(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error("failed to purge uploads", zap.Error(err))
+		HandleServiceError(ctx, err, "failed to purge uploads")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+type TrackingController interface {
+	TrackOrder(ctx *gin.Context)
+}
+
+const (
+	// trackingPingInterval is the interval of the pings keeping tracking sockets open
+	trackingPingInterval = 30 * time.Second
+	// trackingPongWait is how long a tracking socket may stay silent before it is closed
+	trackingPongWait = 2 * trackingPingInterval
+	// trackingWriteTimeout bounds each write to a tracking socket
+	trackingWriteTimeout = 10 * time.Second
+)
+
+// trackingUpgrader accepts sockets from any origin, like the rest of the API:
+// tracking is authorized by its token, not by cookies.
+var trackingUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type trackingController struct {
+	tracker service.OrderTracker
+	logger  *zap.Logger
+}
+
+// NewTrackingController creates a new instance of TrackingController.
+func NewTrackingController(tracker service.OrderTracker, logger *zap.Logger) TrackingController {
+	return &trackingController{
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// TrackOrder godoc
+// @Summary      Track an order live
+// @Description  WebSocket following an order with its pickup code and the tracking token issued when it was created. A dto.OrderTracking JSON message is sent on connection, then each time the status, pickup time or queue position of the order changes. Only status-level data is sent, never documents or customer details. The socket is closed with code 1000 once the order is completed, cancelled or failed, and with code 1013 when the client should reconnect.
+// @Tags         Orders
+// @Param        code   path      string  true  "Pickup code"
+// @Param        token  query     string  true  "Tracking token of the order"
+// @Success      101    {object}  dto.OrderTracking "Switching protocols, then one message per change"
+// @Failure      400    {object}  dto.ErrorResponse "Missing tracking token"
+// @Failure      404    {object}  dto.ErrorResponse "Unknown pickup code or tracking token"
+// @Router       /orders/status/{code}/live [get]
+func (c *trackingController) TrackOrder(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "tracking token is required"})
+		return
+	}
+
+	stream, err := c.tracker.Follow(ctx.Param("code"), token)
+	if err != nil {
+		HandleServiceError(ctx, err, "failed to track order")
+		return
+	}
+	defer stream.Close()
+
+	conn, err := trackingUpgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// The upgrader already replied with an error
+		c.logger.Warn("failed to open tracking socket", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	// Clients send nothing but pongs and close messages. Reading detects closed sockets.
+	closed := make(chan struct{})
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(trackingPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(trackingPongWait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(trackingPingInterval)
+	defer ping.Stop()
+
+	var status entity.OrderStatus
+	for {
+		select {
+		case <-closed:
+			return
+		case tracking, ok := <-stream.Updates:
+			if !ok {
+				code, reason := websocket.CloseTryAgainLater, "reconnect to keep tracking"
+				if slices.Contains(entity.TerminalStatuses, status) {
+					code, reason = websocket.CloseNormalClosure, "order is over"
+				}
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(trackingWriteTimeout))
+				return
+			}
+			status = tracking.Status
+			conn.SetWriteDeadline(time.Now().Add(trackingWriteTimeout))
+			if err := conn.WriteJSON(tracking); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(trackingWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
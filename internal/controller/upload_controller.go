@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/service"
+)
+
+type UploadController interface {
+	ReceiveSignedUpload(ctx *gin.Context)
+}
+
+type uploadController struct {
+	receiver service.SignedUploadReceiver
+	orders   service.OrderService
+	logger   *zap.Logger
+}
+
+// NewUploadController creates a new instance of UploadController.
+func NewUploadController(receiver service.SignedUploadReceiver, orders service.OrderService, logger *zap.Logger) UploadController {
+	return &uploadController{
+		receiver: receiver,
+		orders:   orders,
+		logger:   logger,
+	}
+}
+
+// ReceiveSignedUpload godoc
+// @Summary      Upload a file to a signed upload URL
+// @Description  Receives the file of a document at an upload URL signed by the server, when files are stored locally. The Content-Type header must be the one the URL was issued for. A file is only accepted once, while its order awaits its documents. With cloud storage the signed URLs point to the bucket and this route does not exist.
+// @Tags         Orders
+// @Accept       application/octet-stream
+// @Produce      json
+// @Param        path       path      string  true  "Storage path of the file"
+// @Param        expires    query     int     true  "Expiry of the URL, in Unix seconds"
+// @Param        signature  query     string  true  "Signature of the URL"
+// @Success      204
+// @Failure      400        {object}  dto.ErrorResponse "Invalid expiry"
+// @Failure      403        {object}  dto.ErrorResponse "Upload URL is invalid or has expired"
+// @Failure      409        {object}  dto.ErrorResponse "File already uploaded, or order no longer awaiting its documents"
+// @Failure      413        {object}  dto.ErrorResponse "File too large"
+// @Failure      500        {object}  dto.ErrorResponse "Failed to store file"
+// @Router       /uploads/{path} [put]
+func (c *uploadController) ReceiveSignedUpload(ctx *gin.Context) {
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid expires parameter"})
+		return
+	}
+
+	storagePath := strings.TrimPrefix(ctx.Param("path"), "/")
+	contentType := ctx.GetHeader("Content-Type")
+	signature := ctx.Query("signature")
+
+	// The order is only looked up for URLs signed by this server
+	err = c.receiver.VerifySignedUpload(storagePath, contentType, expires, signature)
+	if err == nil {
+		err = c.orders.CheckDocumentUpload(storagePath)
+	}
+	if err != nil {
+		c.logger.Warn("Signed upload rejected", zap.String("storagePath", storagePath), zap.Error(err))
+		HandleServiceError(ctx, err, "failed to store file")
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MAX_FILE_SIZE)
+	err = c.receiver.ReceiveSignedUpload(storagePath, contentType, expires, signature, body)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{Error: "file too large"})
+		return
+	}
+	if err != nil {
+		c.logger.Warn("Signed upload rejected", zap.String("storagePath", storagePath), zap.Error(err))
+		HandleServiceError(ctx, err, "failed to store file")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -145,6 +145,12 @@ func (c *userController) UpdateUserProfile(ctx *gin.Context) {
 	if req.Disabled {
 		updates["disabled"] = req.Disabled
 	}
+	if req.PhoneNumber != "" {
+		updates["phone_number"] = req.PhoneNumber
+	}
+	if req.Locale != "" {
+		updates["locale"] = req.Locale
+	}
 
 	if err := c.service.UpdateProfile(uid, updates); err != nil {
 		HandleServiceError(ctx, err, "failed to update profile")
@@ -155,20 +161,34 @@ func (c *userController) UpdateUserProfile(ctx *gin.Context) {
 
 // GetAllUsers godoc
 // @Summary      Get all users
-// @Description  Retrieves a list of all users.
+// @Description  Lists one page of users, by registration date by default.
 // @Tags         Users
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200   {array}   entity.User
-// @Failure      500   {object}  dto.ErrorResponse "Failed to fetch users"
+// @Param        limit         query     int     false  "Page size, at most 100" default(20)
+// @Param        offset        query     int     false  "Number of users to skip, ignored with a cursor"
+// @Param        cursor        query     string  false  "next_cursor of the previous page"
+// @Param        sort          query     string  false  "uid, created_at, email, last_name or role, prefixed with - for descending order" default(created_at)
+// @Param        role          query     string  false  "Only return users with this role" Enums(user, manager, admin)
+// @Param        created_from  query     string  false  "Only return users registered at or after this date or time"
+// @Param        created_to    query     string  false  "Only return users registered before this time, or on or before this date"
+// @Success      200           {object}  dto.Page{items=[]entity.User}
+// @Failure      400           {object}  dto.ErrorResponse "Invalid filter, sort or cursor"
+// @Failure      500           {object}  dto.ErrorResponse "Failed to fetch users"
 // @Router       /users [get]
 func (c *userController) GetAllUsers(ctx *gin.Context) {
-	users, err := c.service.GetAll()
+	req, err := bindListRequest(ctx, c.validate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	users, info, err := c.service.GetAll(req)
 	if err != nil {
 		HandleServiceError(ctx, err, "failed to fetch users")
 		return
 	}
-	ctx.JSON(http.StatusOK, users)
+	ctx.JSON(http.StatusOK, dto.Page{Items: users, PageInfo: info})
 }
 
 // DeleteUserByUID godoc
@@ -254,6 +274,10 @@ func (c *userController) UpdateMyProfile(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		return
 	}
+	if err := c.validate.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	// Build a map of fields to update to avoid overwriting with zero values.
 	updates := make(map[string]any)
@@ -269,6 +293,12 @@ func (c *userController) UpdateMyProfile(ctx *gin.Context) {
 	if req.Disabled {
 		updates["disabled"] = req.Disabled
 	}
+	if req.PhoneNumber != "" {
+		updates["phone_number"] = req.PhoneNumber
+	}
+	if req.Locale != "" {
+		updates["locale"] = req.Locale
+	}
 
 	if len(updates) == 0 {
 		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "no fields to update"})
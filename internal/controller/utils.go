@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 	"github.com/kimbasn/printly/internal/dto"
 )
@@ -21,7 +22,42 @@ func HandleServiceError(ctx *gin.Context, err error, defaultMessage string) {
 		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
 	case errors.Is(err, ierrors.ErrOrderNotFound):
 		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrPaymentNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrUnauthorized):
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrPrintCenterNotOperational):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrOrderNotPayable):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrTransitionNotAllowed):
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrOrderCannotBeCancelled):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrOrderStatusConflict):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrTooManyVerificationAttempts):
+		ctx.JSON(http.StatusTooManyRequests, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrPaymentAmountMismatch):
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrMalwareDetected):
+		ctx.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, ierrors.ErrScannerUnavailable), errors.Is(err, ierrors.ErrPaymentsDisabled):
+		ctx.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: err.Error()})
 	default:
 		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: defaultMessage})
 	}
-}
\ No newline at end of file
+}
+
+// actorFromContext builds the actor of the current request from the user set by the authentication middleware.
+func actorFromContext(ctx *gin.Context) (entity.Actor, bool) {
+	value, exists := ctx.Get("user")
+	if !exists {
+		return entity.Actor{}, false
+	}
+	user, ok := value.(*entity.User)
+	if !ok {
+		return entity.Actor{}, false
+	}
+	return entity.Actor{UID: user.UID, Role: user.Role, CenterID: user.CenterID}, true
+}
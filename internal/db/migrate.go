@@ -12,6 +12,15 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.PrintCenter{},
 		&entity.Document{},
 		&entity.Service{},
+		&entity.PriceTier{},
 		&entity.WorkingHour{},
+		&entity.Payment{},
+		&entity.PaymentWebhookEvent{},
+		&entity.OrderStatusHistory{},
+		&entity.JobLease{},
+		&entity.JobRun{},
+		&entity.DeletionReceipt{},
+		&entity.Notification{},
+		&entity.ResumableUpload{},
 	)
 }
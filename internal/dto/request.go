@@ -2,6 +2,7 @@ package dto
 
 import (
 	"mime/multipart"
+	"time"
 
 	"github.com/kimbasn/printly/internal/entity"
 )
@@ -25,7 +26,10 @@ type UpdateUserRequest struct {
 	LastName  string `json:"last_name" validate:"omitempty,"`
 	Email     string `json:"email,omitempty" validate:"omitempty,email"`
 	Disabled  bool   `json:"disabled,omitempty"`
-	// PhoneNumber string `json:"phone_number,omitempty"`
+	// PhoneNumber receives the SMS notifications, in E.164 format
+	PhoneNumber string `json:"phone_number,omitempty" validate:"omitempty,e164"`
+	// Locale is the language of the notifications
+	Locale string `json:"locale,omitempty" validate:"omitempty,oneof=en fr"`
 }
 
 // UpdateUserRoleRequest defines the structure for changing a user's role.
@@ -74,9 +78,58 @@ type CreateOrderRequest struct {
 	Documents []CreateDocumentRequest `json:"documents" validate:"required,min=1,dive"`
 }
 
+// DirectUploadOrderRequest creates an order whose files the client uploads straight to storage.
+type DirectUploadOrderRequest struct {
+	Documents []DirectUploadDocumentRequest `json:"documents" validate:"required,min=1,dive"`
+}
+
+// DirectUploadDocumentRequest declares a document before its file is uploaded.
+// The size and type are checked against the uploaded file when the upload is confirmed.
+type DirectUploadDocumentRequest struct {
+	FileName string `json:"file_name" validate:"required,max=255"`
+	MimeType string `json:"mime_type" validate:"required,oneof=application/pdf application/msword application/vnd.openxmlformats-officedocument.wordprocessingml.document text/plain image/jpeg image/jpg image/png"`
+	Size     int64  `json:"size" validate:"required,min=1,max=52428800"` // 50MB
+	// Prices the order until the file is inspected, the page count of the file is used once it is uploaded
+	PageCount    int                 `json:"page_count,omitempty" validate:"omitempty,min=1"`
+	PrintMode    entity.PrintMode    `json:"print_mode" validate:"required,oneof=PRE_PRINT PRINT_UPON_ARRIVAL"`
+	PrintOptions entity.PrintOptions `json:"print_options" validate:"required"`
+}
+
+// QuoteRequest defines the documents to price before creating an order.
+type QuoteRequest struct {
+	Documents []QuoteDocumentRequest `json:"documents" validate:"required,min=1,dive"`
+}
+
+// QuoteDocumentRequest describes a document to price. No file is uploaded, the page count is given by the client.
+type QuoteDocumentRequest struct {
+	FileName     string              `json:"file_name,omitempty" validate:"max=255"`
+	PageCount    int                 `json:"page_count" validate:"required,min=1"`
+	PrintOptions entity.PrintOptions `json:"print_options" validate:"required"`
+}
+
 // UpdateOrderStatusRequest defines the structure for updating an order's status.
 type UpdateOrderStatusRequest struct {
 	Status entity.OrderStatus `json:"status" validate:"required"`
+	Reason string             `json:"reason,omitempty" validate:"max=255"`
+}
+
+// ScheduleOrderRequest sets when and how a paid order is picked up.
+type ScheduleOrderRequest struct {
+	PickupTime time.Time        `json:"pickup_time" validate:"required" example:"2025-06-25T10:30:00Z"`
+	PrintMode  entity.PrintMode `json:"print_mode" validate:"required,oneof=PRE_PRINT PRINT_UPON_ARRIVAL" example:"PRINT_UPON_ARRIVAL"`
+}
+
+// VerifyPickupRequest carries the pickup code presented by a customer at the counter,
+// either typed in or scanned from the pickup QR code.
+type VerifyPickupRequest struct {
+	Code  string `json:"code,omitempty" validate:"omitempty,alphanum,max=32" example:"A1B2C3"`
+	Token string `json:"token,omitempty" validate:"required_without=Code,max=512"` // scanned QR code payload
+}
+
+// SimulatePaymentRequest defines the outcome the mock payment provider should report.
+type SimulatePaymentRequest struct {
+	// Either SUCCEEDED or FAILED. Defaults to SUCCEEDED.
+	Status entity.PaymentStatus `json:"status,omitempty"`
 }
 
 // DocumentPrintRequest represents the print configuration for a single document
@@ -92,7 +145,13 @@ type CreateDocumentRequest struct {
 	Size         int64               `json:"size" validate:"required,min=1,max=52428800"` // 50MB
 	StoragePath  string              `json:"storage_path,omitempty"`                      // Internal storage path
 	URL          string              `json:"url,omitempty"`                               // For JSON uploads
-	PrintMode    entity.PrintMode    `json:"print_mode" validate:"required,oneof=PRE_PRINT,PRINT_UPON_ARRIVAL"`
+	PageCount    int                 `json:"page_count,omitempty"`
+	PageWidth    float64             `json:"page_width,omitempty"`
+	PageHeight   float64             `json:"page_height,omitempty"`
+	Encrypted    bool                `json:"encrypted,omitempty"`
+	SHA256       string              `json:"-"` // Hex digest of the uploaded content
+	Scan         entity.ScanVerdict  `json:"-"` // Malware scan of the uploaded content
+	PrintMode    entity.PrintMode    `json:"print_mode" validate:"required,oneof=PRE_PRINT PRINT_UPON_ARRIVAL"`
 	PrintOptions entity.PrintOptions `json:"print_options" validate:"required"`
 }
 
@@ -116,3 +175,26 @@ type UploadedFile struct {
 	StoragePath string `json:"storage_path"`
 	URL         string `json:"url"`
 }
+
+// CancelOrderRequest holds the optional reason of a cancellation.
+// It is required when a manager or an admin cancels the order of a customer.
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// ListRequest holds the pagination, sorting and filtering parameters of list endpoints.
+// Filters that do not apply to a list are ignored.
+type ListRequest struct {
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" validate:"omitempty,min=0"`
+	Cursor string `form:"cursor"`
+	// Sort is a column name, prefixed with "-" for descending order
+	Sort     string   `form:"sort" validate:"omitempty,max=32"`
+	Status   []string `form:"status"`
+	CenterID uint     `form:"center_id"`
+	UserUID  string   `form:"user_uid"`
+	Role     string   `form:"role"`
+	// Creation date range, parsed from the created_from and created_to parameters
+	CreatedFrom *time.Time `form:"-"`
+	CreatedTo   *time.Time `form:"-"`
+}
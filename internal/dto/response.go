@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/kimbasn/printly/internal/entity"
+import (
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+)
 
 // ErrorResponse represents a standard error response format for API calls.
 // It's used to provide a consistent structure for error messages.
@@ -22,3 +26,191 @@ type UserResponse struct {
 	Role      entity.Role `json:"role"`
 	Disabled  bool        `json:"disabled"`
 }
+
+// OrderStatusResponse is the public view of an order looked up by its pickup code.
+type OrderStatusResponse struct {
+	Code          string             `json:"code" example:"A1B2C3"`
+	Status        entity.OrderStatus `json:"status" example:"READY_FOR_PICKUP"`
+	PrintCenterID uint               `json:"print_center_id"`
+	PickupTime    *time.Time         `json:"pickup_time,omitempty"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// OrderTracking is the live status of an order, followed by its customer with the pickup code
+// and tracking token. It never carries documents or customer details.
+type OrderTracking struct {
+	Code       string             `json:"code" example:"A1B2C3"`
+	Status     entity.OrderStatus `json:"status" example:"READY_TO_PRINT"`
+	PickupTime *time.Time         `json:"pickup_time,omitempty"`
+	// QueuePosition is 1 when the order is printed next. It is only set while the order waits to be printed.
+	QueuePosition int `json:"queue_position,omitempty" example:"3"`
+	// EstimatedReadyAt is when the order should be printed, while it waits in the queue or is being printed
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Quote is the itemized price of a set of documents at a print center.
+type Quote struct {
+	Currency string      `json:"currency" example:"EUR"`
+	Lines    []QuoteLine `json:"lines"`
+	Total    int64       `json:"total"` // in cents
+}
+
+// QuoteLine is the price of one document.
+type QuoteLine struct {
+	FileName    string             `json:"file_name"`
+	Service     string             `json:"service"`
+	PaperSize   entity.PaperSize   `json:"paper_size"`
+	Color       entity.ColorMode   `json:"color"`
+	Pages       int                `json:"pages"` // selected pages per copy
+	Copies      int                `json:"copies"`
+	DoubleSided bool               `json:"double_sided"`
+	Sheets      int                `json:"sheets"` // for all copies
+	Sides       int                `json:"sides"`  // for all copies
+	PricingUnit entity.PricingUnit `json:"pricing_unit"`
+	Quantity    int                `json:"quantity"`   // charged units, sheets or sides
+	UnitPrice   int64              `json:"unit_price"` // in cents, after volume tiers
+	Amount      int64              `json:"amount"`     // in cents
+	// Set when the page count could not be read from the file and was estimated from its size
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// JobStatus describes a background job and its last run.
+type JobStatus struct {
+	Name        string         `json:"name" example:"job-runs-cleanup"`
+	Description string         `json:"description"`
+	Schedule    string         `json:"schedule" example:"0 3 * * *"`
+	NextRun     time.Time      `json:"next_run"`
+	Running     bool           `json:"running"` // on this replica
+	LastRun     *entity.JobRun `json:"last_run,omitempty"`
+}
+
+// OrderExpiryReport summarizes one run of the unpaid order expiry.
+type OrderExpiryReport struct {
+	ExpiredOrders    []uint   `json:"expired_orders"`
+	DeletedDocuments int      `json:"deleted_documents"`
+	Failures         []string `json:"failures,omitempty"`
+}
+
+// NotificationDeliveryReport summarizes one run of the notification delivery.
+type NotificationDeliveryReport struct {
+	Sent     int      `json:"sent"`
+	Retried  int      `json:"retried"` // failed attempts that will be retried later
+	Failed   int      `json:"failed"`  // notifications given up after their last attempt
+	Failures []string `json:"failures,omitempty"`
+}
+
+// DocumentRetentionReport summarizes one run of the document purge.
+type DocumentRetentionReport struct {
+	PurgedOrders     []uint   `json:"purged_orders"`
+	DeletedDocuments int      `json:"deleted_documents"`
+	Failures         []string `json:"failures,omitempty"`
+}
+
+// ResumableUploadPurgeReport summarizes one run of the cleanup of expired resumable uploads.
+type ResumableUploadPurgeReport struct {
+	DeletedUploads int      `json:"deleted_uploads"`
+	DeletedParts   int      `json:"deleted_parts"`
+	Failures       []string `json:"failures,omitempty"`
+}
+
+// DocumentPurgeReport tells the customer which files of an order are still stored.
+type DocumentPurgeReport struct {
+	OrderID   uint                 `json:"order_id"`
+	Status    entity.OrderStatus   `json:"status"`
+	AllPurged bool                 `json:"all_purged"`
+	Documents []DocumentPurgeState `json:"documents"`
+}
+
+// DocumentPurgeState is the storage state of one document of a purge report.
+type DocumentPurgeState struct {
+	ID               uint       `json:"id"`
+	FileName         string     `json:"file_name"`
+	PrintedAt        *time.Time `json:"printed_at,omitempty"`
+	StorageDeletedAt *time.Time `json:"storage_deleted_at,omitempty"`
+	Deleted          bool       `json:"deleted"`
+}
+
+// DeletionReceiptVerification is the outcome of checking a deletion receipt.
+type DeletionReceiptVerification struct {
+	Valid bool `json:"valid"`
+}
+
+// CancelOrderResponse is the outcome of an order cancellation.
+// The order is cancelled even when its refund or the deletion of its documents failed.
+type CancelOrderResponse struct {
+	Order            *entity.Order   `json:"order"`
+	Refund           *entity.Payment `json:"refund,omitempty"`
+	DeletedDocuments int             `json:"deleted_documents"`
+	Failures         []string        `json:"failures,omitempty"`
+}
+
+// CreatedOrder is an order as returned to its owner when it is created, the only time its
+// tracking token is sent.
+type CreatedOrder struct {
+	entity.Order
+	TrackingToken string `json:"tracking_token"`
+}
+
+// DirectUploadOrder is an order awaiting its documents, with where to upload the file of each of them.
+type DirectUploadOrder struct {
+	Order   CreatedOrder     `json:"order"`
+	Uploads []DocumentUpload `json:"uploads"`
+}
+
+// DocumentUpload tells the client how to upload the file of one document straight to storage.
+type DocumentUpload struct {
+	DocumentID uint              `json:"document_id"`
+	FileName   string            `json:"file_name"`
+	Method     string            `json:"method" example:"PUT"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"` // Headers the upload must be sent with
+	ExpiresAt  time.Time         `json:"expires_at"`
+}
+
+// UserOrder is an order as listed to its owner, with the price of its documents
+// at the current rates of its print center.
+type UserOrder struct {
+	entity.Order
+	Price *Quote `json:"price,omitempty"`
+}
+
+// PageInfo describes a page of a list response.
+type PageInfo struct {
+	// Total counts the items matching the filters, on all pages
+	Total int64 `json:"total"`
+	// NextCursor fetches the next page when passed as the cursor parameter, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Page is the envelope of list responses.
+type Page struct {
+	// Items is the slice of listed items
+	Items any `json:"items"`
+	PageInfo
+}
+
+// OrderEventType names the kind of change an order event reports.
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "order.created"
+	OrderEventPaid          OrderEventType = "order.paid"
+	OrderEventStatusChanged OrderEventType = "order.status_changed"
+	OrderEventCancelled     OrderEventType = "order.cancelled"
+)
+
+// OrderEvent is a change of an order pushed to the live order stream of its print center.
+type OrderEvent struct {
+	// ID increases with every event published, and resumes a stream as its Last-Event-ID
+	ID             uint64             `json:"id"`
+	Type           OrderEventType     `json:"type"`
+	OrderID        uint               `json:"order_id"`
+	PrintCenterID  uint               `json:"print_center_id"`
+	Code           string             `json:"code"`
+	Status         entity.OrderStatus `json:"status"`
+	PreviousStatus entity.OrderStatus `json:"previous_status,omitempty"`
+	TotalCost      int64              `json:"total_cost"`
+	Currency       string             `json:"currency"`
+	OccurredAt     time.Time          `json:"occurred_at"`
+}
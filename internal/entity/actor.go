@@ -0,0 +1,35 @@
+package entity
+
+// Actor identifies who triggers an action on an order: a user, a manager,
+// an admin or the platform itself (payment webhooks, background tasks).
+type Actor struct {
+	UID      string
+	Role     Role
+	CenterID *uint // Set for managers, the center they work for
+}
+
+// SystemActor is used for transitions performed by the platform itself
+var SystemActor = Actor{UID: "system", Role: RoleSystem}
+
+// Helper methods for Actor
+
+func (a Actor) IsSystem() bool {
+	return a.Role == RoleSystem
+}
+
+// ManagesCenter reports whether the actor is a manager of the given center
+func (a Actor) ManagesCenter(centerID uint) bool {
+	return a.Role == RoleManager && a.CenterID != nil && *a.CenterID == centerID
+}
+
+// CanAccessOrder reports whether the actor may read the given order
+func (a Actor) CanAccessOrder(o *Order) bool {
+	switch a.Role {
+	case RoleAdmin, RoleSystem:
+		return true
+	case RoleManager:
+		return a.ManagesCenter(o.PrintCenterID) || o.UserUID == a.UID
+	default:
+		return o.UserUID == a.UID
+	}
+}
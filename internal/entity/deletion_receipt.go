@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+)
+
+// DeletionReceipt is the signed proof, issued to the customer, that the file of a document was deleted from storage.
+// Only hashes of the file name and content are kept, so the receipt reveals nothing about the document.
+type DeletionReceipt struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+
+	Version        string    `gorm:"type:varchar(16)" json:"version" validate:"required"`
+	DocumentID     uint      `gorm:"uniqueIndex;not null" json:"document_id" validate:"required"`
+	OrderCode      string    `gorm:"index;type:varchar(32)" json:"order_code" validate:"required"`
+	FileNameSHA256 string    `gorm:"type:varchar(64)" json:"file_name_sha256" validate:"required,len=64,hexadecimal"`
+	ContentSHA256  string    `gorm:"type:varchar(64)" json:"content_sha256" validate:"omitempty,len=64,hexadecimal"` // empty for files uploaded before hashing
+	StorageBackend string    `gorm:"type:varchar(16)" json:"storage_backend" validate:"required"`
+	DeletedAt      time.Time `json:"deleted_at" validate:"required"`
+	Signature      string    `gorm:"type:varchar(128)" json:"signature" validate:"required"`
+}
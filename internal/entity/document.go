@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type ColorMode string
@@ -13,9 +15,30 @@ const (
 	BlackAndWhite ColorMode = "BLACK_AND_WHITE"
 )
 
+// ScanStatus is the verdict of the malware scan of an uploaded file
+type ScanStatus string
+
+const (
+	ScanClean    ScanStatus = "CLEAN"
+	ScanInfected ScanStatus = "INFECTED"
+)
+
+// ScanVerdict records the malware scan of an uploaded file. Status is empty until the file is scanned.
+type ScanVerdict struct {
+	Status    ScanStatus `gorm:"type:varchar(16)" json:"status,omitempty"`
+	Signature string     `gorm:"type:varchar(255)" json:"signature,omitempty"` // Name of the malware found
+	Engine    string     `gorm:"type:varchar(64)" json:"engine,omitempty"`
+	Time      *time.Time `json:"time,omitempty"`
+}
+
+// Infected reports whether the scan found malware
+func (v ScanVerdict) Infected() bool {
+	return v.Status == ScanInfected
+}
+
 type PrintOptions struct {
 	Copies      int       `json:"copies" validate:"min=1,max=100"`
-	Pages       string    `json:"pages" validate:"required"` // e.g., "1-3,5" - add custom validation
+	Pages       string    `json:"pages" validate:"required,page-range"` // e.g. "1-3,5", "all", "odd", "even"
 	Color       ColorMode `json:"color" gorm:"type:varchar(16)" validate:"required"`
 	PaperSize   PaperSize `json:"paper_size" gorm:"type:varchar(8)" validate:"required"`
 	DoubleSided bool      `json:"double_sided" gorm:"default:true"`
@@ -24,13 +47,29 @@ type PrintOptions struct {
 type Document struct {
 	ID uint `gorm:"primaryKey" json:"id"`
 
-	OrderID  uint   `gorm:"index;not null" json:"order_id"`
-	FileName string `gorm:"type:varchar(255)" json:"file_name" validate:"required,max=255"`
-	MimeType string `gorm:"type:varchar(128)" json:"mime_type" validate:"required"`
-	//StoragePath string     `gorm:"type:text" json:"-"`                 // Internal storage path
-	Size       int64      `json:"size" validate:"min=1,max=52428800"` // 50MB limit
-	UploadedAt *time.Time `json:"uploaded_at,omitempty"`
+	OrderID     uint       `gorm:"index;not null" json:"order_id"`
+	FileName    string     `gorm:"type:varchar(255)" json:"file_name" validate:"required,max=255"`
+	MimeType    string     `gorm:"type:varchar(128)" json:"mime_type" validate:"required"`
+	StoragePath string     `gorm:"type:text" json:"-"`                 // Internal storage path
+	Size        int64      `json:"size" validate:"min=1,max=52428800"` // 50MB limit
+	UploadedAt  *time.Time `json:"uploaded_at,omitempty"`
+
+	// Filled by inspecting the file at upload time. PageCount is 0 when it can not be known before printing.
+	PageCount  int     `json:"page_count"`
+	PageWidth  float64 `json:"page_width,omitempty"`  // in points, first page
+	PageHeight float64 `json:"page_height,omitempty"` // in points, first page
+	Encrypted  bool    `json:"encrypted"`
 
+	// Hex SHA-256 of the uploaded content, kept for the deletion receipt
+	ContentSHA256 string `gorm:"type:varchar(64)" json:"-"`
+
+	// Malware scan of the uploaded file, before the document is accepted
+	Scan ScanVerdict `gorm:"embedded;embeddedPrefix:scan_" json:"scan"`
+
+	// Pages to print resolved from PrintOptions.Pages, empty when the page count is unknown
+	SelectedPages []int `gorm:"-" json:"selected_pages,omitempty"`
+
+	PrintMode    PrintMode    `gorm:"type:varchar(32)" json:"print_mode"`
 	PrintOptions PrintOptions `gorm:"embedded;embeddedPrefix:print_" json:"print_options"`
 
 	PrintedAt        *time.Time `json:"printed_at,omitempty"`
@@ -64,9 +103,41 @@ func (d *Document) IsPDF() bool {
 	return d.MimeType == "application/pdf"
 }
 
+// ResolvePages fills SelectedPages from the print options and the page count.
+func (d *Document) ResolvePages() error {
+	if d.PageCount == 0 {
+		d.SelectedPages = nil
+		return nil
+	}
+	pages, err := d.PrintOptions.SelectedPages(d.PageCount)
+	if err != nil {
+		return err
+	}
+	d.SelectedPages = pages
+	return nil
+}
+
+// AfterFind resolves the selected pages of documents loaded from the database.
+func (d *Document) AfterFind(tx *gorm.DB) error {
+	// Stored options were validated when the order was created
+	_ = d.ResolvePages()
+	return nil
+}
+
+// SelectedPages returns the pages to print of a document with the given page count.
+func (po *PrintOptions) SelectedPages(pageCount int) ([]int, error) {
+	r, err := ParsePageRange(po.Pages)
+	if err != nil {
+		return nil, err
+	}
+	return r.Pages(pageCount)
+}
+
 // Calculate total cost for print options
-func (po *PrintOptions) CalculateCost(pricePerPage int64) int64 {
-	// This is a simplified calculation
-	// You'd need to implement proper page range parsing
-	return int64(po.Copies) * pricePerPage
+func (po *PrintOptions) CalculateCost(pricePerPage int64, pageCount int) (int64, error) {
+	pages, err := po.SelectedPages(pageCount)
+	if err != nil {
+		return 0, err
+	}
+	return int64(po.Copies) * int64(len(pages)) * pricePerPage, nil
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+)
+
+type JobRunStatus string
+
+const (
+	JobRunning   JobRunStatus = "RUNNING"
+	JobSucceeded JobRunStatus = "SUCCEEDED"
+	JobFailed    JobRunStatus = "FAILED"
+)
+
+type JobTrigger string
+
+const (
+	TriggerSchedule JobTrigger = "SCHEDULE"
+	TriggerManual   JobTrigger = "MANUAL"
+)
+
+// JobLease grants one replica the right to run a background job until it expires
+type JobLease struct {
+	Name      string    `gorm:"primaryKey;type:varchar(64)"`
+	Holder    string    `gorm:"type:varchar(128)"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// JobRun records one execution of a background job
+type JobRun struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	JobName     string       `gorm:"index;type:varchar(64)" json:"job_name"`
+	Trigger     JobTrigger   `gorm:"type:varchar(16)" json:"trigger"`
+	TriggeredBy string       `json:"triggered_by,omitempty"`          // UID of the admin for manual runs
+	Holder      string       `gorm:"type:varchar(128)" json:"holder"` // replica that ran the job
+	Status      JobRunStatus `gorm:"index;type:varchar(16)" json:"status"`
+	StartedAt   time.Time    `json:"started_at"`
+	FinishedAt  *time.Time   `json:"finished_at,omitempty"`
+	DurationMs  int64        `json:"duration_ms"`
+	Error       string       `gorm:"type:text" json:"error,omitempty"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+)
+
+// NotificationEvent is what a notification tells its recipient about
+type NotificationEvent string
+
+const (
+	NotifyOrderPaid           NotificationEvent = "order_paid"
+	NotifyOrderReadyForPickup NotificationEvent = "order_ready_for_pickup"
+	NotifyOrderExpiringSoon   NotificationEvent = "order_expiring_soon"
+	NotifyOrderCancelled      NotificationEvent = "order_cancelled"
+	NotifyCenterApproved      NotificationEvent = "center_approved"
+)
+
+// NotificationChannel is the medium a notification is sent through
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "PENDING"
+	NotificationSent    NotificationStatus = "SENT"
+	NotificationFailed  NotificationStatus = "FAILED" // given up after the last attempt
+)
+
+// NotificationStatuses lists every notification status
+var NotificationStatuses = []NotificationStatus{NotificationPending, NotificationSent, NotificationFailed}
+
+// Notification records one message queued for a recipient, and the outcome of its delivery
+type Notification struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Event         NotificationEvent   `gorm:"index;type:varchar(32)" json:"event"`
+	Channel       NotificationChannel `gorm:"type:varchar(16)" json:"channel"`
+	UserUID       string              `gorm:"index" json:"user_uid"`
+	OrderID       *uint               `gorm:"index" json:"order_id,omitempty"`
+	PrintCenterID *uint               `gorm:"index" json:"print_center_id,omitempty"`
+	Recipient     string              `json:"recipient"` // email address or phone number
+	Locale        string              `gorm:"type:varchar(8)" json:"locale"`
+	Subject       string              `json:"subject,omitempty"`
+	Body          string              `gorm:"type:text" json:"body"`
+
+	// Delivery
+	Status        NotificationStatus `gorm:"index;type:varchar(16)" json:"status"`
+	Attempts      int                `json:"attempts"`
+	NextAttemptAt time.Time          `gorm:"index" json:"next_attempt_at"`
+	SentAt        *time.Time         `json:"sent_at,omitempty"`
+	LastError     string             `gorm:"type:text" json:"last_error,omitempty"`
+}
@@ -25,6 +25,25 @@ const (
 	StatusFailed           OrderStatus = "FAILED"
 )
 
+// TerminalStatuses are the statuses an order never leaves
+var TerminalStatuses = []OrderStatus{StatusCompleted, StatusCancelled, StatusFailed}
+
+// OrderStatuses lists every order status, in lifecycle order
+var OrderStatuses = []OrderStatus{
+	StatusCreated,
+	StatusAwaitingDocument,
+	StatusPendingPayment,
+	StatusPaid,
+	StatusAwaitingUser,
+	StatusReadyToPrint,
+	StatusPrinting,
+	StatusPrinted,
+	StatusReadyForPickup,
+	StatusCompleted,
+	StatusCancelled,
+	StatusFailed,
+}
+
 type PrintMode string
 
 const (
@@ -57,14 +76,22 @@ type Order struct {
 	TotalCost int64  `json:"total_cost" validate:"min=0"`                   // in cents
 	Currency  string `json:"currency" gorm:"type:varchar(3);default:'EUR'"` // ISO currency code
 
+	// Pickup
+	PrintMode PrintMode `gorm:"type:varchar(32)" json:"print_mode,omitempty"`
+	// TrackingToken lets the customer follow the order live together with its code, without signing in.
+	// It is never serialized with the order, only returned to its owner when the order is created.
+	TrackingToken string `gorm:"type:varchar(64)" json:"-"`
+
 	// Timestamps
 	PickupTime  *time.Time `json:"pickup_time,omitempty"`
 	PaidAt      *time.Time `json:"paid_at,omitempty"`
 	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"` // pickup code checked at the counter
 
 	// Audit fields
-	CreatedBy string `gorm:"index" json:"created_by"`
-	UpdatedBy string `gorm:"index" json:"updated_by"`
+	CreatedBy  string `gorm:"index" json:"created_by"`
+	UpdatedBy  string `gorm:"index" json:"updated_by"`
+	VerifiedBy string `gorm:"index" json:"verified_by,omitempty"`
 
 	// Relationships
 	Documents []Document  `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"documents"`
@@ -84,13 +111,13 @@ func (o *Order) CanCancel() bool {
 func (o *Order) CanTransitionTo(newStatus OrderStatus) bool {
 	validTransitions := map[OrderStatus][]OrderStatus{
 		StatusCreated:          {StatusAwaitingDocument, StatusCancelled},
-		StatusAwaitingDocument: {StatusPendingPayment, StatusCancelled},
+		StatusAwaitingDocument: {StatusPendingPayment, StatusCancelled, StatusFailed},
 		StatusPendingPayment:   {StatusPaid, StatusCancelled, StatusFailed},
 		StatusPaid:             {StatusAwaitingUser, StatusReadyToPrint, StatusCancelled},
-		StatusAwaitingUser:     {StatusReadyToPrint, StatusCancelled},
+		StatusAwaitingUser:     {StatusReadyToPrint, StatusPrinting, StatusCancelled},
 		StatusReadyToPrint:     {StatusPrinting, StatusCancelled},
 		StatusPrinting:         {StatusPrinted, StatusFailed},
-		StatusPrinted:          {StatusReadyForPickup},
+		StatusPrinted:          {StatusReadyForPickup, StatusCompleted},
 		StatusReadyForPickup:   {StatusCompleted},
 		// Terminal states
 		StatusCompleted: {},
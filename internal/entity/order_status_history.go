@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+)
+
+// OrderStatusHistory records one accepted transition of an order's status
+type OrderStatusHistory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrderID    uint        `gorm:"index;not null" json:"order_id"`
+	FromStatus OrderStatus `gorm:"type:varchar(32)" json:"from_status"`
+	ToStatus   OrderStatus `gorm:"type:varchar(32)" json:"to_status"`
+	ActorUID   string      `gorm:"index" json:"actor_uid"`
+	ActorRole  Role        `gorm:"type:varchar(16)" json:"actor_role"`
+	Reason     string      `gorm:"type:text" json:"reason,omitempty"`
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Page range keywords
+const (
+	PagesAll  = "all"
+	PagesOdd  = "odd"
+	PagesEven = "even"
+)
+
+var ErrPageCountUnknown = errors.New("page count of the document is unknown")
+
+// PageRange is a parsed PrintOptions.Pages expression.
+// The grammar is either one of the keywords "all", "odd" and "even", or a comma separated
+// list of single pages ("5") and ascending ranges ("1-3"). Pages are numbered from 1, and a page
+// may only be selected once, so that it is neither printed nor charged twice.
+type PageRange struct {
+	keyword string
+	spans   [][2]int // inclusive bounds, only set when keyword is empty
+}
+
+// ParsePageRange parses a page range expression, ignoring case and whitespace.
+func ParsePageRange(expr string) (PageRange, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	switch expr {
+	case "":
+		return PageRange{}, errors.New("page range is empty")
+	case PagesAll, PagesOdd, PagesEven:
+		return PageRange{keyword: expr}, nil
+	}
+
+	var r PageRange
+	for part := range strings.SplitSeq(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return PageRange{}, fmt.Errorf("page range %q has an empty item", expr)
+		}
+
+		from, to, isSpan := strings.Cut(part, "-")
+		start, err := parsePageNumber(from)
+		if err != nil {
+			return PageRange{}, err
+		}
+		end := start
+		if isSpan {
+			if end, err = parsePageNumber(to); err != nil {
+				return PageRange{}, err
+			}
+			if start > end {
+				return PageRange{}, fmt.Errorf("page range %q is reversed", part)
+			}
+		}
+		for _, span := range r.spans {
+			if start <= span[1] && span[0] <= end {
+				return PageRange{}, fmt.Errorf("page range %q selects page %d more than once", expr, max(start, span[0]))
+			}
+		}
+		r.spans = append(r.spans, [2]int{start, end})
+	}
+	return r, nil
+}
+
+func parsePageNumber(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid page number %q", s)
+	}
+	return n, nil
+}
+
+// Pages resolves the range against a document with the given number of pages.
+// Pages are returned in the order they will be printed. Every explicit page must exist in the document.
+func (r PageRange) Pages(pageCount int) ([]int, error) {
+	if pageCount <= 0 {
+		return nil, ErrPageCountUnknown
+	}
+
+	var pages []int
+	switch r.keyword {
+	case PagesAll, PagesOdd, PagesEven:
+		first, step := 1, 1
+		if r.keyword == PagesOdd {
+			step = 2
+		} else if r.keyword == PagesEven {
+			first, step = 2, 2
+		}
+		for p := first; p <= pageCount; p += step {
+			pages = append(pages, p)
+		}
+	default:
+		for _, span := range r.spans {
+			if span[1] > pageCount {
+				return nil, fmt.Errorf("page %d is out of range, the document has %d pages", span[1], pageCount)
+			}
+			for p := span[0]; p <= span[1]; p++ {
+				pages = append(pages, p)
+			}
+		}
+	}
+
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no page selected in a document of %d pages", pageCount)
+	}
+	return pages, nil
+}
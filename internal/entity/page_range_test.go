@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/stretchr/testify/suite"
+)
+
+type PageRangeTestSuite struct {
+	suite.Suite
+}
+
+func TestPageRange(t *testing.T) {
+	suite.Run(t, new(PageRangeTestSuite))
+}
+
+// ============================================================================
+// ParsePageRange Tests
+// ============================================================================
+
+func (s *PageRangeTestSuite) TestPages_Valid() {
+	tests := []struct {
+		expr      string
+		pageCount int
+		want      []int
+	}{
+		{"all", 3, []int{1, 2, 3}},
+		{" ALL ", 2, []int{1, 2}},
+		{"odd", 5, []int{1, 3, 5}},
+		{"even", 5, []int{2, 4}},
+		{"1-3,5", 5, []int{1, 2, 3, 5}},
+		{"2 - 3 , 1", 3, []int{2, 3, 1}},
+		{"4", 4, []int{4}},
+		{"2-2", 2, []int{2}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.expr, func() {
+			// Act
+			r, err := entity.ParsePageRange(tt.expr)
+			s.Require().NoError(err)
+			pages, err := r.Pages(tt.pageCount)
+
+			// Assert
+			s.NoError(err)
+			s.Equal(tt.want, pages)
+		})
+	}
+}
+
+func (s *PageRangeTestSuite) TestParse_Invalid() {
+	for _, expr := range []string{"", "3-1", "0", "-2", "1-", "1,,2", "a-b", "1;2", "odd,1", "1.5"} {
+		s.Run(expr, func() {
+			// Act
+			_, err := entity.ParsePageRange(expr)
+
+			// Assert
+			s.Error(err)
+		})
+	}
+}
+
+func (s *PageRangeTestSuite) TestParse_OverlappingSpans() {
+	// Act
+	_, err := entity.ParsePageRange("1-3,2-4")
+
+	// Assert: page 2 would be printed and charged twice
+	s.ErrorContains(err, "selects page 2 more than once")
+}
+
+func (s *PageRangeTestSuite) TestParse_DuplicatePages() {
+	for _, expr := range []string{"1,1", "5,1-5", "2-4, 3"} {
+		s.Run(expr, func() {
+			// Act
+			_, err := entity.ParsePageRange(expr)
+
+			// Assert
+			s.ErrorContains(err, "more than once")
+		})
+	}
+}
+
+func (s *PageRangeTestSuite) TestPages_OutOfDocument() {
+	// Arrange
+	r, err := entity.ParsePageRange("1-3,8")
+	s.Require().NoError(err)
+
+	// Act
+	_, err = r.Pages(5)
+
+	// Assert
+	s.ErrorContains(err, "page 8 is out of range")
+}
+
+func (s *PageRangeTestSuite) TestPages_NothingSelected() {
+	// Arrange
+	r, err := entity.ParsePageRange("even")
+	s.Require().NoError(err)
+
+	// Act
+	_, err = r.Pages(1)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *PageRangeTestSuite) TestPages_UnknownPageCount() {
+	// Arrange
+	r, err := entity.ParsePageRange("all")
+	s.Require().NoError(err)
+
+	// Act
+	_, err = r.Pages(0)
+
+	// Assert
+	s.ErrorIs(err, entity.ErrPageCountUnknown)
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentPending   PaymentStatus = "PENDING"
+	PaymentSucceeded PaymentStatus = "SUCCEEDED"
+	PaymentFailed    PaymentStatus = "FAILED"
+	// Refunds are recorded on the payment they return
+	PaymentRefundPending PaymentStatus = "REFUND_PENDING"
+	PaymentRefunded      PaymentStatus = "REFUNDED"
+)
+
+type Payment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrderID     uint          `gorm:"index;not null" json:"order_id"`
+	Provider    string        `gorm:"type:varchar(32)" json:"provider"`
+	SessionID   string        `gorm:"uniqueIndex;type:varchar(128)" json:"session_id"`
+	CheckoutURL string        `gorm:"type:text" json:"checkout_url"`
+	Amount      int64         `json:"amount"`                          // in cents
+	Currency    string        `gorm:"type:varchar(3)" json:"currency"` // ISO currency code
+	Status      PaymentStatus `gorm:"index;type:varchar(16)" json:"status"`
+
+	// Last webhook event applied to this payment. Every applied event is kept in PaymentWebhookEvent.
+	LastEventID   string     `gorm:"type:varchar(128)" json:"-"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+
+	RefundID     string     `gorm:"type:varchar(128)" json:"refund_id,omitempty"`
+	RefundReason string     `json:"refund_reason,omitempty"`
+	RefundedAt   *time.Time `json:"refunded_at,omitempty"`
+
+	Order Order `gorm:"foreignKey:OrderID;references:ID" json:"-"`
+}
+
+// Helper methods for Payment
+
+func (p *Payment) IsFinal() bool {
+	return p.Status == PaymentSucceeded || p.Status == PaymentFailed
+}
+
+// CanTransitionTo tells whether the payment may move to the given status. A payment never goes
+// back, so that a delayed success event can not revive a refunded payment.
+func (p *Payment) CanTransitionTo(newStatus PaymentStatus) bool {
+	validTransitions := map[PaymentStatus][]PaymentStatus{
+		PaymentPending:       {PaymentSucceeded, PaymentFailed},
+		PaymentFailed:        {PaymentSucceeded},
+		PaymentSucceeded:     {PaymentRefundPending, PaymentRefunded},
+		PaymentRefundPending: {PaymentRefunded},
+		PaymentRefunded:      {},
+	}
+	return slices.Contains(validTransitions[p.Status], newStatus)
+}
+
+// PaymentWebhookEvent records a provider event applied to a payment, so that it is applied only once.
+type PaymentWebhookEvent struct {
+	ID        uint          `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time     `json:"created_at"`
+	EventID   string        `gorm:"uniqueIndex;type:varchar(128);not null" json:"event_id"`
+	PaymentID uint          `gorm:"index;not null" json:"payment_id"`
+	Status    PaymentStatus `gorm:"type:varchar(16)" json:"status"`
+}
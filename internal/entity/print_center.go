@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -37,6 +38,35 @@ type WorkingHour struct {
 	PrintCenterID uint    `json:"-"`
 }
 
+// Helper methods for WorkingHour
+
+// Contains reports whether the time of day of t falls within the working hour, start included and end excluded.
+// Entries with malformed or inverted times never match.
+func (wh WorkingHour) Contains(t time.Time) bool {
+	if Weekday(t.Weekday().String()) != wh.Day {
+		return false
+	}
+	start, okStart := parseClock(wh.Start)
+	end, okEnd := parseClock(wh.End)
+	if !okStart || !okEnd || end <= start {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	return minute >= start && minute < end
+}
+
+// parseClock converts an HH:MM time of day to minutes since midnight, "24:00" included
+func parseClock(s string) (int, bool) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		if strings.TrimSpace(s) == "24:00" {
+			return 24 * 60, true
+		}
+		return 0, false
+	}
+	return clock.Hour()*60 + clock.Minute(), true
+}
+
 type Service struct {
 	// gorm.Model is replaced to be explicit for swagger
 	ID        uint           `gorm:"primaryKey" json:"-"`
@@ -49,6 +79,88 @@ type Service struct {
 	PaperSize     string  `json:"paper_size" validate:"required"`
 	Price         int64   `json:"price" validate:"min=0"`      
 	Description   string  `json:"description" validate:"max=500"`
+
+	// Empty color mode means the service applies to both color and black and white prints
+	ColorMode   ColorMode   `json:"color_mode,omitempty" gorm:"type:varchar(16)" validate:"omitempty,oneof=COLOR BLACK_AND_WHITE"`
+	PricingUnit PricingUnit `json:"pricing_unit,omitempty" gorm:"type:varchar(16)" validate:"omitempty,oneof=PER_SHEET PER_SIDE"`
+	Tiers       []PriceTier `json:"tiers,omitempty" gorm:"foreignKey:ServiceID;constraint:OnDelete:CASCADE" validate:"dive"`
+}
+
+type PricingUnit string
+
+const (
+	PerSheet PricingUnit = "PER_SHEET" // A duplex sheet costs the same as a simplex one
+	PerSide  PricingUnit = "PER_SIDE"  // Every printed side is charged
+)
+
+// PriceTier replaces the service price once the ordered quantity reaches MinQuantity
+type PriceTier struct {
+	ID          uint  `gorm:"primaryKey" json:"-"`
+	ServiceID   uint  `gorm:"index" json:"-"`
+	MinQuantity int   `json:"min_quantity" validate:"min=1"`
+	Price       int64 `json:"price" validate:"min=0"` // in cents, per unit
+}
+
+// Helper methods for Service
+
+// Unit returns how the service is charged, per side unless configured otherwise
+func (s *Service) Unit() PricingUnit {
+	if s.PricingUnit == "" {
+		return PerSide
+	}
+	return s.PricingUnit
+}
+
+// UnitPrice returns the price of one unit when the given quantity is ordered
+func (s *Service) UnitPrice(quantity int) int64 {
+	price, threshold := s.Price, 0
+	for _, tier := range s.Tiers {
+		if quantity >= tier.MinQuantity && tier.MinQuantity > threshold {
+			price, threshold = tier.Price, tier.MinQuantity
+		}
+	}
+	return price
+}
+
+// FindService returns the service of the center for the given paper size and color mode.
+// A service dedicated to the color mode is preferred over one that applies to both.
+func (pc *PrintCenter) FindService(paperSize PaperSize, color ColorMode) (*Service, bool) {
+	var generic *Service
+	for i := range pc.Services {
+		service := &pc.Services[i]
+		if !strings.EqualFold(service.PaperSize, string(paperSize)) {
+			continue
+		}
+		if service.ColorMode == color {
+			return service, true
+		}
+		if service.ColorMode == "" && generic == nil {
+			generic = service
+		}
+	}
+	return generic, generic != nil
+}
+
+// IsOpenAt reports whether one of the working hours of the center contains t.
+// Working hours are expressed in the location of t.
+func (pc *PrintCenter) IsOpenAt(t time.Time) bool {
+	for _, wh := range pc.WorkingHours {
+		if wh.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// HoursOn returns the working hours of the center on the given day
+func (pc *PrintCenter) HoursOn(day Weekday) []WorkingHour {
+	var hours []WorkingHour
+	for _, wh := range pc.WorkingHours {
+		if wh.Day == day {
+			hours = append(hours, wh)
+		}
+	}
+	return hours
 }
 
 type PrintCenterStatus string
@@ -59,6 +171,9 @@ const (
 	StatusSuspended PrintCenterStatus = "suspended"
 )
 
+// PrintCenterStatuses lists every print center status
+var PrintCenterStatuses = []PrintCenterStatus{StatusPending, StatusApproved, StatusRejected, StatusSuspended}
+
 type PrintCenter struct {
 	// gorm.Model is replaced to be explicit for swagger
 	ID        uint           `gorm:"primaryKey" json:"id"`
@@ -78,4 +193,5 @@ type PrintCenter struct {
 
 	Status   PrintCenterStatus `json:"status" gorm:"type:varchar(32);default:'pending';index"`
 	OwnerUID string            `json:"owner_uid" gorm:"index"`
+	Currency string            `json:"currency" gorm:"type:varchar(3);default:'EUR'"` // ISO currency code of the services prices
 }
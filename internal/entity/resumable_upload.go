@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+)
+
+// ResumableUpload tracks the upload of a document sent in several requests with the tus protocol,
+// so that a dropped connection resumes where it stopped. Each request appends one part to storage,
+// and the parts are joined at the storage path of the document once the last byte is received.
+type ResumableUpload struct {
+	ID        string    `gorm:"primaryKey;type:varchar(32)" json:"id"` // random, part of the upload URL
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserUID     string `gorm:"index;not null" json:"user_uid"`
+	OrderID     uint   `gorm:"index;not null" json:"order_id"`
+	DocumentID  uint   `gorm:"index;not null" json:"document_id"`
+	StoragePath string `gorm:"type:text" json:"-"` // of the document, the parts of the upload are stored next to it
+
+	Length int64 `json:"length"` // total size in bytes, declared at creation
+	Offset int64 `json:"offset"` // bytes received so far
+	Parts  int   `json:"parts"`  // number of parts stored so far
+
+	ExpiresAt   time.Time  `gorm:"index" json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Held by the request appending to the upload, so that two requests never write the same part
+	LockedUntil *time.Time `json:"-"`
+}
+
+// IsComplete reports whether every byte of the upload was received
+func (u *ResumableUpload) IsComplete() bool {
+	return u.Offset == u.Length
+}
@@ -11,6 +11,9 @@ const (
 	RoleUser    Role = "user"
 	RoleManager Role = "manager"
 	RoleAdmin   Role = "admin"
+
+	// RoleSystem is never assigned to users, it identifies actions taken by the platform itself
+	RoleSystem Role = "system"
 )
 
 func (r Role) IsValid() bool {
@@ -23,13 +26,15 @@ func (r Role) IsValid() bool {
 }
 
 type User struct {
-	UID       string    `gorm:"unique" json:"uid"` // Firebase UID (unique)
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Role      Role      `json:"role"` // "user", "manager", "admin"
-	Email     string    `json:"email"`
-	Disabled  bool      `json:"disabled" gorm:"default:false"`
-	CenterID  *uint     `json:"center_id,omitempty"` // Nullable: only for managers
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UID         string    `gorm:"unique" json:"uid"` // Firebase UID (unique)
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	Role        Role      `json:"role"` // "user", "manager", "admin"
+	Email       string    `json:"email"`
+	PhoneNumber string    `json:"phone_number,omitempty"` // E.164, where SMS notifications are sent
+	Locale      string    `json:"locale,omitempty"`       // Language of the notifications, the platform default when empty
+	Disabled    bool      `json:"disabled" gorm:"default:false"`
+	CenterID    *uint     `json:"center_id,omitempty"` // Nullable: only for managers
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
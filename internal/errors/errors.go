@@ -58,6 +58,9 @@ const (
 
 	// order can not be cancelled at this stage
 	NotCancellable ErrorCode = "NOT_CANCELLABLE"
+
+	// uploaded file contains malware
+	MalwareDetected ErrorCode = "MALWARE_DETECTED"
 )
 
 type AppError struct {
@@ -110,6 +113,66 @@ var (
 	ErrPrintCenterAlreadyApproved = New(FailedPrecondition, "center already approved")
 	ErrPrintCenterNotOperational  = New(NotOperational, "center not operational")
 
-	ErrOrderNotFound         = New(NotFound, "order not found")
-	ErrOrderCannotBeCancelled = New(NotCancellable, "order can not be cancelled")
+	ErrOrderNotFound           = New(NotFound, "order not found")
+	ErrOrderCannotBeCancelled  = New(NotCancellable, "order can not be cancelled")
+	ErrOrderNotPayable         = New(FailedPrecondition, "order is not awaiting payment")
+	ErrInvalidStatusTransition = New(FailedPrecondition, "invalid order status transition")
+	ErrTransitionNotAllowed    = New(PermissionDenied, "not allowed to perform this status transition")
+	ErrOrderStatusConflict     = New(Aborted, "order status was changed concurrently, please retry")
+	ErrOrderAccessDenied       = New(PermissionDenied, "not allowed to access this order")
+
+	ErrCancellationReasonRequired = New(InvalidArgument, "a reason is required to cancel an order on behalf of the customer")
+
+	ErrInvalidPickupCode           = New(NotFound, "invalid pickup code")
+	ErrOrderNotReadyForPickup      = New(FailedPrecondition, "order is not ready for pickup")
+	ErrNotCenterManager            = New(PermissionDenied, "only managers of this print center can verify pickup codes")
+	ErrTooManyVerificationAttempts = New(ResourceExhausted, "too many failed pickup code verifications, try again later")
+	ErrPickupTokenExpired          = New(FailedPrecondition, "pickup QR code has expired, please refresh it")
+	ErrOrderNotActive              = New(FailedPrecondition, "order is no longer active")
+	ErrOrderNotSchedulable         = New(FailedPrecondition, "only paid orders can be scheduled for pickup")
+	ErrPickupTimeInPast            = New(InvalidArgument, "pickup time must be in the future")
+	ErrPickupOutsideWorkingHours   = New(InvalidArgument, "pickup time is outside the working hours of the print center")
+
+	ErrUnreadableDocument = New(InvalidArgument, "document could not be read")
+	ErrEncryptedDocument  = New(InvalidArgument, "password protected documents can not be printed")
+	ErrContentMismatch    = New(InvalidArgument, "file content does not match its declared type")
+	ErrImageTooLarge      = New(InvalidArgument, "image is too large to be printed")
+	ErrMalwareDetected    = New(MalwareDetected, "malware detected in uploaded file")
+	ErrScannerUnavailable = New(Unavailable, "files can not be scanned for malware right now, please retry later")
+	ErrInvalidPageRange   = New(InvalidArgument, "invalid page range")
+	ErrServiceNotOffered  = New(InvalidArgument, "print center does not offer this service")
+	ErrDocumentNotFound   = New(NotFound, "document not found")
+	ErrDocumentNotPurged  = New(FailedPrecondition, "document has not been deleted from storage yet")
+
+	ErrDeletionReceiptNotFound = New(NotFound, "deletion receipt not found")
+
+	ErrOrderNotAwaitingDocument = New(FailedPrecondition, "order is not awaiting its documents")
+	ErrDocumentNotUploaded      = New(FailedPrecondition, "document has not been uploaded yet")
+	ErrUploadMismatch           = New(InvalidArgument, "uploaded file does not match the declared document")
+	ErrInvalidUploadURL         = New(PermissionDenied, "upload URL is invalid or has expired")
+	ErrUploadAlreadyReceived    = New(AlreadyExists, "a file was already uploaded to this URL")
+	ErrDocumentAlreadyUploaded  = New(AlreadyExists, "a file was already uploaded for this document")
+	ErrUploadNotFound           = New(NotFound, "upload not found")
+	ErrUploadExpired            = New(NotFound, "upload has expired")
+	ErrUploadOffsetMismatch     = New(Aborted, "upload offset does not match the bytes received")
+	ErrUploadLocked             = New(Aborted, "upload is already receiving data, please retry")
+	ErrUploadExceedsLength      = New(InvalidArgument, "upload exceeds its declared length")
+	ErrTooManyUploadParts       = New(InvalidArgument, "upload was sent in too many parts, please send larger chunks")
+
+	ErrJobNotFound       = New(NotFound, "job not found")
+	ErrJobAlreadyRunning = New(Aborted, "job is already running")
+	ErrSchedulerStopped  = New(Unavailable, "job scheduler is stopped")
+
+	ErrPaymentNotFound         = New(NotFound, "payment not found")
+	ErrInvalidWebhookSignature = New(Unauthenticated, "invalid webhook signature")
+	ErrPaymentAmountMismatch   = New(InvalidArgument, "payment amount does not match the order")
+	ErrPaymentsDisabled        = New(Unavailable, "payments are not available on this server yet")
+
+	ErrInvalidCursor     = New(InvalidArgument, "invalid pagination cursor")
+	ErrUnsupportedSort   = New(InvalidArgument, "unsupported sort column")
+	ErrInvalidListFilter = New(InvalidArgument, "invalid list filter")
+
+	ErrNotCenterStaff = New(PermissionDenied, "only staff of this print center can follow its orders")
+
+	ErrInvalidTrackingToken = New(NotFound, "unknown pickup code or tracking token")
 )
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestDeadline gives the requests of a route the given time to be read and answered,
+// in place of the read and write timeouts of the server. It is meant for routes receiving
+// files, which may take minutes over slow mobile connections.
+func RequestDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		deadline := time.Now().Add(timeout)
+		rc := http.NewResponseController(ctx.Writer)
+		// Without deadline support the server timeouts keep applying
+		if err := rc.SetReadDeadline(deadline); err != nil {
+			log.Printf("⚠️ Failed to extend the read deadline of %s: %v", ctx.FullPath(), err)
+		}
+		if err := rc.SetWriteDeadline(deadline); err != nil {
+			log.Printf("⚠️ Failed to extend the write deadline of %s: %v", ctx.FullPath(), err)
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/dto"
+)
+
+// TASK_TOKEN_HEADER carries the shared secret of the internal task endpoints
+const TASK_TOKEN_HEADER = "X-Printly-Task-Token"
+
+// TaskAuthMiddleware only lets through requests presenting the shared task secret,
+// such as the calls of an external scheduler.
+func TaskAuthMiddleware(secret string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctx.GetHeader(TASK_TOKEN_HEADER)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid task token"})
+			return
+		}
+		ctx.Next()
+	}
+}
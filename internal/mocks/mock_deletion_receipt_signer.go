@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: DeletionReceiptSigner)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockDeletionReceiptSigner is a mock of DeletionReceiptSigner interface.
+type MockDeletionReceiptSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeletionReceiptSignerMockRecorder
+}
+
+// MockDeletionReceiptSignerMockRecorder is the mock recorder for MockDeletionReceiptSigner.
+type MockDeletionReceiptSignerMockRecorder struct {
+	mock *MockDeletionReceiptSigner
+}
+
+// NewMockDeletionReceiptSigner creates a new mock instance.
+func NewMockDeletionReceiptSigner(ctrl *gomock.Controller) *MockDeletionReceiptSigner {
+	mock := &MockDeletionReceiptSigner{ctrl: ctrl}
+	mock.recorder = &MockDeletionReceiptSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeletionReceiptSigner) EXPECT() *MockDeletionReceiptSignerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockDeletionReceiptSigner) Issue(arg0 *entity.Order, arg1 *entity.Document, arg2 service.StorageType, arg3 time.Time) (*entity.DeletionReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.DeletionReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockDeletionReceiptSignerMockRecorder) Issue(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockDeletionReceiptSigner)(nil).Issue), arg0, arg1, arg2, arg3)
+}
+
+// Verify mocks base method.
+func (m *MockDeletionReceiptSigner) Verify(arg0 *entity.DeletionReceipt) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockDeletionReceiptSignerMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockDeletionReceiptSigner)(nil).Verify), arg0)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: DocumentInspector)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockDocumentInspector is a mock of DocumentInspector interface.
+type MockDocumentInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentInspectorMockRecorder
+}
+
+// MockDocumentInspectorMockRecorder is the mock recorder for MockDocumentInspector.
+type MockDocumentInspectorMockRecorder struct {
+	mock *MockDocumentInspector
+}
+
+// NewMockDocumentInspector creates a new mock instance.
+func NewMockDocumentInspector(ctrl *gomock.Controller) *MockDocumentInspector {
+	mock := &MockDocumentInspector{ctrl: ctrl}
+	mock.recorder = &MockDocumentInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentInspector) EXPECT() *MockDocumentInspectorMockRecorder {
+	return m.recorder
+}
+
+// Inspect mocks base method.
+func (m *MockDocumentInspector) Inspect(arg0 io.ReaderAt, arg1 int64, arg2 string) (*service.DocumentInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*service.DocumentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockDocumentInspectorMockRecorder) Inspect(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockDocumentInspector)(nil).Inspect), arg0, arg1, arg2)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: DocumentPurger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockDocumentPurger is a mock of DocumentPurger interface.
+type MockDocumentPurger struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentPurgerMockRecorder
+}
+
+// MockDocumentPurgerMockRecorder is the mock recorder for MockDocumentPurger.
+type MockDocumentPurgerMockRecorder struct {
+	mock *MockDocumentPurger
+}
+
+// NewMockDocumentPurger creates a new mock instance.
+func NewMockDocumentPurger(ctrl *gomock.Controller) *MockDocumentPurger {
+	mock := &MockDocumentPurger{ctrl: ctrl}
+	mock.recorder = &MockDocumentPurgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentPurger) EXPECT() *MockDocumentPurgerMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockDocumentPurger) Purge(arg0 *entity.Order) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDocumentPurgerMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDocumentPurger)(nil).Purge), arg0)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: DocumentRetention)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+)
+
+// MockDocumentRetention is a mock of DocumentRetention interface.
+type MockDocumentRetention struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentRetentionMockRecorder
+}
+
+// MockDocumentRetentionMockRecorder is the mock recorder for MockDocumentRetention.
+type MockDocumentRetentionMockRecorder struct {
+	mock *MockDocumentRetention
+}
+
+// NewMockDocumentRetention creates a new mock instance.
+func NewMockDocumentRetention(ctrl *gomock.Controller) *MockDocumentRetention {
+	mock := &MockDocumentRetention{ctrl: ctrl}
+	mock.recorder = &MockDocumentRetentionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentRetention) EXPECT() *MockDocumentRetentionMockRecorder {
+	return m.recorder
+}
+
+// PurgeExpiredDocuments mocks base method.
+func (m *MockDocumentRetention) PurgeExpiredDocuments(arg0 context.Context) (*dto.DocumentRetentionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredDocuments", arg0)
+	ret0, _ := ret[0].(*dto.DocumentRetentionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredDocuments indicates an expected call of PurgeExpiredDocuments.
+func (mr *MockDocumentRetentionMockRecorder) PurgeExpiredDocuments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredDocuments", reflect.TypeOf((*MockDocumentRetention)(nil).PurgeExpiredDocuments), arg0)
+}
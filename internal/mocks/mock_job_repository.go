@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/repository (interfaces: JobRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireLease mocks base method.
+func (m *MockJobRepository) AcquireLease(arg0, arg1 string, arg2 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease.
+func (mr *MockJobRepositoryMockRecorder) AcquireLease(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockJobRepository)(nil).AcquireLease), arg0, arg1, arg2)
+}
+
+// DeleteRunsBefore mocks base method.
+func (m *MockJobRepository) DeleteRunsBefore(arg0 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRunsBefore", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRunsBefore indicates an expected call of DeleteRunsBefore.
+func (mr *MockJobRepositoryMockRecorder) DeleteRunsBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRunsBefore", reflect.TypeOf((*MockJobRepository)(nil).DeleteRunsBefore), arg0)
+}
+
+// FindLastRun mocks base method.
+func (m *MockJobRepository) FindLastRun(arg0 string) (*entity.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastRun", arg0)
+	ret0, _ := ret[0].(*entity.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastRun indicates an expected call of FindLastRun.
+func (mr *MockJobRepositoryMockRecorder) FindLastRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastRun", reflect.TypeOf((*MockJobRepository)(nil).FindLastRun), arg0)
+}
+
+// ReleaseLease mocks base method.
+func (m *MockJobRepository) ReleaseLease(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLease", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLease indicates an expected call of ReleaseLease.
+func (mr *MockJobRepositoryMockRecorder) ReleaseLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLease", reflect.TypeOf((*MockJobRepository)(nil).ReleaseLease), arg0, arg1)
+}
+
+// SaveRun mocks base method.
+func (m *MockJobRepository) SaveRun(arg0 *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRun", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRun indicates an expected call of SaveRun.
+func (mr *MockJobRepositoryMockRecorder) SaveRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRun", reflect.TypeOf((*MockJobRepository)(nil).SaveRun), arg0)
+}
+
+// UpdateRun mocks base method.
+func (m *MockJobRepository) UpdateRun(arg0 *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockJobRepositoryMockRecorder) UpdateRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockJobRepository)(nil).UpdateRun), arg0)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: JobScheduler)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockJobScheduler is a mock of JobScheduler interface.
+type MockJobScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobSchedulerMockRecorder
+}
+
+// MockJobSchedulerMockRecorder is the mock recorder for MockJobScheduler.
+type MockJobSchedulerMockRecorder struct {
+	mock *MockJobScheduler
+}
+
+// NewMockJobScheduler creates a new mock instance.
+func NewMockJobScheduler(ctrl *gomock.Controller) *MockJobScheduler {
+	mock := &MockJobScheduler{ctrl: ctrl}
+	mock.recorder = &MockJobSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobScheduler) EXPECT() *MockJobSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Jobs mocks base method.
+func (m *MockJobScheduler) Jobs() ([]dto.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Jobs")
+	ret0, _ := ret[0].([]dto.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Jobs indicates an expected call of Jobs.
+func (mr *MockJobSchedulerMockRecorder) Jobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Jobs", reflect.TypeOf((*MockJobScheduler)(nil).Jobs))
+}
+
+// Register mocks base method.
+func (m *MockJobScheduler) Register(arg0 service.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockJobSchedulerMockRecorder) Register(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockJobScheduler)(nil).Register), arg0)
+}
+
+// Start mocks base method.
+func (m *MockJobScheduler) Start() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start")
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockJobSchedulerMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockJobScheduler)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockJobScheduler) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockJobSchedulerMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockJobScheduler)(nil).Stop), arg0)
+}
+
+// Trigger mocks base method.
+func (m *MockJobScheduler) Trigger(arg0 string, arg1 entity.Actor) (*entity.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", arg0, arg1)
+	ret0, _ := ret[0].(*entity.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockJobSchedulerMockRecorder) Trigger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockJobScheduler)(nil).Trigger), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: NotificationSender)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockNotificationSender is a mock of NotificationSender interface.
+type MockNotificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSenderMockRecorder
+}
+
+// MockNotificationSenderMockRecorder is the mock recorder for MockNotificationSender.
+type MockNotificationSenderMockRecorder struct {
+	mock *MockNotificationSender
+}
+
+// NewMockNotificationSender creates a new mock instance.
+func NewMockNotificationSender(ctrl *gomock.Controller) *MockNotificationSender {
+	mock := &MockNotificationSender{ctrl: ctrl}
+	mock.recorder = &MockNotificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSender) EXPECT() *MockNotificationSenderMockRecorder {
+	return m.recorder
+}
+
+// Channel mocks base method.
+func (m *MockNotificationSender) Channel() entity.NotificationChannel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Channel")
+	ret0, _ := ret[0].(entity.NotificationChannel)
+	return ret0
+}
+
+// Channel indicates an expected call of Channel.
+func (mr *MockNotificationSenderMockRecorder) Channel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Channel", reflect.TypeOf((*MockNotificationSender)(nil).Channel))
+}
+
+// Send mocks base method.
+func (m *MockNotificationSender) Send(arg0 context.Context, arg1 service.NotificationMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNotificationSenderMockRecorder) Send(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationSender)(nil).Send), arg0, arg1)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// DeliverDue mocks base method.
+func (m *MockNotifier) DeliverDue(arg0 context.Context) (*dto.NotificationDeliveryReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", arg0)
+	ret0, _ := ret[0].(*dto.NotificationDeliveryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockNotifierMockRecorder) DeliverDue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockNotifier)(nil).DeliverDue), arg0)
+}
+
+// GetOrderNotifications mocks base method.
+func (m *MockNotifier) GetOrderNotifications(arg0 uint, arg1 entity.Actor, arg2 dto.ListRequest) ([]entity.Notification, dto.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderNotifications", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(dto.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrderNotifications indicates an expected call of GetOrderNotifications.
+func (mr *MockNotifierMockRecorder) GetOrderNotifications(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderNotifications", reflect.TypeOf((*MockNotifier)(nil).GetOrderNotifications), arg0, arg1, arg2)
+}
+
+// GetUserNotifications mocks base method.
+func (m *MockNotifier) GetUserNotifications(arg0 string, arg1 dto.ListRequest) ([]entity.Notification, dto.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(dto.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserNotifications indicates an expected call of GetUserNotifications.
+func (mr *MockNotifierMockRecorder) GetUserNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotifications", reflect.TypeOf((*MockNotifier)(nil).GetUserNotifications), arg0, arg1)
+}
+
+// NotifyCenter mocks base method.
+func (m *MockNotifier) NotifyCenter(arg0 entity.NotificationEvent, arg1 *entity.PrintCenter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyCenter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyCenter indicates an expected call of NotifyCenter.
+func (mr *MockNotifierMockRecorder) NotifyCenter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyCenter", reflect.TypeOf((*MockNotifier)(nil).NotifyCenter), arg0, arg1)
+}
+
+// NotifyOrder mocks base method.
+func (m *MockNotifier) NotifyOrder(arg0 service.OrderNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyOrder indicates an expected call of NotifyOrder.
+func (mr *MockNotifierMockRecorder) NotifyOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyOrder", reflect.TypeOf((*MockNotifier)(nil).NotifyOrder), arg0)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: OrderEventBus)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockOrderEventBus is a mock of OrderEventBus interface.
+type MockOrderEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEventBusMockRecorder
+}
+
+// MockOrderEventBusMockRecorder is the mock recorder for MockOrderEventBus.
+type MockOrderEventBusMockRecorder struct {
+	mock *MockOrderEventBus
+}
+
+// NewMockOrderEventBus creates a new mock instance.
+func NewMockOrderEventBus(ctrl *gomock.Controller) *MockOrderEventBus {
+	mock := &MockOrderEventBus{ctrl: ctrl}
+	mock.recorder = &MockOrderEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEventBus) EXPECT() *MockOrderEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockOrderEventBus) Publish(arg0 dto.OrderEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockOrderEventBusMockRecorder) Publish(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockOrderEventBus)(nil).Publish), arg0)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderEventBus) Subscribe(arg0 uint, arg1 uint64) *service.OrderSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
+	ret0, _ := ret[0].(*service.OrderSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderEventBusMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderEventBus)(nil).Subscribe), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: OrderExpirer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+)
+
+// MockOrderExpirer is a mock of OrderExpirer interface.
+type MockOrderExpirer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderExpirerMockRecorder
+}
+
+// MockOrderExpirerMockRecorder is the mock recorder for MockOrderExpirer.
+type MockOrderExpirerMockRecorder struct {
+	mock *MockOrderExpirer
+}
+
+// NewMockOrderExpirer creates a new mock instance.
+func NewMockOrderExpirer(ctrl *gomock.Controller) *MockOrderExpirer {
+	mock := &MockOrderExpirer{ctrl: ctrl}
+	mock.recorder = &MockOrderExpirerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderExpirer) EXPECT() *MockOrderExpirerMockRecorder {
+	return m.recorder
+}
+
+// ExpireStaleOrders mocks base method.
+func (m *MockOrderExpirer) ExpireStaleOrders(arg0 context.Context) (*dto.OrderExpiryReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireStaleOrders", arg0)
+	ret0, _ := ret[0].(*dto.OrderExpiryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireStaleOrders indicates an expected call of ExpireStaleOrders.
+func (mr *MockOrderExpirerMockRecorder) ExpireStaleOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireStaleOrders", reflect.TypeOf((*MockOrderExpirer)(nil).ExpireStaleOrders), arg0)
+}
@@ -6,9 +6,11 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/kimbasn/printly/internal/entity"
+	repository "github.com/kimbasn/printly/internal/repository"
 )
 
 // MockOrderRepository is a mock of OrderRepository interface.
@@ -34,48 +36,33 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Delete mocks base method.
-func (m *MockOrderRepository) Delete(arg0 uint) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Delete indicates an expected call of Delete.
-func (mr *MockOrderRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), arg0)
-}
-
-// FindAll mocks base method.
-func (m *MockOrderRepository) FindAll() ([]entity.Order, error) {
+// CountQueueAhead mocks base method.
+func (m *MockOrderRepository) CountQueueAhead(arg0, arg1 uint) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]entity.Order)
+	ret := m.ctrl.Call(m, "CountQueueAhead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAll indicates an expected call of FindAll.
-func (mr *MockOrderRepositoryMockRecorder) FindAll() *gomock.Call {
+// CountQueueAhead indicates an expected call of CountQueueAhead.
+func (mr *MockOrderRepositoryMockRecorder) CountQueueAhead(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQueueAhead", reflect.TypeOf((*MockOrderRepository)(nil).CountQueueAhead), arg0, arg1)
 }
 
-// FindByCenterID mocks base method.
-func (m *MockOrderRepository) FindByCenterID(arg0 uint) ([]entity.Order, error) {
+// Delete mocks base method.
+func (m *MockOrderRepository) Delete(arg0 uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByCenterID", arg0)
-	ret0, _ := ret[0].([]entity.Order)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FindByCenterID indicates an expected call of FindByCenterID.
-func (mr *MockOrderRepositoryMockRecorder) FindByCenterID(arg0 interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockOrderRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCenterID", reflect.TypeOf((*MockOrderRepository)(nil).FindByCenterID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), arg0)
 }
 
 // FindByCode mocks base method.
@@ -123,19 +110,123 @@ func (mr *MockOrderRepositoryMockRecorder) FindByStatus(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockOrderRepository)(nil).FindByStatus), arg0)
 }
 
-// FindByUserUID mocks base method.
-func (m *MockOrderRepository) FindByUserUID(arg0 string) ([]entity.Order, error) {
+// FindDeletionReceipt mocks base method.
+func (m *MockOrderRepository) FindDeletionReceipt(arg0 uint) (*entity.DeletionReceipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUserUID", arg0)
+	ret := m.ctrl.Call(m, "FindDeletionReceipt", arg0)
+	ret0, _ := ret[0].(*entity.DeletionReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletionReceipt indicates an expected call of FindDeletionReceipt.
+func (mr *MockOrderRepositoryMockRecorder) FindDeletionReceipt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletionReceipt", reflect.TypeOf((*MockOrderRepository)(nil).FindDeletionReceipt), arg0)
+}
+
+// FindDocumentByStoragePath mocks base method.
+func (m *MockOrderRepository) FindDocumentByStoragePath(arg0 string) (*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDocumentByStoragePath", arg0)
+	ret0, _ := ret[0].(*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDocumentByStoragePath indicates an expected call of FindDocumentByStoragePath.
+func (mr *MockOrderRepositoryMockRecorder) FindDocumentByStoragePath(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDocumentByStoragePath", reflect.TypeOf((*MockOrderRepository)(nil).FindDocumentByStoragePath), arg0)
+}
+
+// FindDocumentsToPurge mocks base method.
+func (m *MockOrderRepository) FindDocumentsToPurge(arg0 []entity.OrderStatus, arg1 time.Time, arg2 int) ([]entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDocumentsToPurge", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDocumentsToPurge indicates an expected call of FindDocumentsToPurge.
+func (mr *MockOrderRepositoryMockRecorder) FindDocumentsToPurge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDocumentsToPurge", reflect.TypeOf((*MockOrderRepository)(nil).FindDocumentsToPurge), arg0, arg1, arg2)
+}
+
+// FindStale mocks base method.
+func (m *MockOrderRepository) FindStale(arg0 entity.OrderStatus, arg1 time.Time, arg2 int) ([]entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStale", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByUserUID indicates an expected call of FindByUserUID.
-func (mr *MockOrderRepositoryMockRecorder) FindByUserUID(arg0 interface{}) *gomock.Call {
+// FindStale indicates an expected call of FindStale.
+func (mr *MockOrderRepositoryMockRecorder) FindStale(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserUID", reflect.TypeOf((*MockOrderRepository)(nil).FindByUserUID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStale", reflect.TypeOf((*MockOrderRepository)(nil).FindStale), arg0, arg1, arg2)
+}
+
+// FindStatusHistory mocks base method.
+func (m *MockOrderRepository) FindStatusHistory(arg0 uint) ([]entity.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatusHistory", arg0)
+	ret0, _ := ret[0].([]entity.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStatusHistory indicates an expected call of FindStatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) FindStatusHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).FindStatusHistory), arg0)
+}
+
+// List mocks base method.
+func (m *MockOrderRepository) List(arg0 repository.ListQuery) ([]entity.Order, repository.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]entity.Order)
+	ret1, _ := ret[1].(repository.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrderRepositoryMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderRepository)(nil).List), arg0)
+}
+
+// MarkDocumentDeleted mocks base method.
+func (m *MockOrderRepository) MarkDocumentDeleted(arg0 *entity.DeletionReceipt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDocumentDeleted", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDocumentDeleted indicates an expected call of MarkDocumentDeleted.
+func (mr *MockOrderRepositoryMockRecorder) MarkDocumentDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDocumentDeleted", reflect.TypeOf((*MockOrderRepository)(nil).MarkDocumentDeleted), arg0)
+}
+
+// MarkDocumentsPrinted mocks base method.
+func (m *MockOrderRepository) MarkDocumentsPrinted(arg0 uint, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDocumentsPrinted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDocumentsPrinted indicates an expected call of MarkDocumentsPrinted.
+func (mr *MockOrderRepositoryMockRecorder) MarkDocumentsPrinted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDocumentsPrinted", reflect.TypeOf((*MockOrderRepository)(nil).MarkDocumentsPrinted), arg0, arg1)
 }
 
 // Save mocks base method.
@@ -165,3 +256,45 @@ func (mr *MockOrderRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepository)(nil).Update), arg0, arg1)
 }
+
+// UpdateDocument mocks base method.
+func (m *MockOrderRepository) UpdateDocument(arg0 uint, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDocument indicates an expected call of UpdateDocument.
+func (mr *MockOrderRepositoryMockRecorder) UpdateDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDocument", reflect.TypeOf((*MockOrderRepository)(nil).UpdateDocument), arg0, arg1)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepository) UpdateStatus(arg0 uint, arg1 entity.OrderStatus, arg2 map[string]interface{}, arg3 *entity.OrderStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), arg0, arg1, arg2, arg3)
+}
+
+// UpdateStatusAndDocuments mocks base method.
+func (m *MockOrderRepository) UpdateStatusAndDocuments(arg0 uint, arg1 entity.OrderStatus, arg2 map[string]interface{}, arg3 map[uint]map[string]interface{}, arg4 *entity.OrderStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusAndDocuments", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusAndDocuments indicates an expected call of UpdateStatusAndDocuments.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatusAndDocuments(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusAndDocuments", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatusAndDocuments), arg0, arg1, arg2, arg3, arg4)
+}
@@ -6,10 +6,12 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/kimbasn/printly/internal/dto"
 	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
 )
 
 // MockOrderService is a mock of OrderService interface.
@@ -51,17 +53,62 @@ func (mr *MockOrderServiceMockRecorder) CalculateOrderCost(arg0 interface{}) *go
 }
 
 // CancelOrder mocks base method.
-func (m *MockOrderService) CancelOrder(arg0 uint, arg1 string) error {
+func (m *MockOrderService) CancelOrder(arg0 uint, arg1 entity.Actor, arg2 string) (*dto.CancelOrderResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelOrder", arg0, arg1)
+	ret := m.ctrl.Call(m, "CancelOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.CancelOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderServiceMockRecorder) CancelOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderService)(nil).CancelOrder), arg0, arg1, arg2)
+}
+
+// CheckDocumentUpload mocks base method.
+func (m *MockOrderService) CheckDocumentUpload(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDocumentUpload", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CancelOrder indicates an expected call of CancelOrder.
-func (mr *MockOrderServiceMockRecorder) CancelOrder(arg0, arg1 interface{}) *gomock.Call {
+// CheckDocumentUpload indicates an expected call of CheckDocumentUpload.
+func (mr *MockOrderServiceMockRecorder) CheckDocumentUpload(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDocumentUpload", reflect.TypeOf((*MockOrderService)(nil).CheckDocumentUpload), arg0)
+}
+
+// ConfirmDocumentUploads mocks base method.
+func (m *MockOrderService) ConfirmDocumentUploads(arg0 uint, arg1 entity.Actor) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmDocumentUploads", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmDocumentUploads indicates an expected call of ConfirmDocumentUploads.
+func (mr *MockOrderServiceMockRecorder) ConfirmDocumentUploads(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmDocumentUploads", reflect.TypeOf((*MockOrderService)(nil).ConfirmDocumentUploads), arg0, arg1)
+}
+
+// CreateDirectUploadOrder mocks base method.
+func (m *MockOrderService) CreateDirectUploadOrder(arg0 string, arg1 uint, arg2 dto.DirectUploadOrderRequest) (*dto.DirectUploadOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDirectUploadOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.DirectUploadOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirectUploadOrder indicates an expected call of CreateDirectUploadOrder.
+func (mr *MockOrderServiceMockRecorder) CreateDirectUploadOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderService)(nil).CancelOrder), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectUploadOrder", reflect.TypeOf((*MockOrderService)(nil).CreateDirectUploadOrder), arg0, arg1, arg2)
 }
 
 // CreateOrder mocks base method.
@@ -94,18 +141,34 @@ func (mr *MockOrderServiceMockRecorder) DeleteOrder(arg0 interface{}) *gomock.Ca
 }
 
 // GetAllOrders mocks base method.
-func (m *MockOrderService) GetAllOrders() ([]entity.Order, error) {
+func (m *MockOrderService) GetAllOrders(arg0 dto.ListRequest) ([]entity.Order, dto.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrders")
+	ret := m.ctrl.Call(m, "GetAllOrders", arg0)
 	ret0, _ := ret[0].([]entity.Order)
+	ret1, _ := ret[1].(dto.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllOrders indicates an expected call of GetAllOrders.
+func (mr *MockOrderServiceMockRecorder) GetAllOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderService)(nil).GetAllOrders), arg0)
+}
+
+// GetDeletionReceipt mocks base method.
+func (m *MockOrderService) GetDeletionReceipt(arg0, arg1 uint, arg2 entity.Actor) (*entity.DeletionReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletionReceipt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.DeletionReceipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllOrders indicates an expected call of GetAllOrders.
-func (mr *MockOrderServiceMockRecorder) GetAllOrders() *gomock.Call {
+// GetDeletionReceipt indicates an expected call of GetDeletionReceipt.
+func (mr *MockOrderServiceMockRecorder) GetDeletionReceipt(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderService)(nil).GetAllOrders))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletionReceipt", reflect.TypeOf((*MockOrderService)(nil).GetDeletionReceipt), arg0, arg1, arg2)
 }
 
 // GetOrderByCode mocks base method.
@@ -138,46 +201,183 @@ func (mr *MockOrderServiceMockRecorder) GetOrderByID(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderService)(nil).GetOrderByID), arg0)
 }
 
-// GetOrdersForCenter mocks base method.
-func (m *MockOrderService) GetOrdersForCenter(arg0 uint) ([]entity.Order, error) {
+// GetOrderHistory mocks base method.
+func (m *MockOrderService) GetOrderHistory(arg0 uint, arg1 entity.Actor) ([]entity.OrderStatusHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersForCenter", arg0)
-	ret0, _ := ret[0].([]entity.Order)
+	ret := m.ctrl.Call(m, "GetOrderHistory", arg0, arg1)
+	ret0, _ := ret[0].([]entity.OrderStatusHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetOrderHistory indicates an expected call of GetOrderHistory.
+func (mr *MockOrderServiceMockRecorder) GetOrderHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderHistory", reflect.TypeOf((*MockOrderService)(nil).GetOrderHistory), arg0, arg1)
+}
+
+// GetOrdersForCenter mocks base method.
+func (m *MockOrderService) GetOrdersForCenter(arg0 uint, arg1 dto.ListRequest, arg2 entity.Actor) ([]entity.Order, dto.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersForCenter", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.Order)
+	ret1, _ := ret[1].(dto.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // GetOrdersForCenter indicates an expected call of GetOrdersForCenter.
-func (mr *MockOrderServiceMockRecorder) GetOrdersForCenter(arg0 interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) GetOrdersForCenter(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForCenter", reflect.TypeOf((*MockOrderService)(nil).GetOrdersForCenter), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForCenter", reflect.TypeOf((*MockOrderService)(nil).GetOrdersForCenter), arg0, arg1, arg2)
 }
 
 // GetOrdersForUser mocks base method.
-func (m *MockOrderService) GetOrdersForUser(arg0 string) ([]entity.Order, error) {
+func (m *MockOrderService) GetOrdersForUser(arg0 string, arg1 dto.ListRequest) ([]dto.UserOrder, dto.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersForUser", arg0)
-	ret0, _ := ret[0].([]entity.Order)
+	ret := m.ctrl.Call(m, "GetOrdersForUser", arg0, arg1)
+	ret0, _ := ret[0].([]dto.UserOrder)
+	ret1, _ := ret[1].(dto.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrdersForUser indicates an expected call of GetOrdersForUser.
+func (mr *MockOrderServiceMockRecorder) GetOrdersForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForUser", reflect.TypeOf((*MockOrderService)(nil).GetOrdersForUser), arg0, arg1)
+}
+
+// GetPickupToken mocks base method.
+func (m *MockOrderService) GetPickupToken(arg0 uint, arg1 entity.Actor) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickupToken", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPickupToken indicates an expected call of GetPickupToken.
+func (mr *MockOrderServiceMockRecorder) GetPickupToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickupToken", reflect.TypeOf((*MockOrderService)(nil).GetPickupToken), arg0, arg1)
+}
+
+// GetPurgeReport mocks base method.
+func (m *MockOrderService) GetPurgeReport(arg0 uint, arg1 entity.Actor) (*dto.DocumentPurgeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurgeReport", arg0, arg1)
+	ret0, _ := ret[0].(*dto.DocumentPurgeReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrdersForUser indicates an expected call of GetOrdersForUser.
-func (mr *MockOrderServiceMockRecorder) GetOrdersForUser(arg0 interface{}) *gomock.Call {
+// GetPurgeReport indicates an expected call of GetPurgeReport.
+func (mr *MockOrderServiceMockRecorder) GetPurgeReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurgeReport", reflect.TypeOf((*MockOrderService)(nil).GetPurgeReport), arg0, arg1)
+}
+
+// GetUserOrder mocks base method.
+func (m *MockOrderService) GetUserOrder(arg0 uint, arg1 entity.Actor) (*dto.UserOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrder", arg0, arg1)
+	ret0, _ := ret[0].(*dto.UserOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrder indicates an expected call of GetUserOrder.
+func (mr *MockOrderServiceMockRecorder) GetUserOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrder", reflect.TypeOf((*MockOrderService)(nil).GetUserOrder), arg0, arg1)
+}
+
+// QuoteOrder mocks base method.
+func (m *MockOrderService) QuoteOrder(arg0 uint, arg1 dto.QuoteRequest) (*dto.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteOrder", arg0, arg1)
+	ret0, _ := ret[0].(*dto.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteOrder indicates an expected call of QuoteOrder.
+func (mr *MockOrderServiceMockRecorder) QuoteOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteOrder", reflect.TypeOf((*MockOrderService)(nil).QuoteOrder), arg0, arg1)
+}
+
+// ScheduleOrder mocks base method.
+func (m *MockOrderService) ScheduleOrder(arg0 uint, arg1 dto.ScheduleOrderRequest, arg2 entity.Actor) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleOrder indicates an expected call of ScheduleOrder.
+func (mr *MockOrderServiceMockRecorder) ScheduleOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleOrder", reflect.TypeOf((*MockOrderService)(nil).ScheduleOrder), arg0, arg1, arg2)
+}
+
+// SubscribeCenterOrders mocks base method.
+func (m *MockOrderService) SubscribeCenterOrders(arg0 uint, arg1 uint64, arg2 entity.Actor) (*service.OrderSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCenterOrders", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*service.OrderSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeCenterOrders indicates an expected call of SubscribeCenterOrders.
+func (mr *MockOrderServiceMockRecorder) SubscribeCenterOrders(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForUser", reflect.TypeOf((*MockOrderService)(nil).GetOrdersForUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCenterOrders", reflect.TypeOf((*MockOrderService)(nil).SubscribeCenterOrders), arg0, arg1, arg2)
 }
 
 // UpdateOrderStatus mocks base method.
-func (m *MockOrderService) UpdateOrderStatus(arg0 uint, arg1 entity.OrderStatus, arg2 string) error {
+func (m *MockOrderService) UpdateOrderStatus(arg0 uint, arg1 entity.OrderStatus, arg2 entity.Actor, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockOrderServiceMockRecorder) UpdateOrderStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) UpdateOrderStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderService)(nil).UpdateOrderStatus), arg0, arg1, arg2, arg3)
+}
+
+// VerifyDeletionReceipt mocks base method.
+func (m *MockOrderService) VerifyDeletionReceipt(arg0 *entity.DeletionReceipt) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDeletionReceipt", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VerifyDeletionReceipt indicates an expected call of VerifyDeletionReceipt.
+func (mr *MockOrderServiceMockRecorder) VerifyDeletionReceipt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDeletionReceipt", reflect.TypeOf((*MockOrderService)(nil).VerifyDeletionReceipt), arg0)
+}
+
+// VerifyPickup mocks base method.
+func (m *MockOrderService) VerifyPickup(arg0 uint, arg1 dto.VerifyPickupRequest, arg2 entity.Actor) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPickup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPickup indicates an expected call of VerifyPickup.
+func (mr *MockOrderServiceMockRecorder) VerifyPickup(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderService)(nil).UpdateOrderStatus), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPickup", reflect.TypeOf((*MockOrderService)(nil).VerifyPickup), arg0, arg1, arg2)
 }
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: OrderStateMachine)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockOrderStateMachine is a mock of OrderStateMachine interface.
+type MockOrderStateMachine struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStateMachineMockRecorder
+}
+
+// MockOrderStateMachineMockRecorder is the mock recorder for MockOrderStateMachine.
+type MockOrderStateMachineMockRecorder struct {
+	mock *MockOrderStateMachine
+}
+
+// NewMockOrderStateMachine creates a new mock instance.
+func NewMockOrderStateMachine(ctrl *gomock.Controller) *MockOrderStateMachine {
+	mock := &MockOrderStateMachine{ctrl: ctrl}
+	mock.recorder = &MockOrderStateMachineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStateMachine) EXPECT() *MockOrderStateMachineMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockOrderStateMachine) Authorize(arg0 *entity.Order, arg1 entity.OrderStatus, arg2 entity.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockOrderStateMachineMockRecorder) Authorize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockOrderStateMachine)(nil).Authorize), arg0, arg1, arg2)
+}
+
+// Transition mocks base method.
+func (m *MockOrderStateMachine) Transition(arg0 *entity.Order, arg1 entity.OrderStatus, arg2 entity.Actor, arg3 string, arg4 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockOrderStateMachineMockRecorder) Transition(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockOrderStateMachine)(nil).Transition), arg0, arg1, arg2, arg3, arg4)
+}
+
+// TransitionWithDocuments mocks base method.
+func (m *MockOrderStateMachine) TransitionWithDocuments(arg0 *entity.Order, arg1 entity.OrderStatus, arg2 entity.Actor, arg3 string, arg4 map[string]interface{}, arg5 map[uint]map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionWithDocuments", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionWithDocuments indicates an expected call of TransitionWithDocuments.
+func (mr *MockOrderStateMachineMockRecorder) TransitionWithDocuments(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionWithDocuments", reflect.TypeOf((*MockOrderStateMachine)(nil).TransitionWithDocuments), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: OrderTracker)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockOrderTracker is a mock of OrderTracker interface.
+type MockOrderTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderTrackerMockRecorder
+}
+
+// MockOrderTrackerMockRecorder is the mock recorder for MockOrderTracker.
+type MockOrderTrackerMockRecorder struct {
+	mock *MockOrderTracker
+}
+
+// NewMockOrderTracker creates a new mock instance.
+func NewMockOrderTracker(ctrl *gomock.Controller) *MockOrderTracker {
+	mock := &MockOrderTracker{ctrl: ctrl}
+	mock.recorder = &MockOrderTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderTracker) EXPECT() *MockOrderTrackerMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockOrderTracker) Follow(arg0, arg1 string) (*service.OrderTrackingStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", arg0, arg1)
+	ret0, _ := ret[0].(*service.OrderTrackingStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockOrderTrackerMockRecorder) Follow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockOrderTracker)(nil).Follow), arg0, arg1)
+}
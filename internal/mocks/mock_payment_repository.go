@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/repository (interfaces: PaymentRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindBySessionID mocks base method.
+func (m *MockPaymentRepository) FindBySessionID(arg0 string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionID", arg0)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionID indicates an expected call of FindBySessionID.
+func (mr *MockPaymentRepositoryMockRecorder) FindBySessionID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionID", reflect.TypeOf((*MockPaymentRepository)(nil).FindBySessionID), arg0)
+}
+
+// FindPendingByOrderID mocks base method.
+func (m *MockPaymentRepository) FindPendingByOrderID(arg0 uint) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByOrderID", arg0)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByOrderID indicates an expected call of FindPendingByOrderID.
+func (mr *MockPaymentRepositoryMockRecorder) FindPendingByOrderID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByOrderID", reflect.TypeOf((*MockPaymentRepository)(nil).FindPendingByOrderID), arg0)
+}
+
+// FindSucceededByOrderID mocks base method.
+func (m *MockPaymentRepository) FindSucceededByOrderID(arg0 uint) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSucceededByOrderID", arg0)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSucceededByOrderID indicates an expected call of FindSucceededByOrderID.
+func (mr *MockPaymentRepositoryMockRecorder) FindSucceededByOrderID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSucceededByOrderID", reflect.TypeOf((*MockPaymentRepository)(nil).FindSucceededByOrderID), arg0)
+}
+
+// HasEvent mocks base method.
+func (m *MockPaymentRepository) HasEvent(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEvent", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEvent indicates an expected call of HasEvent.
+func (mr *MockPaymentRepositoryMockRecorder) HasEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEvent", reflect.TypeOf((*MockPaymentRepository)(nil).HasEvent), arg0)
+}
+
+// Save mocks base method.
+func (m *MockPaymentRepository) Save(arg0 *entity.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPaymentRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPaymentRepository)(nil).Save), arg0)
+}
+
+// Update mocks base method.
+func (m *MockPaymentRepository) Update(arg0 uint, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPaymentRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPaymentRepository)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithEvent mocks base method.
+func (m *MockPaymentRepository) UpdateWithEvent(arg0 uint, arg1 map[string]interface{}, arg2 *entity.PaymentWebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithEvent indicates an expected call of UpdateWithEvent.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateWithEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithEvent", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateWithEvent), arg0, arg1, arg2)
+}
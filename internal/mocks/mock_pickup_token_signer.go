@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: PickupTokenSigner)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockPickupTokenSigner is a mock of PickupTokenSigner interface.
+type MockPickupTokenSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPickupTokenSignerMockRecorder
+}
+
+// MockPickupTokenSignerMockRecorder is the mock recorder for MockPickupTokenSigner.
+type MockPickupTokenSignerMockRecorder struct {
+	mock *MockPickupTokenSigner
+}
+
+// NewMockPickupTokenSigner creates a new mock instance.
+func NewMockPickupTokenSigner(ctrl *gomock.Controller) *MockPickupTokenSigner {
+	mock := &MockPickupTokenSigner{ctrl: ctrl}
+	mock.recorder = &MockPickupTokenSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPickupTokenSigner) EXPECT() *MockPickupTokenSignerMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockPickupTokenSigner) Sign(arg0 *entity.Order) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockPickupTokenSignerMockRecorder) Sign(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockPickupTokenSigner)(nil).Sign), arg0)
+}
+
+// Verify mocks base method.
+func (m *MockPickupTokenSigner) Verify(arg0 string) (*service.PickupClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(*service.PickupClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockPickupTokenSignerMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPickupTokenSigner)(nil).Verify), arg0)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: PricingEngine)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockPricingEngine is a mock of PricingEngine interface.
+type MockPricingEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricingEngineMockRecorder
+}
+
+// MockPricingEngineMockRecorder is the mock recorder for MockPricingEngine.
+type MockPricingEngineMockRecorder struct {
+	mock *MockPricingEngine
+}
+
+// NewMockPricingEngine creates a new mock instance.
+func NewMockPricingEngine(ctrl *gomock.Controller) *MockPricingEngine {
+	mock := &MockPricingEngine{ctrl: ctrl}
+	mock.recorder = &MockPricingEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricingEngine) EXPECT() *MockPricingEngineMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPricingEngine) Quote(arg0 *entity.PrintCenter, arg1 []entity.Document) (*dto.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", arg0, arg1)
+	ret0, _ := ret[0].(*dto.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPricingEngineMockRecorder) Quote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPricingEngine)(nil).Quote), arg0, arg1)
+}
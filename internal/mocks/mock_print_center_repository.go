@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/kimbasn/printly/internal/entity"
+	repository "github.com/kimbasn/printly/internal/repository"
 )
 
 // MockPrintCenterRepository is a mock of PrintCenterRepository interface.
@@ -48,21 +49,6 @@ func (mr *MockPrintCenterRepositoryMockRecorder) Delete(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPrintCenterRepository)(nil).Delete), arg0)
 }
 
-// FindAll mocks base method.
-func (m *MockPrintCenterRepository) FindAll() ([]entity.PrintCenter, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]entity.PrintCenter)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAll indicates an expected call of FindAll.
-func (mr *MockPrintCenterRepositoryMockRecorder) FindAll() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPrintCenterRepository)(nil).FindAll))
-}
-
 // FindByID mocks base method.
 func (m *MockPrintCenterRepository) FindByID(arg0 uint) (*entity.PrintCenter, error) {
 	m.ctrl.T.Helper()
@@ -78,19 +64,20 @@ func (mr *MockPrintCenterRepositoryMockRecorder) FindByID(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPrintCenterRepository)(nil).FindByID), arg0)
 }
 
-// FindByStatus mocks base method.
-func (m *MockPrintCenterRepository) FindByStatus(arg0 entity.PrintCenterStatus) ([]entity.PrintCenter, error) {
+// List mocks base method.
+func (m *MockPrintCenterRepository) List(arg0 repository.ListQuery) ([]entity.PrintCenter, repository.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByStatus", arg0)
+	ret := m.ctrl.Call(m, "List", arg0)
 	ret0, _ := ret[0].([]entity.PrintCenter)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(repository.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// FindByStatus indicates an expected call of FindByStatus.
-func (mr *MockPrintCenterRepositoryMockRecorder) FindByStatus(arg0 interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockPrintCenterRepositoryMockRecorder) List(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockPrintCenterRepository)(nil).FindByStatus), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPrintCenterRepository)(nil).List), arg0)
 }
 
 // Save mocks base method.
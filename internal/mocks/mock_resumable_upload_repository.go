@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/repository (interfaces: ResumableUploadRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockResumableUploadRepository is a mock of ResumableUploadRepository interface.
+type MockResumableUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumableUploadRepositoryMockRecorder
+}
+
+// MockResumableUploadRepositoryMockRecorder is the mock recorder for MockResumableUploadRepository.
+type MockResumableUploadRepositoryMockRecorder struct {
+	mock *MockResumableUploadRepository
+}
+
+// NewMockResumableUploadRepository creates a new mock instance.
+func NewMockResumableUploadRepository(ctrl *gomock.Controller) *MockResumableUploadRepository {
+	mock := &MockResumableUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockResumableUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumableUploadRepository) EXPECT() *MockResumableUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockResumableUploadRepository) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockResumableUploadRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResumableUploadRepository)(nil).Delete), arg0)
+}
+
+// FindByID mocks base method.
+func (m *MockResumableUploadRepository) FindByID(arg0 string) (*entity.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*entity.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockResumableUploadRepositoryMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockResumableUploadRepository)(nil).FindByID), arg0)
+}
+
+// FindExpired mocks base method.
+func (m *MockResumableUploadRepository) FindExpired(arg0 time.Time, arg1 int) ([]entity.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", arg0, arg1)
+	ret0, _ := ret[0].([]entity.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockResumableUploadRepositoryMockRecorder) FindExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockResumableUploadRepository)(nil).FindExpired), arg0, arg1)
+}
+
+// Lock mocks base method.
+func (m *MockResumableUploadRepository) Lock(arg0 string, arg1 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockResumableUploadRepositoryMockRecorder) Lock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockResumableUploadRepository)(nil).Lock), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockResumableUploadRepository) Save(arg0 *entity.ResumableUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockResumableUploadRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockResumableUploadRepository)(nil).Save), arg0)
+}
+
+// Unlock mocks base method.
+func (m *MockResumableUploadRepository) Unlock(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockResumableUploadRepositoryMockRecorder) Unlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockResumableUploadRepository)(nil).Unlock), arg0)
+}
+
+// Update mocks base method.
+func (m *MockResumableUploadRepository) Update(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResumableUploadRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResumableUploadRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: ResumableUploadService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kimbasn/printly/internal/dto"
+	entity "github.com/kimbasn/printly/internal/entity"
+)
+
+// MockResumableUploadService is a mock of ResumableUploadService interface.
+type MockResumableUploadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumableUploadServiceMockRecorder
+}
+
+// MockResumableUploadServiceMockRecorder is the mock recorder for MockResumableUploadService.
+type MockResumableUploadServiceMockRecorder struct {
+	mock *MockResumableUploadService
+}
+
+// NewMockResumableUploadService creates a new mock instance.
+func NewMockResumableUploadService(ctrl *gomock.Controller) *MockResumableUploadService {
+	mock := &MockResumableUploadService{ctrl: ctrl}
+	mock.recorder = &MockResumableUploadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumableUploadService) EXPECT() *MockResumableUploadServiceMockRecorder {
+	return m.recorder
+}
+
+// AppendUpload mocks base method.
+func (m *MockResumableUploadService) AppendUpload(arg0 string, arg1 int64, arg2 io.Reader, arg3 entity.Actor) (*entity.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendUpload indicates an expected call of AppendUpload.
+func (mr *MockResumableUploadServiceMockRecorder) AppendUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendUpload", reflect.TypeOf((*MockResumableUploadService)(nil).AppendUpload), arg0, arg1, arg2, arg3)
+}
+
+// CreateUpload mocks base method.
+func (m *MockResumableUploadService) CreateUpload(arg0, arg1 uint, arg2 int64, arg3 entity.Actor) (*entity.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockResumableUploadServiceMockRecorder) CreateUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockResumableUploadService)(nil).CreateUpload), arg0, arg1, arg2, arg3)
+}
+
+// GetUpload mocks base method.
+func (m *MockResumableUploadService) GetUpload(arg0 string, arg1 entity.Actor) (*entity.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", arg0, arg1)
+	ret0, _ := ret[0].(*entity.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockResumableUploadServiceMockRecorder) GetUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockResumableUploadService)(nil).GetUpload), arg0, arg1)
+}
+
+// PurgeExpiredUploads mocks base method.
+func (m *MockResumableUploadService) PurgeExpiredUploads(arg0 context.Context) (*dto.ResumableUploadPurgeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredUploads", arg0)
+	ret0, _ := ret[0].(*dto.ResumableUploadPurgeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredUploads indicates an expected call of PurgeExpiredUploads.
+func (mr *MockResumableUploadServiceMockRecorder) PurgeExpiredUploads(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredUploads", reflect.TypeOf((*MockResumableUploadService)(nil).PurgeExpiredUploads), arg0)
+}
+
+// TerminateUpload mocks base method.
+func (m *MockResumableUploadService) TerminateUpload(arg0 string, arg1 entity.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateUpload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateUpload indicates an expected call of TerminateUpload.
+func (mr *MockResumableUploadServiceMockRecorder) TerminateUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateUpload", reflect.TypeOf((*MockResumableUploadService)(nil).TerminateUpload), arg0, arg1)
+}
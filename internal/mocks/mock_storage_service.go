@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kimbasn/printly/internal/service (interfaces: StorageService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	multipart "mime/multipart"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/kimbasn/printly/internal/service"
+)
+
+// MockStorageService is a mock of StorageService interface.
+type MockStorageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageServiceMockRecorder
+}
+
+// MockStorageServiceMockRecorder is the mock recorder for MockStorageService.
+type MockStorageServiceMockRecorder struct {
+	mock *MockStorageService
+}
+
+// NewMockStorageService creates a new mock instance.
+func NewMockStorageService(ctrl *gomock.Controller) *MockStorageService {
+	mock := &MockStorageService{ctrl: ctrl}
+	mock.recorder = &MockStorageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageService) EXPECT() *MockStorageServiceMockRecorder {
+	return m.recorder
+}
+
+// ComposeFiles mocks base method.
+func (m *MockStorageService) ComposeFiles(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComposeFiles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComposeFiles indicates an expected call of ComposeFiles.
+func (mr *MockStorageServiceMockRecorder) ComposeFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComposeFiles", reflect.TypeOf((*MockStorageService)(nil).ComposeFiles), arg0, arg1)
+}
+
+// DeleteFile mocks base method.
+func (m *MockStorageService) DeleteFile(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockStorageServiceMockRecorder) DeleteFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockStorageService)(nil).DeleteFile), arg0)
+}
+
+// GetFileURL mocks base method.
+func (m *MockStorageService) GetFileURL(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileURL", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileURL indicates an expected call of GetFileURL.
+func (mr *MockStorageServiceMockRecorder) GetFileURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileURL", reflect.TypeOf((*MockStorageService)(nil).GetFileURL), arg0)
+}
+
+// GetSignedURL mocks base method.
+func (m *MockStorageService) GetSignedURL(arg0 string, arg1 time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignedURL", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignedURL indicates an expected call of GetSignedURL.
+func (mr *MockStorageServiceMockRecorder) GetSignedURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignedURL", reflect.TypeOf((*MockStorageService)(nil).GetSignedURL), arg0, arg1)
+}
+
+// GetSignedUploadURL mocks base method.
+func (m *MockStorageService) GetSignedUploadURL(arg0, arg1 string, arg2 time.Duration) (*service.SignedUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignedUploadURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*service.SignedUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignedUploadURL indicates an expected call of GetSignedUploadURL.
+func (mr *MockStorageServiceMockRecorder) GetSignedUploadURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignedUploadURL", reflect.TypeOf((*MockStorageService)(nil).GetSignedUploadURL), arg0, arg1, arg2)
+}
+
+// MoveFile mocks base method.
+func (m *MockStorageService) MoveFile(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockStorageServiceMockRecorder) MoveFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockStorageService)(nil).MoveFile), arg0, arg1)
+}
+
+// NewUploadPath mocks base method.
+func (m *MockStorageService) NewUploadPath(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUploadPath", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUploadPath indicates an expected call of NewUploadPath.
+func (mr *MockStorageServiceMockRecorder) NewUploadPath(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUploadPath", reflect.TypeOf((*MockStorageService)(nil).NewUploadPath), arg0, arg1)
+}
+
+// OpenFile mocks base method.
+func (m *MockStorageService) OpenFile(arg0 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", arg0)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockStorageServiceMockRecorder) OpenFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockStorageService)(nil).OpenFile), arg0)
+}
+
+// StatFile mocks base method.
+func (m *MockStorageService) StatFile(arg0 string) (*service.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatFile", arg0)
+	ret0, _ := ret[0].(*service.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatFile indicates an expected call of StatFile.
+func (mr *MockStorageServiceMockRecorder) StatFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatFile", reflect.TypeOf((*MockStorageService)(nil).StatFile), arg0)
+}
+
+// Type mocks base method.
+func (m *MockStorageService) Type() service.StorageType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Type")
+	ret0, _ := ret[0].(service.StorageType)
+	return ret0
+}
+
+// Type indicates an expected call of Type.
+func (mr *MockStorageServiceMockRecorder) Type() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Type", reflect.TypeOf((*MockStorageService)(nil).Type))
+}
+
+// UploadFile mocks base method.
+func (m *MockStorageService) UploadFile(arg0 multipart.File, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockStorageServiceMockRecorder) UploadFile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockStorageService)(nil).UploadFile), arg0, arg1, arg2)
+}
+
+// UploadFromReader mocks base method.
+func (m *MockStorageService) UploadFromReader(arg0 io.Reader, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFromReader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFromReader indicates an expected call of UploadFromReader.
+func (mr *MockStorageServiceMockRecorder) UploadFromReader(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFromReader", reflect.TypeOf((*MockStorageService)(nil).UploadFromReader), arg0, arg1, arg2)
+}
+
+// WriteFile mocks base method.
+func (m *MockStorageService) WriteFile(arg0 string, arg1 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteFile indicates an expected call of WriteFile.
+func (mr *MockStorageServiceMockRecorder) WriteFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFile", reflect.TypeOf((*MockStorageService)(nil).WriteFile), arg0, arg1)
+}
@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/kimbasn/printly/internal/entity"
+	repository "github.com/kimbasn/printly/internal/repository"
 )
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -48,21 +49,6 @@ func (mr *MockUserRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), arg0)
 }
 
-// FindAll mocks base method.
-func (m *MockUserRepository) FindAll() ([]entity.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAll indicates an expected call of FindAll.
-func (mr *MockUserRepositoryMockRecorder) FindAll() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll))
-}
-
 // FindByUID mocks base method.
 func (m *MockUserRepository) FindByUID(arg0 string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +64,22 @@ func (mr *MockUserRepositoryMockRecorder) FindByUID(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUID", reflect.TypeOf((*MockUserRepository)(nil).FindByUID), arg0)
 }
 
+// List mocks base method.
+func (m *MockUserRepository) List(arg0 repository.ListQuery) ([]entity.User, repository.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(repository.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), arg0)
+}
+
 // Save mocks base method.
 func (m *MockUserRepository) Save(arg0 *entity.User) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../mocks/mock_job_repository.go -package=mocks github.com/kimbasn/printly/internal/repository JobRepository
+
+// JobRepository defines the interface for background job leases and run records.
+type JobRepository interface {
+	AcquireLease(name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(name, holder string) error
+	SaveRun(run *entity.JobRun) error
+	UpdateRun(run *entity.JobRun) error
+	FindLastRun(name string) (*entity.JobRun, error)
+	DeleteRunsBefore(cutoff time.Time) (int64, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new instance of a JobRepository.
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// AcquireLease takes the lease of a job for the holder if it is free, expired or already held by the holder.
+// It reports whether the holder owns the lease afterwards.
+func (r *jobRepository) AcquireLease(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	// Make sure the lease row exists, the conditional update below decides who gets it
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.JobLease{Name: name}).Error; err != nil {
+		return false, fmt.Errorf("failed to create lease for job %s: %w", name, err)
+	}
+
+	result := r.db.Model(&entity.JobLease{}).
+		Where("name = ? AND (holder = ? OR holder = '' OR expires_at < ?)", name, holder, now).
+		Updates(map[string]any{"holder": holder, "expires_at": now.Add(ttl)})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire lease for job %s: %w", name, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseLease frees the lease of a job if it is held by the holder.
+func (r *jobRepository) ReleaseLease(name, holder string) error {
+	result := r.db.Model(&entity.JobLease{}).
+		Where("name = ? AND holder = ?", name, holder).
+		Updates(map[string]any{"holder": "", "expires_at": time.Time{}})
+	if result.Error != nil {
+		return fmt.Errorf("failed to release lease for job %s: %w", name, result.Error)
+	}
+	return nil
+}
+
+// SaveRun creates a new job run record in the database.
+func (r *jobRepository) SaveRun(run *entity.JobRun) error {
+	if err := r.db.Create(run).Error; err != nil {
+		return fmt.Errorf("failed to save run of job %s: %w", run.JobName, err)
+	}
+	return nil
+}
+
+// UpdateRun saves the outcome of a job run.
+func (r *jobRepository) UpdateRun(run *entity.JobRun) error {
+	result := r.db.Model(&entity.JobRun{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":      run.Status,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"error":       run.Error,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job run id %d: %w", run.ID, result.Error)
+	}
+	return nil
+}
+
+// FindLastRun retrieves the most recent run of a job.
+func (r *jobRepository) FindLastRun(name string) (*entity.JobRun, error) {
+	var run entity.JobRun
+	result := r.db.Where("job_name = ?", name).Order("started_at DESC, id DESC").First(&run)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch last run of job %s: %w", name, result.Error)
+	}
+	return &run, nil
+}
+
+// DeleteRunsBefore removes the finished job runs started before the cutoff.
+func (r *jobRepository) DeleteRunsBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("started_at < ? AND status <> ?", cutoff, entity.JobRunning).Delete(&entity.JobRun{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete job runs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../mocks/mock_notification_repository.go -package=mocks github.com/kimbasn/printly/internal/repository NotificationRepository
+
+// NotificationRepository defines the interface for the notification queue and its records.
+type NotificationRepository interface {
+	Save(notifications []entity.Notification) error
+	ExistsForOrder(orderID uint, event entity.NotificationEvent) (bool, error)
+	FindDue(now time.Time, limit int) ([]entity.Notification, error)
+	Update(id uint, updates map[string]any) error
+	List(q ListQuery) ([]entity.Notification, PageInfo, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new instance of a NotificationRepository.
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Save queues notifications in a single insert.
+func (r *notificationRepository) Save(notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := r.db.Create(&notifications).Error; err != nil {
+		return fmt.Errorf("failed to save notifications: %w", err)
+	}
+	return nil
+}
+
+// ExistsForOrder reports whether the customer of an order was already notified of an event, on any channel.
+func (r *notificationRepository) ExistsForOrder(orderID uint, event entity.NotificationEvent) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.Notification{}).
+		Where("order_id = ? AND event = ?", orderID, event).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check notifications of order %d: %w", orderID, result.Error)
+	}
+	return count > 0, nil
+}
+
+// FindDue retrieves the pending notifications whose next attempt is due, oldest first.
+func (r *notificationRepository) FindDue(now time.Time, limit int) ([]entity.Notification, error) {
+	var notifications []entity.Notification
+	result := r.db.
+		Where("status = ? AND next_attempt_at <= ?", entity.NotificationPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&notifications)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch due notifications: %w", result.Error)
+	}
+	return notifications, nil
+}
+
+// Update modifies the delivery state of a notification.
+func (r *notificationRepository) Update(id uint, updates map[string]any) error {
+	result := r.db.Model(&entity.Notification{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update notification id %d: %w", id, result.Error)
+	}
+	return nil
+}
+
+// notificationListSpec lists notifications newest first by default.
+var notificationListSpec = listSpec{
+	sortable:    []string{"id", "created_at", "status"},
+	defaultSort: "-created_at",
+	key:         "id",
+}
+
+// List retrieves one page of notifications, filtered by status, order, recipient user and creation date.
+func (r *notificationRepository) List(q ListQuery) ([]entity.Notification, PageInfo, error) {
+	query := r.db.Model(&entity.Notification{})
+	if len(q.Filter.Statuses) > 0 {
+		query = query.Where("status IN ?", q.Filter.Statuses)
+	}
+	if q.Filter.OrderID != 0 {
+		query = query.Where("order_id = ?", q.Filter.OrderID)
+	}
+	if q.Filter.UserUID != "" {
+		query = query.Where("user_uid = ?", q.Filter.UserUID)
+	}
+	query = applyCreatedRange(query, q.Filter)
+
+	notifications, info, err := findPage[entity.Notification](query, notificationListSpec, q)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return notifications, info, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kimbasn/printly/internal/entity"
 	"gorm.io/gorm"
@@ -15,14 +16,26 @@ type OrderRepository interface {
 	Save(order *entity.Order) error
 	FindByID(id uint) (*entity.Order, error)
 	FindByCode(code string) (*entity.Order, error)
-	FindByCenterID(centerID uint) ([]entity.Order, error)
-	FindByUserUID(userUID string) ([]entity.Order, error)
 	FindByStatus(status entity.OrderStatus) ([]entity.Order, error)
-	FindAll() ([]entity.Order, error)
+	FindStale(status entity.OrderStatus, updatedBefore time.Time, limit int) ([]entity.Order, error)
+	CountQueueAhead(centerID, orderID uint) (int64, error)
+	List(q ListQuery) ([]entity.Order, PageInfo, error)
 	Update(id uint, updates map[string]any) error
+	UpdateStatus(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error
+	UpdateStatusAndDocuments(id uint, from entity.OrderStatus, updates map[string]any, documents map[uint]map[string]any, history *entity.OrderStatusHistory) error
+	FindStatusHistory(orderID uint) ([]entity.OrderStatusHistory, error)
 	Delete(id uint) error
+	MarkDocumentDeleted(receipt *entity.DeletionReceipt) error
+	FindDeletionReceipt(documentID uint) (*entity.DeletionReceipt, error)
+	UpdateDocument(id uint, updates map[string]any) error
+	FindDocumentByStoragePath(storagePath string) (*entity.Document, error)
+	MarkDocumentsPrinted(orderID uint, at time.Time) error
+	FindDocumentsToPurge(terminal []entity.OrderStatus, createdBefore time.Time, limit int) ([]entity.Document, error)
 }
 
+// ErrStaleStatus is returned by UpdateStatus when the order no longer has the expected status.
+var ErrStaleStatus = errors.New("order status changed concurrently")
+
 type orderRepository struct {
 	db *gorm.DB
 }
@@ -32,18 +45,30 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 	return &orderRepository{db: db}
 }
 
-// Save creates a new order record in the database.
+// Save creates a new order record and its documents in a single transaction.
 func (r *orderRepository) Save(order *entity.Order) error {
-	if err := r.db.Create(order).Error; err != nil {
-		return fmt.Errorf("failed to save order: %w", err)
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Documents").Create(order).Error; err != nil {
+			return fmt.Errorf("failed to save order: %w", err)
+		}
+
+		if len(order.Documents) == 0 {
+			return nil
+		}
+		for i := range order.Documents {
+			order.Documents[i].OrderID = order.ID
+		}
+		if err := tx.Create(&order.Documents).Error; err != nil {
+			return fmt.Errorf("failed to save documents of order: %w", err)
+		}
+		return nil
+	})
 }
 
 // FindByID retrieves an order from the database by its primary key.
 func (r *orderRepository) FindByID(id uint) (*entity.Order, error) {
 	var order entity.Order
-	result := r.db.First(&order, id)
+	result := r.db.Preload("Documents").First(&order, id)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, gorm.ErrRecordNotFound
@@ -67,45 +92,74 @@ func (r *orderRepository) FindByCode(code string) (*entity.Order, error) {
 	return &order, nil
 }
 
-// FindByCenterID retrieves all orders associated with a specific print center.
-func (r *orderRepository) FindByCenterID(centerID uint) ([]entity.Order, error) {
+// FindByStatus retrieves all orders with a specific status.
+func (r *orderRepository) FindByStatus(status entity.OrderStatus) ([]entity.Order, error) {
 	var orders []entity.Order
-	result := r.db.Find(&orders, "print_center_id = ?", centerID)
+	result := r.db.Find(&orders, "status = ?", status)
 
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch orders for center id %d: %w", centerID, result.Error)
+		return nil, fmt.Errorf("failed to fetch orders with status %s: %w", status, result.Error)
 	}
 	return orders, nil
 }
 
-func (r *orderRepository) FindByUserUID(userUID string) ([]entity.Order, error) {
+// FindStale retrieves the orders left in a status since before the given time, oldest first, with their documents.
+func (r *orderRepository) FindStale(status entity.OrderStatus, updatedBefore time.Time, limit int) ([]entity.Order, error) {
 	var orders []entity.Order
-	result := r.db.Find(&orders, "user_uid = ?", userUID)
+	result := r.db.Preload("Documents").
+		Where("status = ? AND updated_at < ?", status, updatedBefore).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&orders)
 
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch orders for user uid %s: %w", userUID, result.Error)
+		return nil, fmt.Errorf("failed to fetch stale orders with status %s: %w", status, result.Error)
 	}
 	return orders, nil
 }
 
-// FindByStatus retrieves all orders with a specific status.
-func (r *orderRepository) FindByStatus(status entity.OrderStatus) ([]entity.Order, error) {
-	var orders []entity.Order
-	result := r.db.Find(&orders, "status = ?", status)
+// CountQueueAhead counts the orders of a print center printed before the given order:
+// the orders being printed, and the orders ready to print that were placed earlier.
+func (r *orderRepository) CountQueueAhead(centerID, orderID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.Order{}).
+		Where("print_center_id = ?", centerID).
+		Where("status = ? OR (status = ? AND id < ?)", entity.StatusPrinting, entity.StatusReadyToPrint, orderID).
+		Count(&count)
 
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch orders with status %s: %w", status, result.Error)
+		return 0, fmt.Errorf("failed to count the print queue of center %d: %w", centerID, result.Error)
 	}
-	return orders, nil
+	return count, nil
 }
 
-// FindAll retrieves all order records.
-func (r *orderRepository) FindAll() ([]entity.Order, error) {
-	var orders []entity.Order
-	if err := r.db.Find(&orders).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch all orders: %w", err)
+// orderListSpec lists orders newest first by default.
+var orderListSpec = listSpec{
+	sortable:    []string{"id", "created_at", "updated_at", "status", "total_cost"},
+	defaultSort: "-created_at",
+	key:         "id",
+}
+
+// List retrieves one page of orders with their documents. Orders are filtered by
+// status, print center, owner and creation date.
+func (r *orderRepository) List(q ListQuery) ([]entity.Order, PageInfo, error) {
+	query := r.db.Model(&entity.Order{})
+	if len(q.Filter.Statuses) > 0 {
+		query = query.Where("status IN ?", q.Filter.Statuses)
 	}
-	return orders, nil
+	if q.Filter.CenterID != 0 {
+		query = query.Where("print_center_id = ?", q.Filter.CenterID)
+	}
+	if q.Filter.UserUID != "" {
+		query = query.Where("user_uid = ?", q.Filter.UserUID)
+	}
+	query = applyCreatedRange(query, q.Filter)
+
+	orders, info, err := findPage[entity.Order](query, orderListSpec, q, "Documents")
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return orders, info, nil
 }
 
 // Update modifies an existing order's record.
@@ -117,6 +171,60 @@ func (r *orderRepository) Update(id uint, updates map[string]any) error {
 	return nil
 }
 
+// UpdateStatus applies a status change and records it in the order's history in a single transaction.
+// The update only happens if the order is still in the `from` status, otherwise ErrStaleStatus is returned.
+func (r *orderRepository) UpdateStatus(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateStatus(tx, id, from, updates, history)
+	})
+}
+
+// UpdateStatusAndDocuments applies a status change like UpdateStatus, together with column updates
+// to documents of the order keyed by their ID, in a single transaction. Nothing is written when the
+// order no longer has the `from` status.
+func (r *orderRepository) UpdateStatusAndDocuments(id uint, from entity.OrderStatus, updates map[string]any, documents map[uint]map[string]any, history *entity.OrderStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateStatus(tx, id, from, updates, history); err != nil {
+			return err
+		}
+
+		for docID, docUpdates := range documents {
+			result := tx.Model(&entity.Document{}).Where("id = ? AND order_id = ?", docID, id).Updates(docUpdates)
+			if result.Error != nil {
+				return fmt.Errorf("failed to update document id %d: %w", docID, result.Error)
+			}
+		}
+		return nil
+	})
+}
+
+// updateStatus applies a status change within a transaction and records it in the order's history.
+func updateStatus(tx *gorm.DB, id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+	result := tx.Model(&entity.Order{}).Where("id = ? AND status = ?", id, from).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update status of order id %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleStatus
+	}
+
+	if err := tx.Create(history).Error; err != nil {
+		return fmt.Errorf("failed to save status history of order id %d: %w", id, err)
+	}
+	return nil
+}
+
+// FindStatusHistory retrieves the status transitions of an order, oldest first.
+func (r *orderRepository) FindStatusHistory(orderID uint) ([]entity.OrderStatusHistory, error) {
+	var history []entity.OrderStatusHistory
+	result := r.db.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&history)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch status history for order id %d: %w", orderID, result.Error)
+	}
+	return history, nil
+}
+
 // Delete removes an order from the database.
 func (r *orderRepository) Delete(id uint) error {
 	result := r.db.Delete(&entity.Order{}, id)
@@ -128,3 +236,83 @@ func (r *orderRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// MarkDocumentDeleted records that the file of a document was removed from storage, together with its receipt.
+func (r *orderRepository) MarkDocumentDeleted(receipt *entity.DeletionReceipt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Document{}).Where("id = ?", receipt.DocumentID).Update("storage_deleted_at", receipt.DeletedAt)
+		if result.Error != nil {
+			return fmt.Errorf("failed to mark document id %d as deleted: %w", receipt.DocumentID, result.Error)
+		}
+
+		if err := tx.Create(receipt).Error; err != nil {
+			return fmt.Errorf("failed to save deletion receipt of document id %d: %w", receipt.DocumentID, err)
+		}
+		return nil
+	})
+}
+
+// FindDeletionReceipt retrieves the deletion receipt of a document.
+func (r *orderRepository) FindDeletionReceipt(documentID uint) (*entity.DeletionReceipt, error) {
+	var receipt entity.DeletionReceipt
+	result := r.db.Where("document_id = ?", documentID).First(&receipt)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch deletion receipt of document id %d: %w", documentID, result.Error)
+	}
+	return &receipt, nil
+}
+
+// UpdateDocument applies column updates to a document.
+func (r *orderRepository) UpdateDocument(id uint, updates map[string]any) error {
+	result := r.db.Model(&entity.Document{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update document id %d: %w", id, result.Error)
+	}
+	return nil
+}
+
+// FindDocumentByStoragePath retrieves the document stored at a path, together with its order.
+func (r *orderRepository) FindDocumentByStoragePath(storagePath string) (*entity.Document, error) {
+	var doc entity.Document
+	result := r.db.Preload("Order").Where("storage_path = ?", storagePath).First(&doc)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch document stored at %s: %w", storagePath, result.Error)
+	}
+	return &doc, nil
+}
+
+// MarkDocumentsPrinted records the print time of the documents of an order not printed yet.
+func (r *orderRepository) MarkDocumentsPrinted(orderID uint, at time.Time) error {
+	result := r.db.Model(&entity.Document{}).
+		Where("order_id = ? AND printed_at IS NULL", orderID).
+		Update("printed_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark documents of order id %d as printed: %w", orderID, result.Error)
+	}
+	return nil
+}
+
+// FindDocumentsToPurge retrieves the documents still in storage that are printed, belong to an order
+// in one of the terminal statuses, or belong to an order created before the given time.
+// Documents of deleted orders are included, each with its order loaded.
+func (r *orderRepository) FindDocumentsToPurge(terminal []entity.OrderStatus, createdBefore time.Time, limit int) ([]entity.Document, error) {
+	var documents []entity.Document
+	result := r.db.Preload("Order", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Joins("JOIN orders ON orders.id = documents.order_id").
+		Where("documents.storage_deleted_at IS NULL").
+		Where("documents.printed_at IS NOT NULL OR orders.status IN ? OR orders.created_at < ?", terminal, createdBefore).
+		Order("documents.id ASC").
+		Limit(limit).
+		Find(&documents)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch documents to purge: %w", result.Error)
+	}
+	return documents, nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize is the page size of list queries that do not set one
+	DefaultPageSize = 20
+	// MaxPageSize bounds the page size of list queries
+	MaxPageSize = 100
+)
+
+var (
+	// ErrInvalidCursor is returned by list queries given a cursor they did not issue.
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	// ErrUnsupportedSort is returned by list queries sorted on a column they do not allow.
+	ErrUnsupportedSort = errors.New("unsupported sort column")
+)
+
+// ListQuery selects one page of a list.
+type ListQuery struct {
+	// Limit is the page size, DefaultPageSize when zero and at most MaxPageSize
+	Limit int
+	// Offset skips the first rows of the list. It is ignored when Cursor is set.
+	Offset int
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	// Sort is a sortable column of the list, prefixed with "-" for descending order.
+	// The list default applies when empty.
+	Sort   string
+	Filter ListFilter
+}
+
+// ListFilter restricts the rows of a list. Zero fields are ignored, and each list
+// only reads the fields that apply to it.
+type ListFilter struct {
+	Statuses    []string
+	CenterID    uint
+	OrderID     uint
+	UserUID     string
+	Role        string
+	CreatedFrom *time.Time // inclusive
+	CreatedTo   *time.Time // exclusive
+}
+
+// PageInfo describes a page of a list.
+type PageInfo struct {
+	// Total counts the rows matching the filter, on all pages
+	Total int64
+	// NextCursor points after the last row of the page, empty on the last page
+	NextCursor string
+}
+
+// listSpec describes how a table may be listed.
+type listSpec struct {
+	// sortable are the columns a list may be sorted on
+	sortable []string
+	// defaultSort is used when the query does not set one
+	defaultSort string
+	// key is a unique column breaking ties between rows with the same sort value
+	key string
+}
+
+// cursor is the position after the last row of a page, for a given sort.
+type cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	Key   json.RawMessage `json:"k"`
+}
+
+// findPage loads one page of the rows selected by db, which carries the filters of the query.
+// Rows are ordered by the sort column then by the key of the list, so that a cursor always
+// resumes right after the last row it was issued for.
+func findPage[T any](db *gorm.DB, spec listSpec, q ListQuery, preloads ...string) ([]T, PageInfo, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	var after *cursor
+	if q.Cursor != "" {
+		var err error
+		if after, err = decodeCursor(q.Cursor); err != nil {
+			return nil, PageInfo{}, err
+		}
+		if q.Sort == "" {
+			q.Sort = after.Sort
+		}
+	}
+	if q.Sort == "" {
+		q.Sort = spec.defaultSort
+	}
+	column, desc := strings.CutPrefix(q.Sort, "-")
+	if !slices.Contains(spec.sortable, column) {
+		return nil, PageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedSort, column)
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to count rows: %w", err)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to parse list model: %w", err)
+	}
+
+	query := db.Session(&gorm.Session{})
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
+	op, direction := ">", "ASC"
+	if desc {
+		op, direction = "<", "DESC"
+	}
+	if after != nil {
+		if after.Sort != q.Sort {
+			return nil, PageInfo{}, ErrInvalidCursor
+		}
+		key, err := decodeCursorValue(stmt, spec.key, after.Key)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		if column == spec.key {
+			query = query.Where(fmt.Sprintf("%s %s ?", spec.key, op), key)
+		} else {
+			value, err := decodeCursorValue(stmt, column, after.Value)
+			if err != nil {
+				return nil, PageInfo{}, err
+			}
+			query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column, op, column, spec.key, op), value, value, key)
+		}
+	} else if q.Offset > 0 {
+		query = query.Offset(q.Offset)
+	}
+
+	// One extra row tells whether there is a next page
+	var items []T
+	err := query.Order(fmt.Sprintf("%s %s", column, direction)).
+		Order(fmt.Sprintf("%s %s", spec.key, direction)).
+		Limit(limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to fetch rows: %w", err)
+	}
+
+	info := PageInfo{Total: total}
+	if len(items) > limit {
+		items = items[:limit]
+		next, err := encodeCursor(stmt, q.Sort, column, spec.key, &items[limit-1])
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		info.NextCursor = next
+	}
+	return items, info, nil
+}
+
+// applyCreatedRange restricts db to the rows created in the range of the filter.
+func applyCreatedRange(db *gorm.DB, f ListFilter) *gorm.DB {
+	if f.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		db = db.Where("created_at < ?", *f.CreatedTo)
+	}
+	return db
+}
+
+// encodeCursor returns the opaque cursor pointing after the given row.
+func encodeCursor(stmt *gorm.Statement, sort, column, key string, row any) (string, error) {
+	value, err := cursorValue(stmt, column, row)
+	if err != nil {
+		return "", err
+	}
+	keyValue, err := cursorValue(stmt, key, row)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(cursor{Sort: sort, Value: value, Key: keyValue})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor reads a cursor issued by encodeCursor.
+func decodeCursor(encoded string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort == "" || c.Key == nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// cursorValue returns the JSON encoded value of a column of the row.
+func cursorValue(stmt *gorm.Statement, column string, row any) (json.RawMessage, error) {
+	field := stmt.Schema.LookUpField(column)
+	if field == nil {
+		return nil, fmt.Errorf("unknown column %s", column)
+	}
+	value, _ := field.ValueOf(context.Background(), reflect.ValueOf(row).Elem())
+	return json.Marshal(value)
+}
+
+// decodeCursorValue decodes a cursor value into the Go type of the column, so that
+// it is bound to the query the same way the column values are stored.
+func decodeCursorValue(stmt *gorm.Statement, column string, raw json.RawMessage) (any, error) {
+	field := stmt.Schema.LookUpField(column)
+	if field == nil {
+		return nil, fmt.Errorf("unknown column %s", column)
+	}
+	value := reflect.New(field.FieldType)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return value.Elem().Interface(), nil
+}
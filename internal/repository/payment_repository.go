@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../mocks/mock_payment_repository.go -package=mocks github.com/kimbasn/printly/internal/repository PaymentRepository
+
+// PaymentRepository defines the interface for payment-related database operations.
+type PaymentRepository interface {
+	Save(payment *entity.Payment) error
+	FindBySessionID(sessionID string) (*entity.Payment, error)
+	FindPendingByOrderID(orderID uint) (*entity.Payment, error)
+	FindSucceededByOrderID(orderID uint) (*entity.Payment, error)
+	Update(id uint, updates map[string]any) error
+	HasEvent(eventID string) (bool, error)
+	UpdateWithEvent(id uint, updates map[string]any, event *entity.PaymentWebhookEvent) error
+}
+
+// ErrEventAlreadyApplied is returned by UpdateWithEvent when the event was already applied.
+var ErrEventAlreadyApplied = errors.New("payment event already applied")
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository creates a new instance of a PaymentRepository.
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// Save creates a new payment record in the database.
+func (r *paymentRepository) Save(payment *entity.Payment) error {
+	if err := r.db.Create(payment).Error; err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+	return nil
+}
+
+// FindBySessionID retrieves a payment by the checkout session ID issued by the gateway.
+func (r *paymentRepository) FindBySessionID(sessionID string) (*entity.Payment, error) {
+	var payment entity.Payment
+	result := r.db.First(&payment, "session_id = ?", sessionID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch payment with session id %s: %w", sessionID, result.Error)
+	}
+	return &payment, nil
+}
+
+// FindPendingByOrderID retrieves the most recent pending payment of an order.
+func (r *paymentRepository) FindPendingByOrderID(orderID uint) (*entity.Payment, error) {
+	var payment entity.Payment
+	result := r.db.Where("order_id = ? AND status = ?", orderID, entity.PaymentPending).
+		Order("created_at DESC").
+		First(&payment)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch pending payment for order id %d: %w", orderID, result.Error)
+	}
+	return &payment, nil
+}
+
+// FindSucceededByOrderID retrieves the settled payment of an order.
+func (r *paymentRepository) FindSucceededByOrderID(orderID uint) (*entity.Payment, error) {
+	var payment entity.Payment
+	result := r.db.Where("order_id = ? AND status = ?", orderID, entity.PaymentSucceeded).
+		Order("created_at DESC").
+		First(&payment)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch succeeded payment for order id %d: %w", orderID, result.Error)
+	}
+	return &payment, nil
+}
+
+// Update modifies an existing payment's record.
+func (r *paymentRepository) Update(id uint, updates map[string]any) error {
+	result := r.db.Model(&entity.Payment{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update payment id %d: %w", id, result.Error)
+	}
+	return nil
+}
+
+// HasEvent tells whether a provider event was already applied to a payment.
+func (r *paymentRepository) HasEvent(eventID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.PaymentWebhookEvent{}).Where("event_id = ?", eventID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to look up payment event %s: %w", eventID, err)
+	}
+	return count > 0, nil
+}
+
+// UpdateWithEvent records a provider event and applies its updates to the payment in a single transaction.
+// Nothing is written and ErrEventAlreadyApplied is returned when the event was already recorded.
+func (r *paymentRepository) UpdateWithEvent(id uint, updates map[string]any, event *entity.PaymentWebhookEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return fmt.Errorf("failed to record payment event %s: %w", event.EventID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrEventAlreadyApplied
+		}
+
+		if err := tx.Model(&entity.Payment{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update payment id %d: %w", id, err)
+		}
+		return nil
+	})
+}
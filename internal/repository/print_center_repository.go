@@ -13,8 +13,7 @@ import (
 type PrintCenterRepository interface {
 	Save(printCenter *entity.PrintCenter) error
 	FindByID(id uint) (*entity.PrintCenter, error)
-	FindByStatus(status entity.PrintCenterStatus) ([]entity.PrintCenter, error)
-	FindAll() ([]entity.PrintCenter, error)
+	List(q ListQuery) ([]entity.PrintCenter, PageInfo, error)
 	Update(id uint, updates map[string]any) error
 	Delete(id uint) error
 }
@@ -36,7 +35,7 @@ func (r *printCenterRepository) Save(printCenter *entity.PrintCenter) error {
 
 func (r *printCenterRepository) FindByID(id uint) (*entity.PrintCenter, error) {
 	var printCenter entity.PrintCenter
-	result := r.db.First(&printCenter, "id = ?", id)
+	result := r.db.Preload("WorkingHours").Preload("Services.Tiers").First(&printCenter, "id = ?", id)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -47,22 +46,26 @@ func (r *printCenterRepository) FindByID(id uint) (*entity.PrintCenter, error) {
 	return &printCenter, nil
 }
 
-func (r *printCenterRepository) FindByStatus(status entity.PrintCenterStatus) ([]entity.PrintCenter, error) {
-	var printCenters []entity.PrintCenter
-	result := r.db.Find(&printCenters, "status = ?", status)
+// printCenterListSpec lists print centers by registration date by default.
+var printCenterListSpec = listSpec{
+	sortable:    []string{"id", "created_at", "name", "status"},
+	defaultSort: "created_at",
+	key:         "id",
+}
 
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch print centers with status: %s: %w", status, result.Error)
+// List retrieves one page of print centers, filtered by status and registration date.
+func (r *printCenterRepository) List(q ListQuery) ([]entity.PrintCenter, PageInfo, error) {
+	query := r.db.Model(&entity.PrintCenter{})
+	if len(q.Filter.Statuses) > 0 {
+		query = query.Where("status IN ?", q.Filter.Statuses)
 	}
-	return printCenters, nil
-}
+	query = applyCreatedRange(query, q.Filter)
 
-func (r *printCenterRepository) FindAll() ([]entity.PrintCenter, error) {
-	var printCenters []entity.PrintCenter
-	if err := r.db.Find(&printCenters).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch all print centers: %w", err)
+	centers, info, err := findPage[entity.PrintCenter](query, printCenterListSpec, q)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to list print centers: %w", err)
 	}
-	return printCenters, nil
+	return centers, info, nil
 }
 
 func (r *printCenterRepository) Update(id uint, updates map[string]interface{}) error {
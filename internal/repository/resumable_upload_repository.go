@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../mocks/mock_resumable_upload_repository.go -package=mocks github.com/kimbasn/printly/internal/repository ResumableUploadRepository
+
+// ResumableUploadRepository defines the interface for the records of uploads sent in several requests.
+type ResumableUploadRepository interface {
+	Save(upload *entity.ResumableUpload) error
+	FindByID(id string) (*entity.ResumableUpload, error)
+	Lock(id string, until time.Time) (bool, error)
+	Unlock(id string) error
+	Update(id string, updates map[string]any) error
+	Delete(id string) error
+	FindExpired(now time.Time, limit int) ([]entity.ResumableUpload, error)
+}
+
+type resumableUploadRepository struct {
+	db *gorm.DB
+}
+
+// NewResumableUploadRepository creates a new instance of a ResumableUploadRepository.
+func NewResumableUploadRepository(db *gorm.DB) ResumableUploadRepository {
+	return &resumableUploadRepository{db: db}
+}
+
+// Save creates a new upload record in the database.
+func (r *resumableUploadRepository) Save(upload *entity.ResumableUpload) error {
+	if err := r.db.Create(upload).Error; err != nil {
+		return fmt.Errorf("failed to save upload for document %d: %w", upload.DocumentID, err)
+	}
+	return nil
+}
+
+// FindByID retrieves an upload by its ID.
+func (r *resumableUploadRepository) FindByID(id string) (*entity.ResumableUpload, error) {
+	var upload entity.ResumableUpload
+	result := r.db.Where("id = ?", id).First(&upload)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, gorm.ErrRecordNotFound
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch upload %s: %w", id, result.Error)
+	}
+	return &upload, nil
+}
+
+// Lock reserves an upload for one request until the given time, unless another request holds it.
+// It reports whether the lock was taken. Locks outlive crashed requests only until they expire.
+func (r *resumableUploadRepository) Lock(id string, until time.Time) (bool, error) {
+	result := r.db.Model(&entity.ResumableUpload{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until < ?)", id, time.Now()).
+		Update("locked_until", until)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to lock upload %s: %w", id, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Unlock releases the lock of an upload.
+func (r *resumableUploadRepository) Unlock(id string) error {
+	result := r.db.Model(&entity.ResumableUpload{}).Where("id = ?", id).Update("locked_until", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to unlock upload %s: %w", id, result.Error)
+	}
+	return nil
+}
+
+// Update modifies the progress of an upload.
+func (r *resumableUploadRepository) Update(id string, updates map[string]any) error {
+	result := r.db.Model(&entity.ResumableUpload{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update upload %s: %w", id, result.Error)
+	}
+	return nil
+}
+
+// Delete removes an upload record from the database.
+func (r *resumableUploadRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.ResumableUpload{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete upload %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindExpired retrieves the uploads expired before now, oldest first.
+func (r *resumableUploadRepository) FindExpired(now time.Time, limit int) ([]entity.ResumableUpload, error) {
+	var uploads []entity.ResumableUpload
+	result := r.db.
+		Where("expires_at < ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch expired uploads: %w", result.Error)
+	}
+	return uploads, nil
+}
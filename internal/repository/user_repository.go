@@ -18,7 +18,7 @@ type UserRepository interface {
 	FindByUID(uid string) (*entity.User, error)
 	Delete(uid string) error
 	Update(uid string, updates map[string]interface{}) error
-	FindAll() ([]entity.User, error)
+	List(q ListQuery) ([]entity.User, PageInfo, error)
 }
 
 // userRepository implements the UserRepository interface using GORM.
@@ -78,11 +78,24 @@ func (r *userRepository) Delete(uid string) error {
 	return nil
 }
 
-// FindAll retrieves all user records from the database.
-func (r *userRepository) FindAll() ([]entity.User, error) {
-	var users []entity.User
-	if err := r.db.Find(&users).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch all users: %w", err)
+// userListSpec lists users by registration date by default.
+var userListSpec = listSpec{
+	sortable:    []string{"uid", "created_at", "email", "last_name", "role"},
+	defaultSort: "created_at",
+	key:         "uid",
+}
+
+// List retrieves one page of users, filtered by role and registration date.
+func (r *userRepository) List(q ListQuery) ([]entity.User, PageInfo, error) {
+	query := r.db.Model(&entity.User{})
+	if q.Filter.Role != "" {
+		query = query.Where("role = ?", q.Filter.Role)
+	}
+	query = applyCreatedRange(query, q.Filter)
+
+	users, info, err := findPage[entity.User](query, userListSpec, q)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to list users: %w", err)
 	}
-	return users, nil
+	return users, info, nil
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/controller"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RegisterJobRoutes(rg *gin.RouterGroup, db *gorm.DB, fbApp *firebase.App, logger *zap.Logger, scheduler service.JobScheduler) {
+	jobController := controller.NewJobController(scheduler, logger)
+
+	// Admin-specific routes
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthenticationMiddleware(fbApp, db),
+		middlewares.RoleMiddleware(entity.RoleAdmin))
+	admin.GET("/jobs", jobController.ListJobs)
+	admin.POST("/jobs/:name/run", jobController.TriggerJob)
+}
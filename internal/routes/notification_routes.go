@@ -0,0 +1,24 @@
+package routes
+
+import (
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kimbasn/printly/internal/controller"
+	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RegisterNotificationRoutes(rg *gin.RouterGroup, db *gorm.DB, validate *validator.Validate, fbApp *firebase.App, logger *zap.Logger, notifier service.Notifier) {
+	notificationController := controller.NewNotificationController(notifier, validate, logger)
+
+	// Any authenticated user
+	authed := rg.Group("/")
+	authed.Use(middlewares.AuthenticationMiddleware(fbApp, db))
+	{
+		authed.GET("/users/me/notifications", notificationController.GetMyNotifications)
+		authed.GET("/orders/:id/notifications", notificationController.GetOrderNotifications)
+	}
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"time"
+
 	firebase "firebase.google.com/go/v4"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -13,35 +15,79 @@ import (
 	"gorm.io/gorm"
 )
 
-func RegisterOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, validate *validator.Validate, fbApp *firebase.App, logger *zap.Logger, storageService service.StorageService) {
+func RegisterOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, validate *validator.Validate, fbApp *firebase.App, logger *zap.Logger, storageService service.StorageService, scanner service.Scanner, pickupSigner service.PickupTokenSigner, receiptSigner service.DeletionReceiptSigner, gateway service.PaymentGateway, orderEvents service.OrderEventBus, notifier service.Notifier, orderPrintTime, uploadTimeout time.Duration) {
 	// Repositories
 	orderRepo := repository.NewOrderRepository(db)
 	printCenterRepo := repository.NewPrintCenterRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
 
 	// Service & Controller
+	stateMachine := service.NewOrderStateMachine(orderRepo, orderEvents, notifier, logger)
+	paymentService := service.NewPaymentService(paymentRepo, orderRepo, stateMachine, gateway, logger)
+	purger := service.NewDocumentPurger(orderRepo, storageService, receiptSigner, logger)
+	inspector := service.NewDocumentInspector(scanner, logger)
 	orderService := service.NewOrderService(orderRepo,
 		printCenterRepo,
 		userRepo,
+		stateMachine,
+		storageService,
+		inspector,
+		service.NewPricingEngine(),
+		service.NewPickupThrottle(service.DefaultPickupMaxFailures, service.DefaultPickupWindow),
+		pickupSigner,
+		receiptSigner,
+		paymentService,
+		purger,
+		orderEvents,
 		logger)
 	orderController := controller.NewOrderController(orderService,
 		storageService,
+		inspector,
 		validate,
 		logger)
+	trackingController := controller.NewTrackingController(
+		service.NewOrderTracker(orderRepo, orderEvents, orderPrintTime, logger),
+		logger)
 
 	// Public route for checking order status
 	rg.GET("/orders/status/:code", orderController.GetOrderByCode)
+	// Public WebSocket following an order, authorized by its tracking token
+	rg.GET("/orders/status/:code/live", trackingController.TrackOrder)
+	// Public route for pricing documents before ordering
+	rg.POST("/centers/:id/quote", orderController.QuoteOrder)
+	// Public route for checking deletion receipts
+	rg.POST("/deletion-receipts/verify", orderController.VerifyDeletionReceipt)
+	// Receives the files of signed upload URLs when the storage backend does not, authorized by the URL signature
+	if receiver, ok := storageService.(service.SignedUploadReceiver); ok {
+		uploadController := controller.NewUploadController(receiver, orderService, logger)
+		rg.PUT("/uploads/*path", middlewares.RequestDeadline(uploadTimeout), uploadController.ReceiveSignedUpload)
+	}
 
 	// Any authenticated user
 	authed := rg.Group("/")
 	authed.Use(middlewares.AuthenticationMiddleware(fbApp, db))
 	{
 		// any authenticated user
-		authed.POST("/centers/:id/orders", orderController.CreateOrder)
+		authed.POST("/centers/:id/orders", middlewares.RequestDeadline(uploadTimeout), orderController.CreateOrder)
+		authed.POST("/centers/:id/orders/uploads", orderController.CreateDirectUploadOrder)
+		authed.POST("/orders/:id/uploads/confirm", orderController.ConfirmDocumentUploads)
+		authed.GET("/orders/:id/history", orderController.GetOrderHistory)
+		authed.GET("/orders/:id/purge-report", orderController.GetPurgeReport)
+		authed.GET("/orders/:id/documents/:docId/deletion-receipt", orderController.GetDeletionReceipt)
+		authed.GET("/orders/:id/pickup-qr", orderController.GetPickupQR)
+		authed.POST("/orders/:id/schedule", orderController.ScheduleOrder)
+		authed.POST("/orders/:id/cancel", orderController.CancelOrder)
+		authed.GET("/users/me/orders", orderController.GetMyOrders)
+		authed.GET("/users/me/orders/:id", orderController.GetMyOrder)
 
 		// manager + admin
 		authed.GET("centers/:id/orders", middlewares.RoleMiddleware(entity.RoleManager, entity.RoleAdmin), orderController.GetOrdersForCenter)
+		authed.GET("/centers/:id/orders/stream", middlewares.RoleMiddleware(entity.RoleManager, entity.RoleAdmin), orderController.StreamCenterOrders)
 		authed.PATCH("/orders/:id/status", middlewares.RoleMiddleware(entity.RoleManager, entity.RoleAdmin), orderController.UpdateOrderStatus)
+
+		// manager only
+		authed.POST("/centers/:id/orders/verify", middlewares.RoleMiddleware(entity.RoleManager), orderController.VerifyPickup)
 	}
 
 	// Admin-specific routes
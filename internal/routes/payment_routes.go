@@ -0,0 +1,39 @@
+package routes
+
+import (
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/controller"
+	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RegisterPaymentRoutes(rg *gin.RouterGroup, db *gorm.DB, fbApp *firebase.App, logger *zap.Logger, gateway service.PaymentGateway, orderEvents service.OrderEventBus, notifier service.Notifier) {
+	// Repositories
+	paymentRepo := repository.NewPaymentRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+
+	// Service & Controller
+	stateMachine := service.NewOrderStateMachine(orderRepo, orderEvents, notifier, logger)
+	paymentService := service.NewPaymentService(paymentRepo, orderRepo, stateMachine, gateway, logger)
+	simulator, canSimulate := gateway.(service.PaymentSimulator)
+	paymentController := controller.NewPaymentController(paymentService, simulator, logger)
+
+	// Called by the payment provider, authenticated by the payload signature
+	rg.POST("/webhooks/payment", paymentController.HandleWebhook)
+
+	// Lets developers settle sessions of the mock provider
+	if canSimulate {
+		rg.POST("/mock/payments/:session_id", paymentController.SimulatePayment)
+	}
+
+	// Any authenticated user
+	authed := rg.Group("/")
+	authed.Use(middlewares.AuthenticationMiddleware(fbApp, db))
+	{
+		authed.POST("/orders/:id/pay", paymentController.InitiatePayment)
+	}
+}
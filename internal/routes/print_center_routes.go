@@ -9,12 +9,13 @@ import (
 	"github.com/kimbasn/printly/internal/middlewares"
 	"github.com/kimbasn/printly/internal/repository"
 	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-func RegisterPrintCenterRoutes(rg *gin.RouterGroup, db *gorm.DB, validate *validator.Validate, fbApp *firebase.App) {
+func RegisterPrintCenterRoutes(rg *gin.RouterGroup, db *gorm.DB, validate *validator.Validate, fbApp *firebase.App, logger *zap.Logger, notifier service.Notifier) {
 	repo := repository.NewPrintCenterRepository(db)
-	svc := service.NewPrintCenterService(repo)
+	svc := service.NewPrintCenterService(repo, notifier, logger)
 	printCenterController := controller.NewPrintCenterController(svc, validate)
 
 	// Publicly accessible print center routes
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/controller"
+	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RegisterResumableUploadRoutes(rg *gin.RouterGroup, db *gorm.DB, fbApp *firebase.App, logger *zap.Logger, storageService service.StorageService, uploadExpiry, uploadTimeout time.Duration) {
+	uploadService := service.NewResumableUploadService(repository.NewResumableUploadRepository(db),
+		repository.NewOrderRepository(db),
+		storageService,
+		uploadExpiry,
+		uploadTimeout,
+		logger)
+	uploadController := controller.NewResumableUploadController(uploadService, logger)
+
+	// Public discovery of the tus server
+	rg.OPTIONS("/resumable-uploads", uploadController.GetUploadOptions)
+
+	// Any authenticated user
+	authed := rg.Group("/resumable-uploads")
+	authed.Use(middlewares.AuthenticationMiddleware(fbApp, db))
+	{
+		authed.POST("", uploadController.CreateUpload)
+		authed.HEAD("/:id", uploadController.GetUploadOffset)
+		authed.PATCH("/:id", middlewares.RequestDeadline(uploadTimeout), uploadController.AppendUpload)
+		authed.DELETE("/:id", uploadController.TerminateUpload)
+	}
+}
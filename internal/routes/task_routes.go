@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/controller"
+	"github.com/kimbasn/printly/internal/middlewares"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func RegisterTaskRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg *config.Config, logger *zap.Logger, storageService service.StorageService, receiptSigner service.DeletionReceiptSigner, orderEvents service.OrderEventBus, notifier service.Notifier) {
+	// Called by external schedulers, disabled without a shared secret
+	if cfg.Tasks.Secret == "" {
+		return
+	}
+
+	// Repositories
+	orderRepo := repository.NewOrderRepository(db)
+
+	// Services & Controller
+	stateMachine := service.NewOrderStateMachine(orderRepo, orderEvents, notifier, logger)
+	purger := service.NewDocumentPurger(orderRepo, storageService, receiptSigner, logger)
+	expirer := service.NewOrderExpirer(orderRepo, stateMachine, purger, notifier, service.NewExpiryPolicy(cfg.OrderExpiry), cfg.OrderExpiry.WarnBefore, logger)
+	retention := service.NewDocumentRetention(orderRepo, purger, cfg.DocumentRetention.MaxAge, logger)
+	uploads := service.NewResumableUploadService(repository.NewResumableUploadRepository(db), orderRepo, storageService, cfg.OrderExpiry.AwaitingDocumentTTL, cfg.Timeouts.Upload, logger)
+	taskController := controller.NewTaskController(expirer, retention, notifier, uploads, logger)
+
+	tasks := rg.Group("/tasks")
+	tasks.Use(middlewares.TaskAuthMiddleware(cfg.Tasks.Secret))
+	tasks.POST("/order/timeout", taskController.ExpireOrders)
+	tasks.POST("/order/cleanup", taskController.PurgeDocuments)
+	tasks.POST("/notifications/deliver", taskController.DeliverNotifications)
+	tasks.POST("/uploads/cleanup", taskController.PurgeUploads)
+}
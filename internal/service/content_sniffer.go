@@ -0,0 +1,217 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	ierrors "github.com/kimbasn/printly/internal/errors"
+)
+
+// Content types detected from the content of uploaded files
+const (
+	mimePDF     = "application/pdf"
+	mimeDOC     = "application/msword"
+	mimeDOCX    = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeText    = "text/plain"
+	mimeJPEG    = "image/jpeg"
+	mimePNG     = "image/png"
+	mimeZip     = "application/zip"
+	mimeUnknown = "application/octet-stream"
+)
+
+// mimeAliases maps the content types clients may declare to the type detected for them
+var mimeAliases = map[string]string{
+	"image/jpg": mimeJPEG,
+}
+
+// canonicalMimeType returns the content type detected for files of the declared type
+func canonicalMimeType(mimeType string) string {
+	if canonical, ok := mimeAliases[mimeType]; ok {
+		return canonical
+	}
+	return mimeType
+}
+
+var (
+	pngSignature  = []byte("\x89PNG\r\n\x1a\n")
+	jpegSignature = []byte{0xFF, 0xD8, 0xFF}
+	zipSignature  = []byte("PK\x03\x04")
+	// Word 97-2003 documents are OLE compound files
+	oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	pdfHeader    = []byte("%PDF-")
+	// The name of the main stream of Word 97-2003 documents, in UTF-16LE as in the OLE directory
+	wordDocumentStream = []byte("W\x00o\x00r\x00d\x00D\x00o\x00c\x00u\x00m\x00e\x00n\x00t\x00")
+)
+
+const (
+	// pdfHeaderWindow and pdfTrailerWindow are where readers look for the header and the end of a PDF
+	pdfHeaderWindow  = 1024
+	pdfTrailerWindow = 1024
+	// maxImagePixels bounds the images decoded, above an A4 page scanned at 600 DPI
+	maxImagePixels = 50_000_000
+	// maxContentTypesSize bounds the [Content_Types].xml read from an OOXML package
+	maxContentTypesSize = 1 << 20
+	// scanChunkSize is the size of the chunks files are scanned in
+	scanChunkSize = 64 << 10
+)
+
+// detectContentType tells the type of a file from its content, whatever its name or declared type.
+// Files that start like a supported format but are broken are rejected as unreadable; files of
+// other formats are reported as application/octet-stream, or application/zip for other archives.
+func detectContentType(file io.ReaderAt, size int64) (string, error) {
+	head := make([]byte, min(size, pdfHeaderWindow))
+	n, err := file.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", unreadable(err)
+	}
+	head = head[:n]
+
+	switch {
+	case bytes.HasPrefix(head, pngSignature):
+		return mimePNG, checkImage(file, size, png.DecodeConfig, png.Decode)
+	case bytes.HasPrefix(head, jpegSignature):
+		return mimeJPEG, checkImage(file, size, jpeg.DecodeConfig, jpeg.Decode)
+	case bytes.Contains(head, pdfHeader):
+		return mimePDF, checkPDFTrailer(file, size)
+	case bytes.HasPrefix(head, zipSignature):
+		return detectOOXML(file, size)
+	case bytes.HasPrefix(head, oleSignature):
+		found, err := containsBytes(file, size, wordDocumentStream)
+		if err != nil {
+			return "", unreadable(err)
+		}
+		if !found {
+			return mimeUnknown, nil
+		}
+		return mimeDOC, nil
+	}
+
+	text, err := isText(file, size)
+	if err != nil {
+		return "", unreadable(err)
+	}
+	if text {
+		return mimeText, nil
+	}
+	return mimeUnknown, nil
+}
+
+// checkImage decodes a whole image, after checking from its header that it is not too large to decode
+func checkImage(file io.ReaderAt, size int64, decodeConfig func(io.Reader) (image.Config, error), decode func(io.Reader) (image.Image, error)) error {
+	config, err := decodeConfig(io.NewSectionReader(file, 0, size))
+	if err != nil {
+		return unreadable(err)
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return ierrors.ErrImageTooLarge
+	}
+	if _, err := decode(io.NewSectionReader(file, 0, size)); err != nil {
+		return unreadable(err)
+	}
+	return nil
+}
+
+// checkPDFTrailer checks that a PDF ends with the offset of its cross-reference table and the
+// end-of-file marker, which truncated files and other formats behind a PDF header lack.
+func checkPDFTrailer(file io.ReaderAt, size int64) error {
+	tail := make([]byte, min(size, pdfTrailerWindow))
+	if _, err := file.ReadAt(tail, size-int64(len(tail))); err != nil && !errors.Is(err, io.EOF) {
+		return unreadable(err)
+	}
+	if !bytes.Contains(tail, []byte("startxref")) || !bytes.Contains(tail, []byte("%%EOF")) {
+		return ierrors.NewWithCause(ierrors.InvalidArgument, ierrors.ErrUnreadableDocument.Error(), errors.New("PDF trailer not found"))
+	}
+	return nil
+}
+
+// detectOOXML tells whether a zip archive is a Word document, from the parts of the package
+// and the type of its main part. Only the directory and [Content_Types].xml are read.
+func detectOOXML(file io.ReaderAt, size int64) (string, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return "", unreadable(err)
+	}
+
+	var contentTypes *zip.File
+	hasDocument := false
+	for _, f := range archive.File {
+		switch f.Name {
+		case "[Content_Types].xml":
+			contentTypes = f
+		case "word/document.xml":
+			hasDocument = true
+		}
+	}
+	if contentTypes == nil || !hasDocument {
+		return mimeZip, nil
+	}
+
+	rc, err := contentTypes.Open()
+	if err != nil {
+		return "", unreadable(err)
+	}
+	defer rc.Close()
+	types, err := io.ReadAll(io.LimitReader(rc, maxContentTypesSize))
+	if err != nil {
+		return "", unreadable(err)
+	}
+	if !bytes.Contains(types, []byte("application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml")) {
+		return mimeZip, nil
+	}
+	return mimeDOCX, nil
+}
+
+// isText reports whether a file is text, i.e. holds none of the control bytes
+// http.DetectContentType considers binary. Any text encoding is accepted.
+func isText(file io.ReaderAt, size int64) (bool, error) {
+	if size == 0 {
+		return false, nil
+	}
+
+	binary := false
+	err := scanChunks(file, size, 0, func(chunk []byte) bool {
+		for _, b := range chunk {
+			if b <= 0x08 || b == 0x0B || (0x0E <= b && b <= 0x1A) || (0x1C <= b && b <= 0x1F) {
+				binary = true
+				return false
+			}
+		}
+		return true
+	})
+	return !binary, err
+}
+
+// containsBytes reports whether a file contains the given bytes
+func containsBytes(file io.ReaderAt, size int64, needle []byte) (bool, error) {
+	found := false
+	err := scanChunks(file, size, len(needle)-1, func(chunk []byte) bool {
+		found = bytes.Contains(chunk, needle)
+		return !found
+	})
+	return found, err
+}
+
+// scanChunks calls fn on the successive chunks of a file until it returns false.
+// Consecutive chunks share overlap bytes, so that sequences across chunks are seen whole.
+func scanChunks(file io.ReaderAt, size int64, overlap int, fn func(chunk []byte) bool) error {
+	buf := make([]byte, scanChunkSize+overlap)
+	for off := int64(0); off < size; off += scanChunkSize {
+		n, err := file.ReadAt(buf[:min(int64(len(buf)), size-off)], off)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if !fn(buf[:n]) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func unreadable(err error) error {
+	return ierrors.NewWithCause(ierrors.InvalidArgument, ierrors.ErrUnreadableDocument.Error(), err)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+//go:generate mockgen -destination=../mocks/mock_deletion_receipt_signer.go -package=mocks github.com/kimbasn/printly/internal/service DeletionReceiptSigner
+
+// DeletionReceiptSigner issues and checks the receipts proving that a document file was deleted.
+type DeletionReceiptSigner interface {
+	// Issue returns a signed receipt for the deletion of the document file
+	Issue(order *entity.Order, doc *entity.Document, backend StorageType, deletedAt time.Time) (*entity.DeletionReceipt, error)
+	// Verify reports whether the receipt was issued by this server and left unchanged
+	Verify(receipt *entity.DeletionReceipt) bool
+}
+
+// DeletionReceiptVersion versions the signed payload of the receipts.
+const DeletionReceiptVersion = "ply-dr1"
+
+// receiptPayload is the signed part of a receipt. The deletion time is signed
+// as unix seconds so that it does not depend on its time zone or encoding.
+type receiptPayload struct {
+	Version        string `json:"v"`
+	DocumentID     uint   `json:"doc"`
+	OrderCode      string `json:"code"`
+	FileNameSHA256 string `json:"name"`
+	ContentSHA256  string `json:"content"`
+	StorageBackend string `json:"backend"`
+	DeletedAt      int64  `json:"at"`
+}
+
+type deletionReceiptSigner struct {
+	secret []byte
+}
+
+// NewDeletionReceiptSigner creates a DeletionReceiptSigner using HMAC-SHA256 with the given secret.
+func NewDeletionReceiptSigner(secret []byte) DeletionReceiptSigner {
+	return &deletionReceiptSigner{secret: secret}
+}
+
+// Issue only keeps the hash of the file name, and truncates the deletion time to the second.
+func (s *deletionReceiptSigner) Issue(order *entity.Order, doc *entity.Document, backend StorageType, deletedAt time.Time) (*entity.DeletionReceipt, error) {
+	fileName := sha256.Sum256([]byte(doc.FileName))
+	receipt := &entity.DeletionReceipt{
+		Version:        DeletionReceiptVersion,
+		DocumentID:     doc.ID,
+		OrderCode:      order.Code,
+		FileNameSHA256: hex.EncodeToString(fileName[:]),
+		ContentSHA256:  doc.ContentSHA256,
+		StorageBackend: string(backend),
+		DeletedAt:      deletedAt.UTC().Truncate(time.Second),
+	}
+
+	signature, err := s.sign(receipt)
+	if err != nil {
+		return nil, err
+	}
+	receipt.Signature = signature
+	return receipt, nil
+}
+
+// Verify recomputes the signature of the receipt fields.
+func (s *deletionReceiptSigner) Verify(receipt *entity.DeletionReceipt) bool {
+	if receipt.Version != DeletionReceiptVersion {
+		return false
+	}
+	signature, err := s.sign(receipt)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(receipt.Signature), []byte(signature))
+}
+
+// sign returns the base64url HMAC-SHA256 of the receipt payload
+func (s *deletionReceiptSigner) sign(receipt *entity.DeletionReceipt) (string, error) {
+	payload, err := json.Marshal(receiptPayload{
+		Version:        receipt.Version,
+		DocumentID:     receipt.DocumentID,
+		OrderCode:      receipt.OrderCode,
+		FileNameSHA256: receipt.FileNameSHA256,
+		ContentSHA256:  receipt.ContentSHA256,
+		StorageBackend: receipt.StorageBackend,
+		DeletedAt:      receipt.DeletedAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeletionReceiptTestSuite struct {
+	suite.Suite
+	signer service.DeletionReceiptSigner
+	order  *entity.Order
+	doc    *entity.Document
+}
+
+func (s *DeletionReceiptTestSuite) SetupTest() {
+	s.signer = service.NewDeletionReceiptSigner([]byte("secret"))
+	s.order = &entity.Order{ID: 1, Code: "ABC123"}
+	s.doc = &entity.Document{ID: 10, OrderID: 1, FileName: "thesis.pdf", ContentSHA256: sha256Of("content")}
+}
+
+func TestDeletionReceipt(t *testing.T) {
+	suite.Run(t, new(DeletionReceiptTestSuite))
+}
+
+func sha256Of(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// receiptOf matches the deletion receipt of the given document.
+func receiptOf(documentID uint) gomock.Matcher {
+	return receiptMatcher(documentID)
+}
+
+type receiptMatcher uint
+
+func (m receiptMatcher) Matches(x any) bool {
+	receipt, ok := x.(*entity.DeletionReceipt)
+	return ok && receipt.DocumentID == uint(m) && receipt.Signature != ""
+}
+
+func (m receiptMatcher) String() string {
+	return fmt.Sprintf("is a signed deletion receipt of document %d", uint(m))
+}
+
+// ============================================================================
+// Issue / Verify Tests
+// ============================================================================
+
+func (s *DeletionReceiptTestSuite) TestIssue_HidesFileName() {
+	// Act
+	receipt, err := s.signer.Issue(s.order, s.doc, service.StorageTypeGCS, time.Now())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("ABC123", receipt.OrderCode)
+	s.Equal(sha256Of("thesis.pdf"), receipt.FileNameSHA256)
+	s.Equal(s.doc.ContentSHA256, receipt.ContentSHA256)
+	s.Equal("gcs", receipt.StorageBackend)
+	s.NotContains(receipt.FileNameSHA256, "thesis")
+}
+
+func (s *DeletionReceiptTestSuite) TestVerify_IgnoresTimeZone() {
+	// Arrange: receipts read back from the database or JSON may be in another location
+	receipt, err := s.signer.Issue(s.order, s.doc, service.StorageTypeLocal, time.Now())
+	s.Require().NoError(err)
+	receipt.DeletedAt = receipt.DeletedAt.In(time.FixedZone("WAT", 3600))
+
+	// Act & Assert
+	s.True(s.signer.Verify(receipt))
+}
+
+func (s *DeletionReceiptTestSuite) TestVerify_TamperedReceipt() {
+	receipt, err := s.signer.Issue(s.order, s.doc, service.StorageTypeLocal, time.Now())
+	s.Require().NoError(err)
+
+	tampered := *receipt
+	tampered.DeletedAt = receipt.DeletedAt.Add(-24 * time.Hour)
+	s.False(s.signer.Verify(&tampered))
+
+	tampered = *receipt
+	tampered.OrderCode = "ZZZ999"
+	s.False(s.signer.Verify(&tampered))
+}
+
+func (s *DeletionReceiptTestSuite) TestVerify_OtherSecret() {
+	// Arrange
+	receipt, err := service.NewDeletionReceiptSigner([]byte("other")).Issue(s.order, s.doc, service.StorageTypeLocal, time.Now())
+	s.Require().NoError(err)
+
+	// Act & Assert
+	s.False(s.signer.Verify(receipt))
+}
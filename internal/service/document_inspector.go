@@ -0,0 +1,172 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+)
+
+//go:generate mockgen -destination=../mocks/mock_document_inspector.go -package=mocks github.com/kimbasn/printly/internal/service DocumentInspector
+
+// DocumentInspector reads uploaded files to extract the information needed for pricing and printing.
+// Files are scanned for malware first. The type of a file is detected from its content and must
+// match the declared MIME type. An infected file is rejected with ErrMalwareDetected, together
+// with an info holding only its scan verdict.
+type DocumentInspector interface {
+	Inspect(file io.ReaderAt, size int64, mimeType string) (*DocumentInfo, error)
+}
+
+// DocumentInfo describes the printable content of a document.
+// PageCount is 0 when the format can not be paginated before printing (text, Word documents).
+type DocumentInfo struct {
+	MimeType   string // Detected from the content, to be stored in place of the declared type
+	PageCount  int
+	PageWidth  float64 // Width of the first page, in points (1/72 inch)
+	PageHeight float64 // Height of the first page, in points (1/72 inch)
+	Encrypted  bool
+	SHA256     string // Hex digest of the whole file
+	Scan       entity.ScanVerdict
+}
+
+type documentInspector struct {
+	scanner Scanner
+	logger  *zap.Logger
+}
+
+// NewDocumentInspector creates a new instance of DocumentInspector.
+func NewDocumentInspector(scanner Scanner, logger *zap.Logger) DocumentInspector {
+	return &documentInspector{scanner: scanner, logger: logger}
+}
+
+// Inspect scans the file before any parser reads it, hashes it, detects its type from its content
+// and rejects it when it is not the declared type, then dispatches on the detected type.
+// Images always print on a single page.
+func (i *documentInspector) Inspect(file io.ReaderAt, size int64, mimeType string) (*DocumentInfo, error) {
+	verdict, err := i.scanner.Scan(context.Background(), io.NewSectionReader(file, 0, size))
+	if err != nil {
+		i.logger.Error("Failed to scan uploaded file", zap.Error(err))
+		return nil, ierrors.NewWithCause(ierrors.Unavailable, ierrors.ErrScannerUnavailable.Error(), err)
+	}
+	if verdict.Infected() {
+		i.logger.Warn("Uploaded file is infected",
+			zap.String("signature", verdict.Signature),
+			zap.String("engine", verdict.Engine))
+		return &DocumentInfo{Scan: *verdict}, ierrors.New(ierrors.MalwareDetected,
+			fmt.Sprintf("%s: %s", ierrors.ErrMalwareDetected.Error(), verdict.Signature))
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, 0, size)); err != nil {
+		return nil, ierrors.NewWithCause(ierrors.InvalidArgument, ierrors.ErrUnreadableDocument.Error(), err)
+	}
+
+	detected, err := detectContentType(file, size)
+	if err != nil {
+		return nil, err
+	}
+	if detected != canonicalMimeType(mimeType) {
+		i.logger.Warn("Uploaded file content does not match its declared type",
+			zap.String("declared", mimeType),
+			zap.String("detected", detected))
+		return nil, ierrors.New(ierrors.InvalidArgument,
+			fmt.Sprintf("%s: %s declared, %s detected", ierrors.ErrContentMismatch.Error(), mimeType, detected))
+	}
+
+	var info *DocumentInfo
+	switch {
+	case detected == mimePDF:
+		info, err = i.inspectPDF(file, size)
+	case strings.HasPrefix(detected, "image/"):
+		info = &DocumentInfo{PageCount: 1}
+	default:
+		info = &DocumentInfo{}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info.MimeType = detected
+	info.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	info.Scan = *verdict
+	return info, nil
+}
+
+// inspectPDF counts the pages of a PDF and reads the dimensions of its first page.
+// Files protected by an owner password only can still be read and are reported as encrypted,
+// files that require a password to be opened are rejected.
+func (i *documentInspector) inspectPDF(file io.ReaderAt, size int64) (info *DocumentInfo, err error) {
+	// The parser panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Warn("PDF parser panicked", zap.Any("panic", r))
+			info, err = nil, ierrors.ErrUnreadableDocument
+		}
+	}()
+
+	reader, err := pdf.NewReader(pdf20Compat{file}, size)
+	if err != nil {
+		if errors.Is(err, pdf.ErrInvalidPassword) {
+			return nil, ierrors.ErrEncryptedDocument
+		}
+		i.logger.Warn("Failed to parse PDF", zap.Error(err))
+		return nil, ierrors.NewWithCause(ierrors.InvalidArgument, ierrors.ErrUnreadableDocument.Error(), err)
+	}
+
+	pageCount := reader.NumPage()
+	if pageCount <= 0 {
+		return nil, ierrors.ErrUnreadableDocument
+	}
+
+	info = &DocumentInfo{
+		PageCount: pageCount,
+		Encrypted: !reader.Trailer().Key("Encrypt").IsNull(),
+	}
+
+	// MediaBox is [llx lly urx ury], the page rotation swaps width and height
+	page := reader.Page(1).V
+	if box := inheritedPageAttribute(page, "MediaBox"); box.Len() == 4 {
+		info.PageWidth = math.Abs(box.Index(2).Float64() - box.Index(0).Float64())
+		info.PageHeight = math.Abs(box.Index(3).Float64() - box.Index(1).Float64())
+		if rotate := inheritedPageAttribute(page, "Rotate").Int64(); rotate%180 != 0 {
+			info.PageWidth, info.PageHeight = info.PageHeight, info.PageWidth
+		}
+	}
+
+	return info, nil
+}
+
+// inheritedPageAttribute looks a page attribute up on the page, then on its ancestors in the page tree.
+func inheritedPageAttribute(page pdf.Value, key string) pdf.Value {
+	for v := page; !v.IsNull(); v = v.Key("Parent") {
+		if attr := v.Key(key); !attr.IsNull() {
+			return attr
+		}
+	}
+	return pdf.Value{}
+}
+
+// pdf20Compat presents PDF 2.0 files with a 1.7 header.
+// The parser only accepts 1.x headers although the file structure it reads is unchanged in 2.0.
+type pdf20Compat struct {
+	io.ReaderAt
+}
+
+func (r pdf20Compat) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.ReaderAt.ReadAt(p, off)
+	if off == 0 && bytes.HasPrefix(p[:n], []byte("%PDF-2.0")) {
+		copy(p, "%PDF-1.7")
+	}
+	return n, err
+}
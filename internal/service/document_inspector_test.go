@@ -0,0 +1,325 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type DocumentInspectorTestSuite struct {
+	suite.Suite
+	inspector service.DocumentInspector
+}
+
+func (s *DocumentInspectorTestSuite) SetupTest() {
+	s.inspector = service.NewDocumentInspector(service.NewStubScanner(), zap.NewNop())
+}
+
+func TestDocumentInspector(t *testing.T) {
+	suite.Run(t, new(DocumentInspectorTestSuite))
+}
+
+// buildPDF writes a minimal PDF with the given number of A4 pages.
+// The MediaBox is set on the page tree root so that pages inherit it.
+func buildPDF(version string, pages int, rotate int) []byte {
+	var objects []string
+	kids := make([]string, pages)
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", i+3)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 595 842] /Rotate %d >>", strings.Join(kids, " "), pages, rotate),
+	)
+	for range pages {
+		objects = append(objects, "<< /Type /Page /Parent 2 0 R >>")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%%PDF-%s\n", version)
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// ============================================================================
+// Inspect Tests
+// ============================================================================
+
+func (s *DocumentInspectorTestSuite) TestInspect_PDF() {
+	// Arrange
+	file := buildPDF("1.4", 3, 0)
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(3, info.PageCount)
+	s.Equal(595.0, info.PageWidth)
+	s.Equal(842.0, info.PageHeight)
+	s.False(info.Encrypted)
+	s.Equal(sha256Of(string(file)), info.SHA256)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_RotatedPDF20() {
+	// Arrange
+	file := buildPDF("2.0", 1, 90)
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(1, info.PageCount)
+	s.Equal(842.0, info.PageWidth)
+	s.Equal(595.0, info.PageHeight)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_CorruptedPDF() {
+	// Arrange
+	file := []byte("%PDF-1.4\nthis is not a pdf")
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUnreadableDocument)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_TruncatedPDF() {
+	// Arrange: the upload stopped before the trailer
+	file := buildPDF("1.4", 3, 0)
+	file = file[:len(file)/2]
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUnreadableDocument)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_PNG() {
+	// Arrange
+	file := buildPNG(4, 3)
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "image/png")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("image/png", info.MimeType)
+	s.Equal(1, info.PageCount)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_JPEGDeclaredAsJPG() {
+	// Arrange
+	var buf bytes.Buffer
+	s.Require().NoError(jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 3)), nil))
+	file := buf.Bytes()
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "image/jpg")
+
+	// Assert: the detected type is stored
+	s.Require().NoError(err)
+	s.Equal("image/jpeg", info.MimeType)
+	s.Equal(1, info.PageCount)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_CorruptedPNG() {
+	// Arrange: a valid header followed by garbage
+	file := append(buildPNG(4, 3)[:40], "not an image"...)
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "image/png")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUnreadableDocument)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_ImageTooLarge() {
+	// Arrange: a header announcing 100000x100000 pixels
+	file := buildPNG(4, 3)
+	binary.BigEndian.PutUint32(file[16:], 100000)
+	binary.BigEndian.PutUint32(file[20:], 100000)
+	binary.BigEndian.PutUint32(file[29:], crc32.ChecksumIEEE(file[12:29]))
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "image/png")
+
+	// Assert
+	s.ErrorContains(err, ierrors.ErrImageTooLarge.Error())
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_Text() {
+	// Arrange
+	file := []byte("Chapitre 1\r\n\tIl était une fois…\n")
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "text/plain")
+
+	// Assert: text has no pages before printing
+	s.Require().NoError(err)
+	s.Equal("text/plain", info.MimeType)
+	s.Equal(0, info.PageCount)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_DOCX() {
+	// Arrange
+	file := buildZip(map[string]string{
+		"[Content_Types].xml": `<Types><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"word/document.xml":   "<w:document/>",
+	})
+	docx := "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), docx)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(docx, info.MimeType)
+	s.Equal(0, info.PageCount)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_ZipDeclaredAsDOCX() {
+	// Arrange
+	file := buildZip(map[string]string{"payload.exe": "MZ"})
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+
+	// Assert
+	s.ErrorContains(err, ierrors.ErrContentMismatch.Error())
+	s.ErrorContains(err, "application/zip detected")
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_DOC() {
+	// Arrange: an OLE compound file with a WordDocument stream
+	file := append([]byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, make([]byte, 70000)...)
+	file = append(file, "W\x00o\x00r\x00d\x00D\x00o\x00c\x00u\x00m\x00e\x00n\x00t\x00"...)
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/msword")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("application/msword", info.MimeType)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_RenamedExecutable() {
+	// Arrange
+	file := append([]byte("MZ\x90\x00\x03\x00\x00\x00"), make([]byte, 64)...)
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.ErrorContains(err, ierrors.ErrContentMismatch.Error())
+	s.ErrorContains(err, "application/octet-stream detected")
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_PDFDeclaredAsImage() {
+	// Arrange
+	file := buildPDF("1.4", 1, 0)
+
+	// Act
+	_, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "image/png")
+
+	// Assert
+	s.ErrorContains(err, ierrors.ErrContentMismatch.Error())
+}
+
+// ============================================================================
+// Malware Scan Tests
+// ============================================================================
+
+func (s *DocumentInspectorTestSuite) TestInspect_RecordsCleanVerdict() {
+	// Arrange
+	file := buildPDF("1.4", 1, 0)
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanClean, info.Scan.Status)
+	s.Equal("stub", info.Scan.Engine)
+	s.NotNil(info.Scan.Time)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_InfectedFile() {
+	// Arrange: the test file passes the content checks as text
+	file := eicarFile()
+
+	// Act
+	info, err := s.inspector.Inspect(bytes.NewReader(file), int64(len(file)), "text/plain")
+
+	// Assert: the verdict comes with the error
+	s.ErrorIs(err, ierrors.ErrMalwareDetected)
+	s.ErrorContains(err, "Eicar-Test-Signature")
+	s.Require().NotNil(info)
+	s.True(info.Scan.Infected())
+	s.Equal("Eicar-Test-Signature", info.Scan.Signature)
+	s.Empty(info.MimeType)
+}
+
+func (s *DocumentInspectorTestSuite) TestInspect_ScannerFailure() {
+	// Arrange
+	ctrl := gomock.NewController(s.T())
+	scanner := mocks.NewMockScanner(ctrl)
+	scanner.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	inspector := service.NewDocumentInspector(scanner, zap.NewNop())
+	file := buildPDF("1.4", 1, 0)
+
+	// Act
+	info, err := inspector.Inspect(bytes.NewReader(file), int64(len(file)), "application/pdf")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrScannerUnavailable)
+	s.Nil(info)
+}
+
+// buildPNG encodes a blank PNG of the given size
+func buildPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+// buildZip writes a zip archive of the given files
+func buildZip(files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, _ := w.Create(name)
+		_, _ = f.Write([]byte(content))
+	}
+	_ = w.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_document_purger.go -package=mocks github.com/kimbasn/printly/internal/service DocumentPurger
+
+// DocumentPurger removes the files of an order's documents from storage
+// and records the deletion on each document.
+type DocumentPurger interface {
+	// Purge deletes the files of the documents not purged yet. It returns the number of
+	// documents purged, and an error joining the failures of the other documents.
+	Purge(order *entity.Order) (int, error)
+}
+
+type documentPurger struct {
+	orderRepo      repository.OrderRepository
+	storageService StorageService
+	receiptSigner  DeletionReceiptSigner
+	logger         *zap.Logger
+}
+
+// NewDocumentPurger creates a new instance of DocumentPurger.
+func NewDocumentPurger(orderRepo repository.OrderRepository, storageService StorageService, receiptSigner DeletionReceiptSigner, logger *zap.Logger) DocumentPurger {
+	return &documentPurger{
+		orderRepo:      orderRepo,
+		storageService: storageService,
+		receiptSigner:  receiptSigner,
+		logger:         logger,
+	}
+}
+
+// Purge treats files already missing from storage as deleted, so a failed purge can be retried.
+// A signed deletion receipt is saved with each purged document.
+func (p *documentPurger) Purge(order *entity.Order) (int, error) {
+	var (
+		purged int
+		errs   []error
+	)
+
+	for i := range order.Documents {
+		doc := &order.Documents[i]
+		if doc.StorageDeletedAt != nil {
+			continue
+		}
+
+		if doc.StoragePath != "" {
+			if err := p.storageService.DeleteFile(doc.StoragePath); err != nil && !errors.Is(err, ErrFileNotFound) {
+				errs = append(errs, fmt.Errorf("document %d: %w", doc.ID, err))
+				continue
+			}
+		}
+
+		receipt, err := p.receiptSigner.Issue(order, doc, p.storageService.Type(), time.Now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("document %d: %w", doc.ID, err))
+			continue
+		}
+		if err := p.orderRepo.MarkDocumentDeleted(receipt); err != nil {
+			errs = append(errs, fmt.Errorf("document %d: %w", doc.ID, err))
+			continue
+		}
+		doc.StorageDeletedAt = &receipt.DeletedAt
+		purged++
+	}
+
+	if len(errs) > 0 {
+		p.logger.Error("Failed to purge documents",
+			zap.Uint("orderID", order.ID),
+			zap.Int("purged", purged),
+			zap.Errors("errors", errs))
+	}
+	return purged, errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_document_retention.go -package=mocks github.com/kimbasn/printly/internal/service DocumentRetention
+
+// DocumentRetention deletes uploaded files as soon as they are no longer needed:
+// once printed, once their order is over, and in any case after a maximum age.
+type DocumentRetention interface {
+	PurgeExpiredDocuments(ctx context.Context) (*dto.DocumentRetentionReport, error)
+}
+
+// retentionBatchSize bounds the number of documents purged in one run.
+const retentionBatchSize = 500
+
+type documentRetention struct {
+	orderRepo repository.OrderRepository
+	purger    DocumentPurger
+	maxAge    time.Duration
+	logger    *zap.Logger
+}
+
+// NewDocumentRetention creates a new instance of DocumentRetention.
+func NewDocumentRetention(orderRepo repository.OrderRepository, purger DocumentPurger, maxAge time.Duration, logger *zap.Logger) DocumentRetention {
+	return &documentRetention{
+		orderRepo: orderRepo,
+		purger:    purger,
+		maxAge:    maxAge,
+		logger:    logger,
+	}
+}
+
+// PurgeExpiredDocuments purges the documents due for deletion, order by order.
+// Documents that fail to be deleted are left unmarked and retried on the next run.
+func (r *documentRetention) PurgeExpiredDocuments(ctx context.Context) (*dto.DocumentRetentionReport, error) {
+	report := &dto.DocumentRetentionReport{PurgedOrders: []uint{}}
+
+	documents, err := r.orderRepo.FindDocumentsToPurge(entity.TerminalStatuses, time.Now().Add(-r.maxAge), retentionBatchSize)
+	if err != nil {
+		return report, err
+	}
+
+	// Group the documents by order, keeping the order they were found in
+	var orders []*entity.Order
+	byID := make(map[uint]*entity.Order)
+	for _, doc := range documents {
+		order, ok := byID[doc.OrderID]
+		if !ok {
+			order = &entity.Order{ID: doc.OrderID, Code: doc.Order.Code}
+			byID[doc.OrderID] = order
+			orders = append(orders, order)
+		}
+		order.Documents = append(order.Documents, doc)
+	}
+
+	for _, order := range orders {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		purged, err := r.purger.Purge(order)
+		report.DeletedDocuments += purged
+		if purged > 0 {
+			report.PurgedOrders = append(report.PurgedOrders, order.ID)
+		}
+		if err != nil {
+			report.Failures = append(report.Failures, fmt.Sprintf("order %d: %s", order.ID, err.Error()))
+		}
+	}
+
+	r.logger.Info("Expired documents purged",
+		zap.Int("orders", len(report.PurgedOrders)),
+		zap.Int("documentsDeleted", report.DeletedDocuments),
+		zap.Int("failures", len(report.Failures)))
+	return report, nil
+}
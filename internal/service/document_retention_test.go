@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type DocumentRetentionTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	orderRepo      *mocks.MockOrderRepository
+	storageService *mocks.MockStorageService
+	retention      service.DocumentRetention
+}
+
+func (s *DocumentRetentionTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.storageService = mocks.NewMockStorageService(s.ctrl)
+	s.storageService.EXPECT().Type().Return(service.StorageTypeLocal).AnyTimes()
+	logger := zap.NewNop()
+
+	s.retention = service.NewDocumentRetention(
+		s.orderRepo,
+		service.NewDocumentPurger(s.orderRepo, s.storageService, service.NewDeletionReceiptSigner([]byte("secret")), logger),
+		24*time.Hour,
+		logger,
+	)
+}
+
+func (s *DocumentRetentionTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestDocumentRetention(t *testing.T) {
+	suite.Run(t, new(DocumentRetentionTestSuite))
+}
+
+// ============================================================================
+// PurgeExpiredDocuments Tests
+// ============================================================================
+
+func (s *DocumentRetentionTestSuite) TestPurgeExpiredDocuments_GroupsByOrder() {
+	// Arrange
+	documents := []entity.Document{
+		{ID: 10, OrderID: 1, StoragePath: "a.pdf"},
+		{ID: 20, OrderID: 2, StoragePath: "c.pdf"},
+		{ID: 11, OrderID: 1, StoragePath: "b.pdf"},
+	}
+	s.orderRepo.EXPECT().
+		FindDocumentsToPurge(entity.TerminalStatuses, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(terminal []entity.OrderStatus, before time.Time, limit int) ([]entity.Document, error) {
+			s.WithinDuration(time.Now().Add(-24*time.Hour), before, time.Minute)
+			return documents, nil
+		})
+	for _, path := range []string{"a.pdf", "b.pdf", "c.pdf"} {
+		s.storageService.EXPECT().DeleteFile(path).Return(nil)
+	}
+	for _, id := range []uint{10, 11, 20} {
+		s.orderRepo.EXPECT().MarkDocumentDeleted(receiptOf(id)).Return(nil)
+	}
+
+	// Act
+	report, err := s.retention.PurgeExpiredDocuments(context.Background())
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]uint{1, 2}, report.PurgedOrders)
+	s.Equal(3, report.DeletedDocuments)
+	s.Empty(report.Failures)
+}
+
+func (s *DocumentRetentionTestSuite) TestPurgeExpiredDocuments_FailureLeftForRetry() {
+	// Arrange
+	documents := []entity.Document{
+		{ID: 10, OrderID: 1, StoragePath: "a.pdf"},
+		{ID: 11, OrderID: 1, StoragePath: "b.pdf"},
+	}
+	s.orderRepo.EXPECT().FindDocumentsToPurge(gomock.Any(), gomock.Any(), gomock.Any()).Return(documents, nil)
+	s.storageService.EXPECT().DeleteFile("a.pdf").Return(errors.New("bucket unavailable"))
+	s.storageService.EXPECT().DeleteFile("b.pdf").Return(nil)
+	// The failed document is not marked, so the next run picks it up again
+	s.orderRepo.EXPECT().MarkDocumentDeleted(receiptOf(uint(11))).Return(nil)
+
+	// Act
+	report, err := s.retention.PurgeExpiredDocuments(context.Background())
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]uint{1}, report.PurgedOrders)
+	s.Equal(1, report.DeletedDocuments)
+	s.Require().Len(report.Failures, 1)
+	s.Contains(report.Failures[0], "bucket unavailable")
+}
+
+func (s *DocumentRetentionTestSuite) TestPurgeExpiredDocuments_RepositoryError() {
+	// Arrange
+	s.orderRepo.EXPECT().FindDocumentsToPurge(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+	// Act
+	_, err := s.retention.PurgeExpiredDocuments(context.Background())
+
+	// Assert
+	s.Error(err)
+}
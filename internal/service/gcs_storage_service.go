@@ -2,14 +2,19 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/kimbasn/printly/internal/config"
+	ierrors "github.com/kimbasn/printly/internal/errors"
 	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
@@ -70,7 +75,11 @@ func (s *gcsStorageService) UploadFile(file multipart.File, filename, userUID st
 
 func (s *gcsStorageService) DeleteFile(storagePath string) error {
 	ctx := context.Background()
-	return s.client.Bucket(s.bucketName).Object(storagePath).Delete(ctx)
+	err := s.client.Bucket(s.bucketName).Object(storagePath).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
+	}
+	return err
 }
 
 func (s *gcsStorageService) GetFileURL(storagePath string) (string, error) {
@@ -86,6 +95,149 @@ func (s *gcsStorageService) GetSignedURL(storagePath string, expiration time.Dur
 	return s.client.Bucket(s.bucketName).SignedURL(storagePath, opts)
 }
 
+// NewUploadPath reserves a unique object name under the orders of the user
+func (s *gcsStorageService) NewUploadPath(filename, userUID string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+	return fmt.Sprintf("orders/%s/%s/%x_%s", userUID, time.Now().Format("2006-01-02"), suffix, sanitizeFileName(filename)), nil
+}
+
+// GetSignedUploadURL returns a V4 signed URL accepting a PUT of the object with the given content type.
+// The URL is signed with a generation precondition of 0, so the PUT only succeeds while the object
+// does not exist and a confirmed file can not be replaced.
+func (s *gcsStorageService) GetSignedUploadURL(storagePath, contentType string, expiration time.Duration) (*SignedUpload, error) {
+	opts := &storage.SignedURLOptions{
+		Scheme:      storage.SigningSchemeV4,
+		Method:      "PUT",
+		ContentType: contentType,
+		Headers:     []string{"x-goog-if-generation-match:0"},
+		Expires:     time.Now().Add(expiration),
+	}
+
+	url, err := s.client.Bucket(s.bucketName).SignedURL(storagePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedUpload{
+		URL: url,
+		Headers: map[string]string{
+			"Content-Type":               contentType,
+			"x-goog-if-generation-match": "0",
+		},
+	}, nil
+}
+
+func (s *gcsStorageService) StatFile(storagePath string) (*ObjectInfo, error) {
+	attrs, err := s.client.Bucket(s.bucketName).Object(storagePath).Attrs(context.Background())
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+	return &ObjectInfo{Size: attrs.Size, ContentType: attrs.ContentType}, nil
+}
+
+func (s *gcsStorageService) OpenFile(storagePath string) (io.ReadCloser, error) {
+	reader, err := s.client.Bucket(s.bucketName).Object(storagePath).NewReader(context.Background())
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	return reader, nil
+}
+
+// UploadFromReader streams a file to a new object. The upload is aborted if the reader fails,
+// so no partial object is left behind.
 func (s *gcsStorageService) UploadFromReader(reader io.Reader, filename, userUID string) (string, error) {
-	return "", nil
+	storagePath, err := s.NewUploadPath(filename, userUID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.WriteFile(storagePath, reader); err != nil {
+		return "", err
+	}
+
+	return storagePath, nil
+}
+
+// WriteFile streams the reader to the object at the given path. The upload is aborted if the
+// reader fails, so the previous object, if any, is kept.
+func (s *gcsStorageService) WriteFile(storagePath string, reader io.Reader) error {
+	// Cancelling the context before Close discards the object
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wc := s.client.Bucket(s.bucketName).Object(storagePath).NewWriter(ctx)
+
+	if _, err := io.Copy(wc, reader); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	return nil
+}
+
+// maxComposeSources is the number of objects GCS composes in one request
+const maxComposeSources = 32
+
+// ComposeFiles joins the source objects in the bucket, without downloading them. Past the limit
+// of a compose request, the object composed so far is the first source of the next request.
+func (s *gcsStorageService) ComposeFiles(storagePath string, sources []string) error {
+	ctx := context.Background()
+	bucket := s.client.Bucket(s.bucketName)
+	dst := bucket.Object(storagePath)
+
+	for start := 0; start < len(sources); {
+		var srcs []*storage.ObjectHandle
+		if start > 0 {
+			srcs = append(srcs, dst)
+		}
+		end := min(start+maxComposeSources-len(srcs), len(sources))
+		for _, source := range sources[start:end] {
+			srcs = append(srcs, bucket.Object(source))
+		}
+
+		if _, err := dst.ComposerFrom(srcs...).Run(ctx); err != nil {
+			return fmt.Errorf("failed to compose object: %w", err)
+		}
+		start = end
+	}
+
+	return nil
+}
+
+// MoveFile copies the source object with a precondition that the destination does not exist,
+// then deletes the source. GCS has no atomic move, a source left behind is only logged.
+func (s *gcsStorageService) MoveFile(source, storagePath string) error {
+	ctx := context.Background()
+	bucket := s.client.Bucket(s.bucketName)
+	src := bucket.Object(source)
+	dst := bucket.Object(storagePath).If(storage.Conditions{DoesNotExist: true})
+
+	if _, err := dst.CopierFrom(src).Run(ctx); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			return ierrors.ErrUploadAlreadyReceived
+		}
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("%w: %s", ErrFileNotFound, source)
+		}
+		return fmt.Errorf("failed to copy object: %w", err)
+	}
+	if err := src.Delete(ctx); err != nil {
+		s.logger.Error("Failed to delete moved object", zap.String("storagePath", source), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *gcsStorageService) Type() StorageType {
+	return StorageTypeGCS
 }
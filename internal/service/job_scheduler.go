@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_job_scheduler.go -package=mocks github.com/kimbasn/printly/internal/service JobScheduler
+
+// JobScheduler runs recurring background jobs inside the server process.
+// A lease stored in the database makes sure only one replica runs a job at a time,
+// and every run is recorded with its duration and error.
+type JobScheduler interface {
+	// Register adds a job. Jobs must be registered before Start.
+	Register(job Job) error
+	// Start begins running the registered jobs on their schedule
+	Start()
+	// Stop cancels the running jobs and waits for them to return, or for ctx to be done
+	Stop(ctx context.Context) error
+	// Jobs returns the status of every registered job
+	Jobs() ([]dto.JobStatus, error)
+	// Trigger starts a job immediately on behalf of an admin
+	Trigger(name string, actor entity.Actor) (*entity.JobRun, error)
+}
+
+// Job is a recurring background task.
+type Job struct {
+	Name        string
+	Description string
+	// Standard 5-field cron expression or descriptor such as "@hourly", in the server time zone
+	Schedule string
+	// Maximum run duration, the job context is cancelled afterwards. Defaults to DefaultJobTimeout.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+const (
+	DefaultJobTimeout = 10 * time.Minute
+	// leaseMargin keeps the lease a little longer than the job timeout so that
+	// a slow job is not started again by another replica before it returns
+	leaseMargin = time.Minute
+)
+
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+	next     time.Time
+	running  bool
+}
+
+type jobScheduler struct {
+	jobRepo repository.JobRepository
+	holder  string
+	logger  *zap.Logger
+
+	mu      sync.Mutex
+	jobs    map[string]*scheduledJob
+	names   []string // registration order
+	started bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobScheduler creates a new instance of JobScheduler. Each instance identifies
+// itself in the leases with its host name, process ID and a random suffix.
+func NewJobScheduler(jobRepo repository.JobRepository, logger *zap.Logger) JobScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jobScheduler{
+		jobRepo: jobRepo,
+		holder:  schedulerHolderID(),
+		logger:  logger,
+		jobs:    make(map[string]*scheduledJob),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Register parses the job schedule and adds the job to the scheduler.
+func (s *jobScheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("job name and run function are required")
+	}
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q for job %s: %w", job.Schedule, job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %s registered after the scheduler started", job.Name)
+	}
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	s.jobs[job.Name] = &scheduledJob{Job: job, schedule: schedule, next: schedule.Next(time.Now())}
+	s.names = append(s.names, job.Name)
+	return nil
+}
+
+// Start runs the scheduling loop in the background. Calling it more than once has no effect.
+func (s *jobScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	s.wg.Add(1)
+	go s.loop()
+	s.logger.Info("Job scheduler started", zap.String("holder", s.holder), zap.Strings("jobs", s.names))
+}
+
+// Stop cancels the context of the running jobs and waits for them to return.
+// The context is cancelled under the lock, so that no job is launched once Stop waits.
+func (s *jobScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("job scheduler did not stop in time: %w", ctx.Err())
+	}
+}
+
+// Jobs lists the registered jobs with their next and last run.
+func (s *jobScheduler) Jobs() ([]dto.JobStatus, error) {
+	s.mu.Lock()
+	statuses := make([]dto.JobStatus, len(s.names))
+	for i, name := range s.names {
+		job := s.jobs[name]
+		statuses[i] = dto.JobStatus{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+			NextRun:     job.next,
+			Running:     job.running,
+		}
+	}
+	s.mu.Unlock()
+
+	for i := range statuses {
+		run, err := s.jobRepo.FindLastRun(statuses[i].Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to fetch last run of job %s: %w", statuses[i].Name, err)
+		}
+		statuses[i].LastRun = run
+	}
+	return statuses, nil
+}
+
+// Trigger starts a job now, unless it is already running on any replica.
+func (s *jobScheduler) Trigger(name string, actor entity.Actor) (*entity.JobRun, error) {
+	s.mu.Lock()
+	job, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ierrors.ErrJobNotFound
+	}
+
+	s.logger.Info("Job triggered manually", zap.String("job", name), zap.String("actor", actor.UID))
+	return s.launch(job, entity.TriggerManual, actor.UID)
+}
+
+// loop sleeps until the next job is due and launches every due job.
+func (s *jobScheduler) loop() {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, job := range s.jobs {
+			if next.IsZero() || job.next.Before(next) {
+				next = job.next
+			}
+		}
+		s.mu.Unlock()
+
+		if next.IsZero() {
+			<-s.ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		var due []*scheduledJob
+		s.mu.Lock()
+		for _, job := range s.jobs {
+			if !job.next.After(now) {
+				job.next = job.schedule.Next(now)
+				due = append(due, job)
+			}
+		}
+		s.mu.Unlock()
+
+		for _, job := range due {
+			_, err := s.launch(job, entity.TriggerSchedule, "")
+			if err != nil && !errors.Is(err, ierrors.ErrJobAlreadyRunning) && !errors.Is(err, ierrors.ErrSchedulerStopped) {
+				s.logger.Error("Failed to start scheduled job", zap.String("job", job.Name), zap.Error(err))
+			}
+		}
+	}
+}
+
+// launch takes the job lease, records the run and executes the job in the background.
+// Jobs are no longer launched once the scheduler is stopped.
+func (s *jobScheduler) launch(job *scheduledJob, trigger entity.JobTrigger, triggeredBy string) (*entity.JobRun, error) {
+	s.mu.Lock()
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return nil, ierrors.ErrSchedulerStopped
+	}
+	if job.running {
+		s.mu.Unlock()
+		return nil, ierrors.ErrJobAlreadyRunning
+	}
+	job.running = true
+	// Counted before the lock is released, so that Stop waits for the job
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	run, err := s.begin(job, trigger, triggeredBy)
+	if err != nil {
+		s.mu.Lock()
+		job.running = false
+		s.mu.Unlock()
+		s.wg.Done()
+		return nil, err
+	}
+
+	go s.execute(job, run)
+	return run, nil
+}
+
+// begin acquires the lease of the job and saves its run record
+func (s *jobScheduler) begin(job *scheduledJob, trigger entity.JobTrigger, triggeredBy string) (*entity.JobRun, error) {
+	acquired, err := s.jobRepo.AcquireLease(job.Name, s.holder, job.Timeout+leaseMargin)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		s.logger.Debug("Job is running on another replica", zap.String("job", job.Name))
+		return nil, ierrors.ErrJobAlreadyRunning
+	}
+
+	run := &entity.JobRun{
+		JobName:     job.Name,
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		Holder:      s.holder,
+		Status:      entity.JobRunning,
+		StartedAt:   time.Now(),
+	}
+	if err := s.jobRepo.SaveRun(run); err != nil {
+		s.releaseLease(job.Name)
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute runs the job with its timeout and records the outcome.
+func (s *jobScheduler) execute(job *scheduledJob, run *entity.JobRun) {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	err := runJob(ctx, job.Run)
+	cancel()
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = entity.JobSucceeded
+	if err != nil {
+		run.Status = entity.JobFailed
+		run.Error = err.Error()
+	}
+
+	if err := s.jobRepo.UpdateRun(run); err != nil {
+		s.logger.Error("Failed to record job run", zap.String("job", job.Name), zap.Uint("runID", run.ID), zap.Error(err))
+	}
+	s.releaseLease(job.Name)
+
+	s.mu.Lock()
+	job.running = false
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Job failed", zap.String("job", job.Name), zap.Int64("durationMs", run.DurationMs), zap.Error(err))
+		return
+	}
+	s.logger.Info("Job succeeded", zap.String("job", job.Name), zap.Int64("durationMs", run.DurationMs))
+}
+
+func (s *jobScheduler) releaseLease(name string) {
+	if err := s.jobRepo.ReleaseLease(name, s.holder); err != nil {
+		s.logger.Error("Failed to release job lease", zap.String("job", name), zap.Error(err))
+	}
+}
+
+// runJob calls the job function, turning a panic into an error
+func runJob(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// schedulerHolderID identifies this process in the job leases
+func schedulerHolderID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
@@ -0,0 +1,254 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type JobSchedulerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	jobRepo   *mocks.MockJobRepository
+	scheduler service.JobScheduler
+	admin     entity.Actor
+}
+
+func (s *JobSchedulerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.jobRepo = mocks.NewMockJobRepository(s.ctrl)
+	s.scheduler = service.NewJobScheduler(s.jobRepo, zap.NewNop())
+	s.admin = entity.Actor{UID: "admin-1", Role: entity.RoleAdmin}
+}
+
+func (s *JobSchedulerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestJobScheduler(t *testing.T) {
+	suite.Run(t, new(JobSchedulerTestSuite))
+}
+
+// stop waits for the jobs started by the test to return
+func (s *JobSchedulerTestSuite) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Require().NoError(s.scheduler.Stop(ctx))
+}
+
+// expectRun expects one run of the job and returns the recorded outcome once the scheduler is stopped
+func (s *JobSchedulerTestSuite) expectRun(name string) *entity.JobRun {
+	recorded := &entity.JobRun{}
+	s.jobRepo.EXPECT().AcquireLease(name, gomock.Any(), gomock.Any()).Return(true, nil)
+	s.jobRepo.EXPECT().SaveRun(gomock.Any()).DoAndReturn(func(run *entity.JobRun) error {
+		run.ID = 1
+		return nil
+	})
+	s.jobRepo.EXPECT().UpdateRun(gomock.Any()).DoAndReturn(func(run *entity.JobRun) error {
+		*recorded = *run
+		return nil
+	})
+	s.jobRepo.EXPECT().ReleaseLease(name, gomock.Any()).Return(nil)
+	return recorded
+}
+
+// ============================================================================
+// Register Tests
+// ============================================================================
+
+func (s *JobSchedulerTestSuite) TestRegister_InvalidSchedule() {
+	// Act
+	err := s.scheduler.Register(service.Job{Name: "broken", Schedule: "every minute", Run: func(context.Context) error { return nil }})
+
+	// Assert
+	s.ErrorContains(err, "invalid schedule")
+}
+
+func (s *JobSchedulerTestSuite) TestRegister_Duplicate() {
+	// Arrange
+	job := service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error { return nil }}
+	s.Require().NoError(s.scheduler.Register(job))
+
+	// Act
+	err := s.scheduler.Register(job)
+
+	// Assert
+	s.ErrorContains(err, "already registered")
+}
+
+// ============================================================================
+// Trigger Tests
+// ============================================================================
+
+func (s *JobSchedulerTestSuite) TestTrigger_RecordsSuccess() {
+	// Arrange
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error { return nil }}))
+	recorded := s.expectRun("cleanup")
+
+	// Act
+	run, err := s.scheduler.Trigger("cleanup", s.admin)
+	s.stop()
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.TriggerManual, run.Trigger)
+	s.Equal("admin-1", run.TriggeredBy)
+	s.Equal(entity.JobSucceeded, recorded.Status)
+	s.NotNil(recorded.FinishedAt)
+	s.Empty(recorded.Error)
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_RecordsError() {
+	// Arrange
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error {
+		return errors.New("storage unavailable")
+	}}))
+	recorded := s.expectRun("cleanup")
+
+	// Act
+	_, err := s.scheduler.Trigger("cleanup", s.admin)
+	s.stop()
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.JobFailed, recorded.Status)
+	s.Equal("storage unavailable", recorded.Error)
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_RecordsPanic() {
+	// Arrange
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error {
+		panic("boom")
+	}}))
+	recorded := s.expectRun("cleanup")
+
+	// Act
+	_, err := s.scheduler.Trigger("cleanup", s.admin)
+	s.stop()
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.JobFailed, recorded.Status)
+	s.Contains(recorded.Error, "boom")
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_LeaseHeldByAnotherReplica() {
+	// Arrange
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error { return nil }}))
+	s.jobRepo.EXPECT().AcquireLease("cleanup", gomock.Any(), gomock.Any()).Return(false, nil)
+
+	// Act
+	_, err := s.scheduler.Trigger("cleanup", s.admin)
+
+	// Assert
+	s.Equal(ierrors.ErrJobAlreadyRunning, err)
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_UnknownJob() {
+	// Act
+	_, err := s.scheduler.Trigger("missing", s.admin)
+
+	// Assert
+	s.Equal(ierrors.ErrJobNotFound, err)
+}
+
+// ============================================================================
+// Stop Tests
+// ============================================================================
+
+func (s *JobSchedulerTestSuite) TestStop_CancelsRunningJobs() {
+	// Arrange
+	started := make(chan struct{})
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "slow", Schedule: "@hourly", Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}}))
+	recorded := s.expectRun("slow")
+	s.scheduler.Start()
+
+	_, err := s.scheduler.Trigger("slow", s.admin)
+	s.Require().NoError(err)
+	<-started
+
+	// Act
+	s.stop()
+
+	// Assert
+	s.Equal(entity.JobFailed, recorded.Status)
+	s.Equal(context.Canceled.Error(), recorded.Error)
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_AfterStopRejected() {
+	// Arrange
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error { return nil }}))
+	s.scheduler.Start()
+	s.stop()
+	// No lease nor run expected
+
+	// Act
+	_, err := s.scheduler.Trigger("cleanup", s.admin)
+
+	// Assert
+	s.Equal(ierrors.ErrSchedulerStopped, err)
+}
+
+func (s *JobSchedulerTestSuite) TestTrigger_DuringStop() {
+	// Arrange: triggers race with Stop, each either runs to completion or is rejected
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "cleanup", Schedule: "@hourly", Run: func(context.Context) error { return nil }}))
+	s.jobRepo.EXPECT().AcquireLease("cleanup", gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	s.scheduler.Start()
+
+	triggered := make(chan struct{})
+	go func() {
+		defer close(triggered)
+		for {
+			if _, err := s.scheduler.Trigger("cleanup", s.admin); errors.Is(err, ierrors.ErrSchedulerStopped) {
+				return
+			}
+		}
+	}()
+
+	// Act
+	s.stop()
+
+	// Assert: triggers are rejected once stopped
+	<-triggered
+}
+
+// ============================================================================
+// Jobs Tests
+// ============================================================================
+
+func (s *JobSchedulerTestSuite) TestJobs() {
+	// Arrange
+	noop := func(context.Context) error { return nil }
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "first", Schedule: "@hourly", Run: noop}))
+	s.Require().NoError(s.scheduler.Register(service.Job{Name: "second", Schedule: "0 3 * * *", Run: noop}))
+
+	lastRun := &entity.JobRun{ID: 4, JobName: "first", Status: entity.JobSucceeded}
+	s.jobRepo.EXPECT().FindLastRun("first").Return(lastRun, nil)
+	s.jobRepo.EXPECT().FindLastRun("second").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	jobs, err := s.scheduler.Jobs()
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(jobs, 2)
+	s.Equal("first", jobs[0].Name)
+	s.Equal(lastRun, jobs[0].LastRun)
+	s.True(jobs[0].NextRun.After(time.Now()))
+	s.Equal("second", jobs[1].Name)
+	s.Nil(jobs[1].LastRun)
+}
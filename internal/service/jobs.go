@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+// JobRunRetention is how long job run records are kept.
+const JobRunRetention = 30 * 24 * time.Hour
+
+// NewJobRunCleanupJob deletes the job run records older than the retention, every night.
+func NewJobRunCleanupJob(jobRepo repository.JobRepository, retention time.Duration, logger *zap.Logger) Job {
+	return Job{
+		Name:        "job-runs-cleanup",
+		Description: "Deletes the records of job runs older than the retention period",
+		Schedule:    "0 3 * * *",
+		Run: func(ctx context.Context) error {
+			deleted, err := jobRepo.DeleteRunsBefore(time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			logger.Info("Old job runs deleted", zap.Int64("count", deleted))
+			return nil
+		},
+	}
+}
+
+// NewOrderTimeoutJob cancels the unpaid orders past their TTL and deletes their files, every 5 minutes.
+func NewOrderTimeoutJob(expirer OrderExpirer) Job {
+	return Job{
+		Name:        "order-timeout",
+		Description: "Cancels orders left too long awaiting their documents or payment and deletes their files",
+		Schedule:    "*/5 * * * *",
+		Run: func(ctx context.Context) error {
+			report, err := expirer.ExpireStaleOrders(ctx)
+			if err != nil {
+				return err
+			}
+			if len(report.Failures) > 0 {
+				return fmt.Errorf("%d orders could not be expired or purged: %s", len(report.Failures), strings.Join(report.Failures, "; "))
+			}
+			return nil
+		},
+	}
+}
+
+// NewDocumentRetentionJob deletes the files of printed documents, finished orders and old orders, every 15 minutes.
+func NewDocumentRetentionJob(retention DocumentRetention) Job {
+	return Job{
+		Name:        "document-retention",
+		Description: "Deletes uploaded files once printed, once their order is over, or past the maximum age",
+		Schedule:    "*/15 * * * *",
+		Run: func(ctx context.Context) error {
+			report, err := retention.PurgeExpiredDocuments(ctx)
+			if err != nil {
+				return err
+			}
+			if len(report.Failures) > 0 {
+				return fmt.Errorf("%d orders could not be purged: %s", len(report.Failures), strings.Join(report.Failures, "; "))
+			}
+			return nil
+		},
+	}
+}
+
+// NewNotificationDeliveryJob sends the queued notifications that are due, every minute.
+func NewNotificationDeliveryJob(notifier Notifier) Job {
+	return Job{
+		Name:        "notification-delivery",
+		Description: "Sends the queued emails and SMS, retrying failed attempts with a backoff",
+		Schedule:    "* * * * *",
+		Run: func(ctx context.Context) error {
+			report, err := notifier.DeliverDue(ctx)
+			if err != nil {
+				return err
+			}
+			if len(report.Failures) > 0 {
+				return fmt.Errorf("%d notifications could not be delivered: %s", len(report.Failures), strings.Join(report.Failures, "; "))
+			}
+			return nil
+		},
+	}
+}
+
+// NewResumableUploadCleanupJob deletes the expired resumable uploads and their parts, every 15 minutes.
+func NewResumableUploadCleanupJob(uploads ResumableUploadService) Job {
+	return Job{
+		Name:        "resumable-upload-cleanup",
+		Description: "Deletes the expired resumable uploads and the parts of those never completed",
+		Schedule:    "*/15 * * * *",
+		Run: func(ctx context.Context) error {
+			report, err := uploads.PurgeExpiredUploads(ctx)
+			if err != nil {
+				return err
+			}
+			if len(report.Failures) > 0 {
+				return fmt.Errorf("%d uploads could not be deleted: %s", len(report.Failures), strings.Join(report.Failures, "; "))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kimbasn/printly/internal/dto"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+// listQuery converts a list request into a repository query with the given filter.
+// The creation date range of the request is added to the filter.
+func listQuery(req dto.ListRequest, filter repository.ListFilter) repository.ListQuery {
+	filter.CreatedFrom = req.CreatedFrom
+	filter.CreatedTo = req.CreatedTo
+	return repository.ListQuery{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Cursor: req.Cursor,
+		Sort:   req.Sort,
+		Filter: filter,
+	}
+}
+
+// listStatuses checks a status filter against the known statuses, ignoring case.
+func listStatuses[S ~string](values []string, known []S) ([]string, error) {
+	var statuses []string
+	for _, value := range values {
+		found := false
+		for _, status := range known {
+			if strings.EqualFold(value, string(status)) {
+				statuses = append(statuses, string(status))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown status %q", ierrors.ErrInvalidListFilter, value)
+		}
+	}
+	return statuses, nil
+}
+
+// listError maps the errors of repository list queries to service errors.
+func listError(err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrInvalidCursor):
+		return ierrors.ErrInvalidCursor
+	case errors.Is(err, repository.ErrUnsupportedSort):
+		return ierrors.ErrUnsupportedSort
+	default:
+		return fmt.Errorf("%s: %w", message, err)
+	}
+}
+
+// pageInfo converts the description of a repository page for responses.
+func pageInfo(info repository.PageInfo) dto.PageInfo {
+	return dto.PageInfo{Total: info.Total, NextCursor: info.NextCursor}
+}
@@ -1,10 +1,16 @@
 package service
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -12,13 +18,16 @@ import (
 	"time"
 
 	"github.com/kimbasn/printly/internal/config"
+	ierrors "github.com/kimbasn/printly/internal/errors"
 	"go.uber.org/zap"
 )
 
 type localStorageService struct {
-	basePath string
-	baseURL  string
-	logger   *zap.Logger
+	basePath      string
+	baseURL       string
+	uploadURL     string
+	signingSecret []byte
+	logger        *zap.Logger
 }
 
 // NewLocalStorageService creates a new local storage service
@@ -29,9 +38,11 @@ func NewLocalStorageService(localConfig config.LocalStorageConfig, logger *zap.L
 	}
 
 	return &localStorageService{
-		basePath: localConfig.BasePath,
-		baseURL:  localConfig.BaseURL,
-		logger:   logger,
+		basePath:      localConfig.BasePath,
+		baseURL:       localConfig.BaseURL,
+		uploadURL:     localConfig.UploadURL,
+		signingSecret: []byte(localConfig.SigningSecret),
+		logger:        logger,
 	}, nil
 }
 
@@ -182,7 +193,7 @@ func (s *localStorageService) DeleteFile(storagePath string) error {
 
 	// Check if file exists
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		return fmt.Errorf("file does not exist: %s", storagePath)
+		return fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
 	}
 
 	// Delete the file
@@ -249,6 +260,222 @@ func (s *localStorageService) generateSimpleSignature(storagePath string, expire
 	return fmt.Sprintf("%x", hash)
 }
 
+// NewUploadPath reserves a unique storage path in the directory of the user
+func (s *localStorageService) NewUploadPath(filename, userUID string) (string, error) {
+	uniqueFilename, err := s.generateUniqueFileName(filename, userUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate unique filename: %w", err)
+	}
+	return filepath.Join(userUID, uniqueFilename), nil
+}
+
+// GetSignedUploadURL returns a URL of the upload route of this server, signed with HMAC-SHA256.
+// The signature covers the storage path, the content type and the expiry.
+func (s *localStorageService) GetSignedUploadURL(storagePath, contentType string, expiration time.Duration) (*SignedUpload, error) {
+	if storagePath == "" {
+		return nil, fmt.Errorf("storage path cannot be empty")
+	}
+
+	// The signature covers the path as the upload route receives it, decoded
+	urlPath := filepath.ToSlash(storagePath)
+	expires := time.Now().Add(expiration).Unix()
+	signature := s.signUpload(urlPath, contentType, expires)
+
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return &SignedUpload{
+		URL:     fmt.Sprintf("%s/%s?expires=%d&signature=%s", strings.TrimRight(s.uploadURL, "/"), strings.Join(segments, "/"), expires, signature),
+		Headers: map[string]string{"Content-Type": contentType},
+	}, nil
+}
+
+// VerifySignedUpload checks that the URL is not expired and was signed by this server for the content type
+func (s *localStorageService) VerifySignedUpload(storagePath, contentType string, expires int64, signature string) error {
+	if time.Now().Unix() > expires {
+		return ierrors.ErrInvalidUploadURL
+	}
+	expected := s.signUpload(storagePath, contentType, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ierrors.ErrInvalidUploadURL
+	}
+	return nil
+}
+
+// ReceiveSignedUpload stores the body of an upload sent to a signed upload URL.
+// The file is written next to its final path and linked there once complete, so an interrupted
+// upload never leaves a partial file behind. A file already stored at the path is never replaced.
+func (s *localStorageService) ReceiveSignedUpload(storagePath, contentType string, expires int64, signature string, body io.Reader) error {
+	if err := s.VerifySignedUpload(storagePath, contentType, expires, signature); err != nil {
+		return err
+	}
+
+	// The path is signed by this server, it is checked again in case the secret leaks
+	fullPath := filepath.Join(s.basePath, filepath.FromSlash(storagePath))
+	if rel, err := filepath.Rel(s.basePath, fullPath); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ierrors.ErrInvalidUploadURL
+	}
+
+	// Checked before reading the body, the link below still guards against concurrent uploads
+	if _, err := os.Stat(fullPath); err == nil {
+		return ierrors.ErrUploadAlreadyReceived
+	}
+	if err := createFileAtomically(fullPath, body); err != nil {
+		return err
+	}
+
+	s.logger.Info("Signed upload received", zap.String("storagePath", storagePath))
+	return nil
+}
+
+// writeFileAtomically writes the file next to its final path and renames it once complete,
+// so that a failed write never leaves a partial file behind.
+func writeFileAtomically(fullPath string, reader io.Reader) error {
+	tmp, err := writeTempFile(fullPath, reader)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Rename(tmp, fullPath); err != nil {
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	return nil
+}
+
+// createFileAtomically is writeFileAtomically failing with ErrUploadAlreadyReceived when there is
+// already a file at the path. The complete file is hard linked to its path, which fails if it exists.
+func createFileAtomically(fullPath string, reader io.Reader) error {
+	tmp, err := writeTempFile(fullPath, reader)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Link(tmp, fullPath); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return ierrors.ErrUploadAlreadyReceived
+		}
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	return nil
+}
+
+// writeTempFile writes the content of the reader to a temporary file next to the given path,
+// and returns the path of the temporary file. The caller must remove it.
+func writeTempFile(fullPath string, reader io.Reader) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create user directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination file: %w", err)
+	}
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to copy upload content: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write upload content: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// signUpload computes the hex HMAC-SHA256 of an upload URL
+func (s *localStorageService) signUpload(urlPath, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingSecret)
+	fmt.Fprintf(mac, "PUT\n%s\n%s\n%d", urlPath, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// StatFile returns the size of a stored file. Local storage does not record content types.
+func (s *localStorageService) StatFile(storagePath string) (*ObjectInfo, error) {
+	info, err := os.Stat(filepath.Join(s.basePath, storagePath))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return &ObjectInfo{Size: info.Size()}, nil
+}
+
+// OpenFile opens a stored file for reading
+func (s *localStorageService) OpenFile(storagePath string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(s.basePath, storagePath))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, storagePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+// WriteFile stores the content of the reader at the given storage path
+func (s *localStorageService) WriteFile(storagePath string, reader io.Reader) error {
+	if storagePath == "" {
+		return fmt.Errorf("storage path cannot be empty")
+	}
+	return writeFileAtomically(filepath.Join(s.basePath, storagePath), reader)
+}
+
+// ComposeFiles concatenates the source files into the given storage path
+func (s *localStorageService) ComposeFiles(storagePath string, sources []string) error {
+	readers := make([]io.Reader, 0, len(sources))
+	for _, source := range sources {
+		file, err := s.OpenFile(source)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+
+	if err := s.WriteFile(storagePath, io.MultiReader(readers...)); err != nil {
+		return err
+	}
+
+	s.logger.Info("Files composed", zap.String("storagePath", storagePath), zap.Int("sources", len(sources)))
+	return nil
+}
+
+// MoveFile hard links the source file to the storage path, which fails if a file is there, then removes the source
+func (s *localStorageService) MoveFile(source, storagePath string) error {
+	sourcePath := filepath.Join(s.basePath, source)
+	fullPath := filepath.Join(s.basePath, storagePath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create user directory: %w", err)
+	}
+
+	if err := os.Link(sourcePath, fullPath); err != nil {
+		switch {
+		case errors.Is(err, fs.ErrExist):
+			return ierrors.ErrUploadAlreadyReceived
+		case errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("%w: %s", ErrFileNotFound, source)
+		default:
+			return fmt.Errorf("failed to move file: %w", err)
+		}
+	}
+	if err := os.Remove(sourcePath); err != nil {
+		s.logger.Error("Failed to remove moved file", zap.String("storagePath", source), zap.Error(err))
+	}
+
+	s.logger.Info("File moved", zap.String("source", source), zap.String("storagePath", storagePath))
+	return nil
+}
+
+// Type returns the local storage backend type
+func (s *localStorageService) Type() StorageType {
+	return StorageTypeLocal
+}
+
 // GetFileInfo returns information about a stored file
 func (s *localStorageService) GetFileInfo(storagePath string) (os.FileInfo, error) {
 	fullPath := filepath.Join(s.basePath, storagePath)
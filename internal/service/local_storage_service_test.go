@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kimbasn/printly/internal/config"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type LocalStorageServiceTestSuite struct {
+	suite.Suite
+	storage  service.StorageService
+	receiver service.SignedUploadReceiver
+}
+
+func (s *LocalStorageServiceTestSuite) SetupTest() {
+	storage, err := service.NewLocalStorageService(config.LocalStorageConfig{
+		BasePath:      s.T().TempDir(),
+		BaseURL:       "http://localhost:8080/files",
+		UploadURL:     "http://localhost:8080/api/v1/uploads",
+		SigningSecret: "secret",
+	}, zap.NewNop())
+	s.Require().NoError(err)
+	s.storage = storage
+	s.receiver = storage.(service.SignedUploadReceiver)
+}
+
+func TestLocalStorageService(t *testing.T) {
+	suite.Run(t, new(LocalStorageServiceTestSuite))
+}
+
+// signedUpload signs an upload URL for the path and returns its expiry and signature
+func (s *LocalStorageServiceTestSuite) signedUpload(storagePath, contentType string) (int64, string) {
+	signed, err := s.storage.GetSignedUploadURL(storagePath, contentType, time.Minute)
+	s.Require().NoError(err)
+	s.Equal(contentType, signed.Headers["Content-Type"])
+
+	u, err := url.Parse(signed.URL)
+	s.Require().NoError(err)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	s.Require().NoError(err)
+	return expires, u.Query().Get("signature")
+}
+
+// readStored returns the content stored at the path
+func (s *LocalStorageServiceTestSuite) readStored(storagePath string) string {
+	file, err := s.storage.OpenFile(storagePath)
+	s.Require().NoError(err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	s.Require().NoError(err)
+	return string(content)
+}
+
+// ============================================================================
+// ReceiveSignedUpload Tests
+// ============================================================================
+
+func (s *LocalStorageServiceTestSuite) TestReceiveSignedUpload_Success() {
+	// Arrange
+	expires, signature := s.signedUpload("user-1/notes.txt", "text/plain")
+
+	// Act
+	err := s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("hello"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("hello", s.readStored("user-1/notes.txt"))
+}
+
+func (s *LocalStorageServiceTestSuite) TestReceiveSignedUpload_PathWithReservedCharacters() {
+	// Arrange
+	storagePath := "user-1/50% off?#1.txt"
+	signed, err := s.storage.GetSignedUploadURL(storagePath, "text/plain", time.Minute)
+	s.Require().NoError(err)
+	u, err := url.Parse(signed.URL)
+	s.Require().NoError(err)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	s.Require().NoError(err)
+
+	// Act: the upload route receives the decoded path
+	received := strings.TrimPrefix(u.Path, "/api/v1/uploads/")
+	err = s.receiver.ReceiveSignedUpload(received, "text/plain", expires, u.Query().Get("signature"), strings.NewReader("hello"))
+
+	// Assert
+	s.Equal(storagePath, received)
+	s.Require().NoError(err)
+	s.Equal("hello", s.readStored(storagePath))
+}
+
+func (s *LocalStorageServiceTestSuite) TestReceiveSignedUpload_ReuploadAfterConfirmationRejected() {
+	// Arrange: the file was uploaded then confirmed, the URL is still valid
+	expires, signature := s.signedUpload("user-1/notes.txt", "text/plain")
+	s.Require().NoError(s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("clean")))
+
+	// Act
+	err := s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("replaced"))
+
+	// Assert: the checked file is kept
+	s.ErrorIs(err, ierrors.ErrUploadAlreadyReceived)
+	s.Equal("clean", s.readStored("user-1/notes.txt"))
+}
+
+func (s *LocalStorageServiceTestSuite) TestReceiveSignedUpload_AcceptedAgainOnceRejectedFileDeleted() {
+	// Arrange: the confirmation rejected the file and deleted it
+	expires, signature := s.signedUpload("user-1/notes.txt", "text/plain")
+	s.Require().NoError(s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("broken")))
+	s.Require().NoError(s.storage.DeleteFile("user-1/notes.txt"))
+
+	// Act
+	err := s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("fixed"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("fixed", s.readStored("user-1/notes.txt"))
+}
+
+func (s *LocalStorageServiceTestSuite) TestReceiveSignedUpload_WrongContentType() {
+	// Arrange
+	expires, signature := s.signedUpload("user-1/notes.txt", "text/plain")
+
+	// Act
+	err := s.receiver.ReceiveSignedUpload("user-1/notes.txt", "application/pdf", expires, signature, strings.NewReader("%PDF-"))
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidUploadURL)
+}
+
+// ============================================================================
+// MoveFile Tests
+// ============================================================================
+
+func (s *LocalStorageServiceTestSuite) TestMoveFile_Success() {
+	// Arrange
+	s.Require().NoError(s.storage.WriteFile("user-1/notes.txt.abc.staging", strings.NewReader("joined")))
+
+	// Act
+	err := s.storage.MoveFile("user-1/notes.txt.abc.staging", "user-1/notes.txt")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("joined", s.readStored("user-1/notes.txt"))
+	_, err = s.storage.StatFile("user-1/notes.txt.abc.staging")
+	s.ErrorIs(err, service.ErrFileNotFound)
+}
+
+func (s *LocalStorageServiceTestSuite) TestMoveFile_KeepsStoredFile() {
+	// Arrange: the file was received through the signed URL meanwhile
+	expires, signature := s.signedUpload("user-1/notes.txt", "text/plain")
+	s.Require().NoError(s.receiver.ReceiveSignedUpload("user-1/notes.txt", "text/plain", expires, signature, strings.NewReader("signed")))
+	s.Require().NoError(s.storage.WriteFile("user-1/notes.txt.abc.staging", strings.NewReader("joined")))
+
+	// Act
+	err := s.storage.MoveFile("user-1/notes.txt.abc.staging", "user-1/notes.txt")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadAlreadyReceived)
+	s.Equal("signed", s.readStored("user-1/notes.txt"))
+	s.Equal("joined", s.readStored("user-1/notes.txt.abc.staging"))
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+//go:generate mockgen -destination=../mocks/mock_scanner.go -package=mocks github.com/kimbasn/printly/internal/service Scanner
+
+// Scanner checks uploaded files for malware before their document is accepted.
+type Scanner interface {
+	// Scan reads the whole file and returns its verdict. An error means the file could not be
+	// scanned, not that it is infected.
+	Scan(ctx context.Context, file io.Reader) (*entity.ScanVerdict, error)
+}
+
+// GetScanner creates the malware scanner based on the provided config
+func GetScanner(cfg config.ScannerConfig) (Scanner, error) {
+	switch cfg.Provider {
+	case config.ScannerProviderClamd:
+		return NewClamdScanner(cfg.Clamd), nil
+	case config.ScannerProviderStub:
+		return NewStubScanner(), nil
+	default:
+		return nil, fmt.Errorf("unsupported malware scanner: %s", cfg.Provider)
+	}
+}
+
+// ============================================================================
+// clamd
+// ============================================================================
+
+const (
+	clamdEngine = "clamav"
+	// clamdChunkSize is the size of the chunks files are streamed to clamd in
+	clamdChunkSize = 64 << 10
+	// maxClamdReply bounds the reply read from clamd
+	maxClamdReply = 4 << 10
+)
+
+type clamdScanner struct {
+	cfg config.ClamdConfig
+}
+
+// NewClamdScanner creates a Scanner sending files to a ClamAV daemon, over TCP or a unix socket.
+// clamd must accept streams of the largest files uploaded (StreamMaxLength 50M).
+func NewClamdScanner(cfg config.ClamdConfig) Scanner {
+	return &clamdScanner{cfg: cfg}
+}
+
+// Scan streams the file with the INSTREAM command, in chunks prefixed with their length and
+// ended by an empty chunk. The scan is bound to the configured timeout and the context deadline.
+func (s *clamdScanner) Scan(ctx context.Context, file io.Reader) (*entity.ScanVerdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, s.cfg.Network, s.cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd %s: %w", s.cfg.Address, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return nil, fmt.Errorf("failed to send command to clamd: %w", err)
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, readErr := io.ReadFull(file, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return s.interrupted(conn, err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read file: %w", readErr)
+		}
+	}
+	if _, err := conn.Write(make([]byte, 4)); err != nil {
+		return s.interrupted(conn, err)
+	}
+
+	reply, err := readClamdReply(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamdReply(reply)
+}
+
+// interrupted reads the reply of clamd after it stopped receiving the file. clamd answers then
+// closes the connection when a stream exceeds its StreamMaxLength.
+func (s *clamdScanner) interrupted(conn net.Conn, err error) (*entity.ScanVerdict, error) {
+	if reply, replyErr := readClamdReply(conn); replyErr == nil {
+		return parseClamdReply(reply)
+	}
+	return nil, fmt.Errorf("failed to send file to clamd: %w", err)
+}
+
+// readClamdReply reads a reply up to its terminating null byte, or to the end of the connection
+func readClamdReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(io.LimitReader(conn, maxClamdReply)).ReadString(0)
+	if err != nil && (!errors.Is(err, io.EOF) || reply == "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+// parseClamdReply reads the verdict of an INSTREAM reply: "stream: OK", "stream: <signature> FOUND",
+// or "<message> ERROR" when clamd could not scan the file.
+func parseClamdReply(reply string) (*entity.ScanVerdict, error) {
+	now := time.Now()
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return &entity.ScanVerdict{Status: entity.ScanClean, Engine: clamdEngine, Time: &now}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &entity.ScanVerdict{
+			Status:    entity.ScanInfected,
+			Signature: strings.TrimSuffix(result, " FOUND"),
+			Engine:    clamdEngine,
+			Time:      &now,
+		}, nil
+	default:
+		return nil, fmt.Errorf("clamd could not scan the file: %s", reply)
+	}
+}
+
+// ============================================================================
+// Stub
+// ============================================================================
+
+const (
+	stubEngine    = "stub"
+	stubSignature = "Eicar-Test-Signature"
+)
+
+// eicarTestFile is the EICAR anti-malware test file, which every scanner flags.
+// It is split so that this source file is not flagged itself.
+var eicarTestFile = []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+
+type stubScanner struct{}
+
+// NewStubScanner creates a deterministic Scanner for development and tests. It reports files
+// containing the EICAR test file as infected, and every other file as clean.
+func NewStubScanner() Scanner {
+	return stubScanner{}
+}
+
+func (stubScanner) Scan(ctx context.Context, file io.Reader) (*entity.ScanVerdict, error) {
+	// The end of each chunk is kept, so that the test file is found across chunks
+	keep := len(eicarTestFile) - 1
+	window := make([]byte, 0, keep+scanChunkSize)
+	chunk := make([]byte, scanChunkSize)
+	found := false
+	for !found {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, err := file.Read(chunk)
+		window = append(window, chunk[:n]...)
+		found = bytes.Contains(window, eicarTestFile)
+		if len(window) > keep {
+			window = append(window[:0], window[len(window)-keep:]...)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if found {
+		return &entity.ScanVerdict{Status: entity.ScanInfected, Signature: stubSignature, Engine: stubEngine, Time: &now}, nil
+	}
+	return &entity.ScanVerdict{Status: entity.ScanClean, Engine: stubEngine, Time: &now}, nil
+}
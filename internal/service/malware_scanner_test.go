@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type MalwareScannerTestSuite struct {
+	suite.Suite
+}
+
+func TestMalwareScanner(t *testing.T) {
+	suite.Run(t, new(MalwareScannerTestSuite))
+}
+
+// eicarFile returns the EICAR anti-malware test file, split so that this source file is not flagged
+func eicarFile() []byte {
+	return []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+}
+
+// fakeClamd serves one INSTREAM command on the listener. It checks the command, reassembles the
+// chunks of the stream, and answers with the reply made of the received file.
+func (s *MalwareScannerTestSuite) fakeClamd(listener net.Listener, reply func(file []byte) string) <-chan []byte {
+	received := make(chan []byte, 1)
+	go func() {
+		defer close(received)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+			return
+		}
+		var file bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&file, conn, int64(size)); err != nil {
+				return
+			}
+		}
+		_, _ = io.WriteString(conn, reply(file.Bytes())+"\x00")
+		received <- file.Bytes()
+	}()
+	return received
+}
+
+func (s *MalwareScannerTestSuite) listenTCP() (net.Listener, config.ClamdConfig) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { listener.Close() })
+	return listener, config.ClamdConfig{Network: "tcp", Address: listener.Addr().String(), Timeout: 5 * time.Second}
+}
+
+// ============================================================================
+// clamd Tests
+// ============================================================================
+
+func (s *MalwareScannerTestSuite) TestClamd_Clean() {
+	// Arrange: a file of several chunks
+	listener, cfg := s.listenTCP()
+	received := s.fakeClamd(listener, func([]byte) string { return "stream: OK" })
+	file := bytes.Repeat([]byte("printly "), 20000)
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), bytes.NewReader(file))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanClean, verdict.Status)
+	s.Equal("clamav", verdict.Engine)
+	s.Empty(verdict.Signature)
+	s.NotNil(verdict.Time)
+	s.Equal(file, <-received)
+}
+
+func (s *MalwareScannerTestSuite) TestClamd_Infected() {
+	// Arrange
+	listener, cfg := s.listenTCP()
+	s.fakeClamd(listener, func(file []byte) string {
+		if bytes.Contains(file, eicarFile()) {
+			return "stream: Win.Test.EICAR_HDB-1 FOUND"
+		}
+		return "stream: OK"
+	})
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), bytes.NewReader(eicarFile()))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanInfected, verdict.Status)
+	s.Equal("Win.Test.EICAR_HDB-1", verdict.Signature)
+}
+
+func (s *MalwareScannerTestSuite) TestClamd_UnixSocket() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "clamd.sock")
+	listener, err := net.Listen("unix", path)
+	s.Require().NoError(err)
+	defer listener.Close()
+	s.fakeClamd(listener, func([]byte) string { return "stream: OK" })
+	cfg := config.ClamdConfig{Network: "unix", Address: path, Timeout: 5 * time.Second}
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), strings.NewReader("hello"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanClean, verdict.Status)
+}
+
+func (s *MalwareScannerTestSuite) TestClamd_ErrorReply() {
+	// Arrange
+	listener, cfg := s.listenTCP()
+	s.fakeClamd(listener, func([]byte) string { return "INSTREAM size limit exceeded. ERROR" })
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), strings.NewReader("hello"))
+
+	// Assert: the file is not reported clean
+	s.ErrorContains(err, "size limit exceeded")
+	s.Nil(verdict)
+}
+
+func (s *MalwareScannerTestSuite) TestClamd_Unreachable() {
+	// Arrange: a port nobody listens on anymore
+	listener, cfg := s.listenTCP()
+	listener.Close()
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), strings.NewReader("hello"))
+
+	// Assert
+	s.ErrorContains(err, "failed to connect to clamd")
+	s.Nil(verdict)
+}
+
+func (s *MalwareScannerTestSuite) TestClamd_Timeout() {
+	// Arrange: a daemon that never answers
+	listener, cfg := s.listenTCP()
+	cfg.Timeout = 200 * time.Millisecond
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	// Act
+	verdict, err := service.NewClamdScanner(cfg).Scan(context.Background(), strings.NewReader("hello"))
+
+	// Assert
+	s.Error(err)
+	s.Nil(verdict)
+}
+
+// ============================================================================
+// Stub Tests
+// ============================================================================
+
+func (s *MalwareScannerTestSuite) TestStub_Clean() {
+	// Act
+	verdict, err := service.NewStubScanner().Scan(context.Background(), strings.NewReader("hello"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanClean, verdict.Status)
+	s.Equal("stub", verdict.Engine)
+}
+
+func (s *MalwareScannerTestSuite) TestStub_TestFileAcrossChunks() {
+	// Arrange: the test file straddles the 64KB chunks the stub reads
+	file := append(bytes.Repeat([]byte{'a'}, 64<<10-10), eicarFile()...)
+	file = append(file, "trailer"...)
+
+	// Act
+	verdict, err := service.NewStubScanner().Scan(context.Background(), bytes.NewReader(file))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.ScanInfected, verdict.Status)
+	s.Equal("Eicar-Test-Signature", verdict.Signature)
+}
+
+// ============================================================================
+// GetScanner Tests
+// ============================================================================
+
+func (s *MalwareScannerTestSuite) TestGetScanner_UnsupportedProvider() {
+	// Act
+	_, err := service.GetScanner(config.ScannerConfig{Provider: "antivirus"})
+
+	// Assert
+	s.ErrorContains(err, "unsupported malware scanner")
+}
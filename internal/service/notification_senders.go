@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+//go:generate mockgen -destination=../mocks/mock_notification_sender.go -package=mocks github.com/kimbasn/printly/internal/service NotificationSender
+
+// NotificationSender delivers the notifications of one channel through a provider.
+type NotificationSender interface {
+	// Channel returns the channel the sender delivers
+	Channel() entity.NotificationChannel
+	// Send delivers one message. Errors are retried later by the notifier.
+	Send(ctx context.Context, message NotificationMessage) error
+}
+
+// NotificationMessage is a rendered notification addressed to one recipient
+type NotificationMessage struct {
+	To      string // email address or phone number
+	Subject string // emails only
+	Body    string
+}
+
+// smsRequestTimeout bounds each call to the SMS API
+const smsRequestTimeout = 15 * time.Second
+
+// GetNotificationSenders creates the senders of the enabled notification channels based on the provided config
+func GetNotificationSenders(cfg config.NotificationConfig, logger *zap.Logger) ([]NotificationSender, error) {
+	var logSink *notificationLogSink
+	if cfg.EmailProvider == config.NotificationProviderLog || cfg.SMSProvider == config.NotificationProviderLog {
+		sink, err := newNotificationLogSink(cfg.LogFile, logger)
+		if err != nil {
+			return nil, err
+		}
+		logSink = sink
+	}
+
+	var senders []NotificationSender
+	switch cfg.EmailProvider {
+	case config.NotificationProviderNone:
+	case config.NotificationProviderLog:
+		senders = append(senders, &logSender{channel: entity.ChannelEmail, sink: logSink})
+	case config.NotificationProviderSMTP:
+		senders = append(senders, NewSMTPSender(cfg.SMTP))
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", cfg.EmailProvider)
+	}
+
+	switch cfg.SMSProvider {
+	case config.NotificationProviderNone:
+	case config.NotificationProviderLog:
+		senders = append(senders, &logSender{channel: entity.ChannelSMS, sink: logSink})
+	case config.NotificationProviderHTTP:
+		senders = append(senders, NewSMSHTTPSender(cfg.SMS))
+	default:
+		return nil, fmt.Errorf("unsupported SMS provider: %s", cfg.SMSProvider)
+	}
+	return senders, nil
+}
+
+// ============================================================================
+// SMTP
+// ============================================================================
+
+type smtpSender struct {
+	cfg config.SMTPConfig
+}
+
+// NewSMTPSender creates an email sender for an SMTP server. Port 465 is dialed with
+// implicit TLS, other ports are upgraded with STARTTLS when the server offers it.
+func NewSMTPSender(cfg config.SMTPConfig) NotificationSender {
+	return &smtpSender{cfg: cfg}
+}
+
+func (s *smtpSender) Channel() entity.NotificationChannel {
+	return entity.ChannelEmail
+}
+
+// Send delivers one plain text email. The connection is bound to the context deadline.
+func (s *smtpSender) Send(ctx context.Context, message NotificationMessage) error {
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.Port == "465" {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet SMTP server %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("SMTP server refused sender: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return fmt.Errorf("SMTP server refused recipient: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP server refused data: %w", err)
+	}
+	if _, err := w.Write(s.compose(message)); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose writes the headers and the quoted-printable body of an email.
+func (s *smtpSender) compose(message NotificationMessage) []byte {
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		// Strip line breaks so that values can not add headers
+		value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", s.cfg.From)
+	header("To", message.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(strings.ReplaceAll(message.Body, "\n", "\r\n")))
+	qp.Close()
+	return buf.Bytes()
+}
+
+// ============================================================================
+// SMS over HTTP
+// ============================================================================
+
+type smsHTTPSender struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+// NewSMSHTTPSender creates an SMS sender for a generic HTTP API. Each message is
+// posted as a JSON object with "from", "to" and "message" fields, authenticated with
+// the API key as a bearer token. Any 2xx response is a success.
+func NewSMSHTTPSender(cfg config.SMSConfig) NotificationSender {
+	return &smsHTTPSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: smsRequestTimeout},
+	}
+}
+
+func (s *smsHTTPSender) Channel() entity.NotificationChannel {
+	return entity.ChannelSMS
+}
+
+func (s *smsHTTPSender) Send(ctx context.Context, message NotificationMessage) error {
+	payload, err := json.Marshal(map[string]string{
+		"from":    s.cfg.Sender,
+		"to":      message.To,
+		"message": message.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode SMS: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create SMS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SMS API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("SMS API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ============================================================================
+// Log sink
+// ============================================================================
+
+// notificationLogSink writes notifications as JSON lines to a file, or to the
+// application log when no file is configured. It is shared by the log senders.
+type notificationLogSink struct {
+	mu     sync.Mutex
+	file   io.Writer
+	logger *zap.Logger
+}
+
+func newNotificationLogSink(path string, logger *zap.Logger) (*notificationLogSink, error) {
+	sink := &notificationLogSink{logger: logger}
+	if path != "" {
+		// The file stays open for the lifetime of the process
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open notification log file: %w", err)
+		}
+		sink.file = f
+	}
+	return sink, nil
+}
+
+// loggedNotification is a line of the notification log file
+type loggedNotification struct {
+	Time    time.Time                  `json:"time"`
+	Channel entity.NotificationChannel `json:"channel"`
+	To      string                     `json:"to"`
+	Subject string                     `json:"subject,omitempty"`
+	Body    string                     `json:"body"`
+}
+
+func (s *notificationLogSink) write(channel entity.NotificationChannel, message NotificationMessage) error {
+	if s.file == nil {
+		s.logger.Info("Notification",
+			zap.String("channel", string(channel)),
+			zap.String("to", message.To),
+			zap.String("subject", message.Subject),
+			zap.String("body", message.Body))
+		return nil
+	}
+
+	line, err := json.Marshal(loggedNotification{
+		Time:    time.Now(),
+		Channel: channel,
+		To:      message.To,
+		Subject: message.Subject,
+		Body:    message.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification log: %w", err)
+	}
+	return nil
+}
+
+// logSender writes the notifications of a channel to the log sink, for development.
+type logSender struct {
+	channel entity.NotificationChannel
+	sink    *notificationLogSink
+}
+
+func (s *logSender) Channel() entity.NotificationChannel {
+	return s.channel
+}
+
+func (s *logSender) Send(ctx context.Context, message NotificationMessage) error {
+	return s.sink.write(s.channel, message)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+// notificationData is what the notification templates may refer to.
+// Times and amounts are formatted for the locale of the recipient.
+type notificationData struct {
+	FirstName  string
+	Code       string
+	CenterName string
+	Amount     string
+	PickupTime string
+	ExpiresAt  string
+}
+
+// notificationTemplate is the text of one event in one language. Bodies are kept short
+// enough for a single SMS; emails send the same body with the subject.
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// notificationLocale holds the templates and formats of one language
+type notificationLocale struct {
+	timeLayout       string
+	decimalSeparator string
+	templates        map[entity.NotificationEvent]notificationTemplate
+}
+
+// fallbackNotificationLocale is used when neither the recipient nor the configuration give a known locale
+const fallbackNotificationLocale = "en"
+
+func newNotificationTemplate(subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// notificationLocales lists the supported languages. Every language must define every event.
+var notificationLocales = map[string]notificationLocale{
+	"en": {
+		timeLayout:       "Jan 2 at 15:04",
+		decimalSeparator: ".",
+		templates: map[entity.NotificationEvent]notificationTemplate{
+			entity.NotifyOrderPaid: newNotificationTemplate(
+				"Payment received for order {{.Code}}",
+				"Hi {{.FirstName}}, we received your payment of {{.Amount}} for order {{.Code}} at {{.CenterName}}. Choose when to pick it up in the app."),
+			entity.NotifyOrderReadyForPickup: newNotificationTemplate(
+				"Order {{.Code}} is ready for pickup",
+				"Hi {{.FirstName}}, your order {{.Code}} is ready at {{.CenterName}}.{{if .PickupTime}} Pickup: {{.PickupTime}}.{{end}} Show your QR code at the counter."),
+			entity.NotifyOrderExpiringSoon: newNotificationTemplate(
+				"Order {{.Code}} expires soon",
+				"Hi {{.FirstName}}, your order {{.Code}} at {{.CenterName}} is not complete and will be cancelled on {{.ExpiresAt}}. Finish it in the app to keep it."),
+			entity.NotifyOrderCancelled: newNotificationTemplate(
+				"Order {{.Code}} cancelled",
+				"Hi {{.FirstName}}, your order {{.Code}} at {{.CenterName}} was cancelled. Any payment will be refunded."),
+			entity.NotifyCenterApproved: newNotificationTemplate(
+				"{{.CenterName}} is approved",
+				"Good news{{if .FirstName}} {{.FirstName}}{{end}}: {{.CenterName}} is approved and now visible to customers on Printly."),
+		},
+	},
+	"fr": {
+		timeLayout:       "02/01 à 15h04",
+		decimalSeparator: ",",
+		templates: map[entity.NotificationEvent]notificationTemplate{
+			entity.NotifyOrderPaid: newNotificationTemplate(
+				"Paiement reçu pour la commande {{.Code}}",
+				"Bonjour {{.FirstName}}, nous avons reçu votre paiement de {{.Amount}} pour la commande {{.Code}} chez {{.CenterName}}. Choisissez votre heure de retrait dans l'application."),
+			entity.NotifyOrderReadyForPickup: newNotificationTemplate(
+				"La commande {{.Code}} est prête",
+				"Bonjour {{.FirstName}}, votre commande {{.Code}} est prête chez {{.CenterName}}.{{if .PickupTime}} Retrait : {{.PickupTime}}.{{end}} Présentez votre QR code au comptoir."),
+			entity.NotifyOrderExpiringSoon: newNotificationTemplate(
+				"La commande {{.Code}} expire bientôt",
+				"Bonjour {{.FirstName}}, votre commande {{.Code}} chez {{.CenterName}} n'est pas terminée et sera annulée le {{.ExpiresAt}}. Finalisez-la dans l'application pour la conserver."),
+			entity.NotifyOrderCancelled: newNotificationTemplate(
+				"Commande {{.Code}} annulée",
+				"Bonjour {{.FirstName}}, votre commande {{.Code}} chez {{.CenterName}} a été annulée. Tout paiement sera remboursé."),
+			entity.NotifyCenterApproved: newNotificationTemplate(
+				"{{.CenterName}} est validé",
+				"Bonne nouvelle{{if .FirstName}} {{.FirstName}}{{end}} : {{.CenterName}} est validé et désormais visible des clients sur Printly."),
+		},
+	},
+}
+
+// resolveNotificationLocale returns the first supported locale among the given ones.
+func resolveNotificationLocale(locales ...string) string {
+	for _, locale := range locales {
+		if _, ok := notificationLocales[locale]; ok {
+			return locale
+		}
+	}
+	return fallbackNotificationLocale
+}
+
+// formatTime formats an optional time for the locale, in the server time zone.
+func (l notificationLocale) formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format(l.timeLayout)
+}
+
+// formatAmount formats an amount in cents with its currency, e.g. "6.50 EUR".
+func (l notificationLocale) formatAmount(cents int64, currency string) string {
+	amount := fmt.Sprintf("%d.%02d", cents/100, cents%100)
+	return strings.Replace(amount, ".", l.decimalSeparator, 1) + " " + currency
+}
+
+// renderNotification renders the subject and body of an event in a supported locale.
+func renderNotification(locale string, event entity.NotificationEvent, data notificationData) (subject, body string, err error) {
+	tmpl, ok := notificationLocales[locale].templates[event]
+	if !ok {
+		return "", "", fmt.Errorf("no %s template for notification %s", locale, event)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.subject.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of notification %s: %w", event, err)
+	}
+	subject = buf.String()
+
+	buf.Reset()
+	if err := tmpl.body.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of notification %s: %w", event, err)
+	}
+	return subject, buf.String(), nil
+}
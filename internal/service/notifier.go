@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_notifier.go -package=mocks github.com/kimbasn/printly/internal/service Notifier
+
+// Notifier tells customers and print center owners about the events that concern them, by
+// email and SMS in their language. Notifications are queued and recorded per order and user,
+// then delivered in the background with retries.
+type Notifier interface {
+	// NotifyOrder queues a notification of an order event for its customer, once per order and event
+	NotifyOrder(n OrderNotification) error
+	// NotifyCenter queues a notification of a print center event for the center and its owner
+	NotifyCenter(event entity.NotificationEvent, center *entity.PrintCenter) error
+	// DeliverDue sends the queued notifications whose next attempt is due
+	DeliverDue(ctx context.Context) (*dto.NotificationDeliveryReport, error)
+	// GetUserNotifications retrieves one page of the notifications sent to a user
+	GetUserNotifications(userUID string, req dto.ListRequest) ([]entity.Notification, dto.PageInfo, error)
+	// GetOrderNotifications retrieves one page of the notifications of an order the actor placed
+	GetOrderNotifications(orderID uint, actor entity.Actor, req dto.ListRequest) ([]entity.Notification, dto.PageInfo, error)
+}
+
+// OrderNotification describes an order event to notify
+type OrderNotification struct {
+	Event     entity.NotificationEvent
+	Order     *entity.Order
+	ExpiresAt *time.Time // when the order will be cancelled, for expiry warnings
+}
+
+const (
+	// notificationBatchSize bounds the number of notifications sent in one delivery run
+	notificationBatchSize = 100
+	// notificationMaxAttempts is the number of attempts after which a notification is given up
+	notificationMaxAttempts = 6
+	// notificationRetryDelay is the delay before the first retry. It doubles with each attempt.
+	notificationRetryDelay = time.Minute
+	// notificationMaxRetryDelay caps the delay between two attempts
+	notificationMaxRetryDelay = time.Hour
+	// notificationSendTimeout bounds each attempt
+	notificationSendTimeout = 30 * time.Second
+)
+
+type notifier struct {
+	notificationRepo repository.NotificationRepository
+	orderRepo        repository.OrderRepository
+	userRepo         repository.UserRepository
+	printCenterRepo  repository.PrintCenterRepository
+	senders          map[entity.NotificationChannel]NotificationSender
+	defaultLocale    string
+	logger           *zap.Logger
+}
+
+// NewNotifier creates a new instance of Notifier. Notifications are only queued on the
+// channels of the given senders, and in the default locale for users without one.
+func NewNotifier(
+	notificationRepo repository.NotificationRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+	printCenterRepo repository.PrintCenterRepository,
+	senders []NotificationSender,
+	defaultLocale string,
+	logger *zap.Logger,
+) Notifier {
+	byChannel := make(map[entity.NotificationChannel]NotificationSender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+	return &notifier{
+		notificationRepo: notificationRepo,
+		orderRepo:        orderRepo,
+		userRepo:         userRepo,
+		printCenterRepo:  printCenterRepo,
+		senders:          byChannel,
+		defaultLocale:    defaultLocale,
+		logger:           logger,
+	}
+}
+
+// notificationRecipient is an address of a recipient on one channel
+type notificationRecipient struct {
+	channel entity.NotificationChannel
+	address string
+}
+
+// NotifyOrder sends the customer an email and an SMS, when they have a phone number.
+// An order is notified of each event at most once, so that retried triggers do not spam.
+func (n *notifier) NotifyOrder(on OrderNotification) error {
+	order := on.Order
+	notified, err := n.notificationRepo.ExistsForOrder(order.ID, on.Event)
+	if err != nil {
+		return err
+	}
+	if notified {
+		return nil
+	}
+
+	user, err := n.userRepo.FindByUID(order.UserUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ierrors.ErrUserNotFound
+		}
+		return fmt.Errorf("getting user %s: %w", order.UserUID, err)
+	}
+	center, err := n.printCenterRepo.FindByID(order.PrintCenterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ierrors.ErrPrintCenterNotFound
+		}
+		return fmt.Errorf("getting print center id %d: %w", order.PrintCenterID, err)
+	}
+
+	localeName := resolveNotificationLocale(user.Locale, n.defaultLocale)
+	locale := notificationLocales[localeName]
+	data := notificationData{
+		FirstName:  user.FirstName,
+		Code:       order.Code,
+		CenterName: center.Name,
+		Amount:     locale.formatAmount(order.TotalCost, order.Currency),
+		PickupTime: locale.formatTime(order.PickupTime),
+		ExpiresAt:  locale.formatTime(on.ExpiresAt),
+	}
+	base := entity.Notification{
+		Event:         on.Event,
+		UserUID:       user.UID,
+		OrderID:       &order.ID,
+		PrintCenterID: &center.ID,
+		Locale:        localeName,
+	}
+	recipients := []notificationRecipient{
+		{channel: entity.ChannelEmail, address: user.Email},
+		{channel: entity.ChannelSMS, address: user.PhoneNumber},
+	}
+	return n.queue(base, recipients, data)
+}
+
+// NotifyCenter writes to the contact email and phone number of the center, in the
+// language of its owner. The notifications are recorded for the owner.
+func (n *notifier) NotifyCenter(event entity.NotificationEvent, center *entity.PrintCenter) error {
+	var firstName, ownerLocale string
+	if center.OwnerUID != "" {
+		owner, err := n.userRepo.FindByUID(center.OwnerUID)
+		switch {
+		case err == nil:
+			firstName, ownerLocale = owner.FirstName, owner.Locale
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return fmt.Errorf("getting user %s: %w", center.OwnerUID, err)
+		}
+	}
+
+	localeName := resolveNotificationLocale(ownerLocale, n.defaultLocale)
+	base := entity.Notification{
+		Event:         event,
+		UserUID:       center.OwnerUID,
+		PrintCenterID: &center.ID,
+		Locale:        localeName,
+	}
+	recipients := []notificationRecipient{
+		{channel: entity.ChannelEmail, address: center.Email},
+		{channel: entity.ChannelSMS, address: center.PhoneNumber},
+	}
+	return n.queue(base, recipients, notificationData{FirstName: firstName, CenterName: center.Name})
+}
+
+// queue renders the notification and saves one pending copy per enabled channel the recipient has an address on.
+func (n *notifier) queue(base entity.Notification, recipients []notificationRecipient, data notificationData) error {
+	subject, body, err := renderNotification(base.Locale, base.Event, data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var notifications []entity.Notification
+	for _, recipient := range recipients {
+		if recipient.address == "" {
+			continue
+		}
+		if _, enabled := n.senders[recipient.channel]; !enabled {
+			continue
+		}
+
+		notification := base
+		notification.Channel = recipient.channel
+		notification.Recipient = recipient.address
+		notification.Body = body
+		if recipient.channel == entity.ChannelEmail {
+			notification.Subject = subject
+		}
+		notification.Status = entity.NotificationPending
+		notification.NextAttemptAt = now
+		notifications = append(notifications, notification)
+	}
+
+	if len(notifications) == 0 {
+		n.logger.Debug("No channel to notify", zap.String("event", string(base.Event)), zap.String("userUID", base.UserUID))
+		return nil
+	}
+	if err := n.notificationRepo.Save(notifications); err != nil {
+		return err
+	}
+
+	n.logger.Info("Notification queued",
+		zap.String("event", string(base.Event)),
+		zap.String("userUID", base.UserUID),
+		zap.Int("channels", len(notifications)))
+	return nil
+}
+
+// DeliverDue sends the due notifications one by one. Failed attempts are retried with an
+// exponential backoff, until the notification is given up after its last attempt.
+func (n *notifier) DeliverDue(ctx context.Context) (*dto.NotificationDeliveryReport, error) {
+	report := &dto.NotificationDeliveryReport{}
+
+	due, err := n.notificationRepo.FindDue(time.Now(), notificationBatchSize)
+	if err != nil {
+		return report, err
+	}
+
+	for i := range due {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		n.deliver(ctx, &due[i], report)
+	}
+
+	if len(due) > 0 {
+		n.logger.Info("Notifications delivered",
+			zap.Int("sent", report.Sent),
+			zap.Int("retried", report.Retried),
+			zap.Int("failed", report.Failed))
+	}
+	return report, nil
+}
+
+// deliver makes one attempt to send a notification and records its outcome
+func (n *notifier) deliver(ctx context.Context, notification *entity.Notification, report *dto.NotificationDeliveryReport) {
+	err := n.send(ctx, notification)
+	now := time.Now()
+	attempts := notification.Attempts + 1
+	updates := map[string]any{
+		"attempts":   attempts,
+		"updated_at": now,
+	}
+
+	switch {
+	case err == nil:
+		updates["status"] = entity.NotificationSent
+		updates["sent_at"] = now
+		updates["last_error"] = ""
+		report.Sent++
+	case attempts >= notificationMaxAttempts:
+		updates["status"] = entity.NotificationFailed
+		updates["last_error"] = err.Error()
+		report.Failed++
+		report.Failures = append(report.Failures, fmt.Sprintf("notification %d: %s", notification.ID, err.Error()))
+	default:
+		updates["next_attempt_at"] = now.Add(notificationRetryAfter(attempts))
+		updates["last_error"] = err.Error()
+		report.Retried++
+		n.logger.Warn("Notification attempt failed",
+			zap.Uint("notificationID", notification.ID),
+			zap.Int("attempts", attempts),
+			zap.Error(err))
+	}
+
+	if err := n.notificationRepo.Update(notification.ID, updates); err != nil {
+		report.Failures = append(report.Failures, fmt.Sprintf("notification %d: %s", notification.ID, err.Error()))
+	}
+}
+
+// send hands a notification to the sender of its channel
+func (n *notifier) send(ctx context.Context, notification *entity.Notification) error {
+	sender, ok := n.senders[notification.Channel]
+	if !ok {
+		return fmt.Errorf("no provider enabled for channel %s", notification.Channel)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	defer cancel()
+	return sender.Send(ctx, NotificationMessage{
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+}
+
+// notificationRetryAfter returns the delay before the next attempt: 1, 2, 4, 8 minutes and so on, capped.
+func notificationRetryAfter(attempts int) time.Duration {
+	delay := notificationRetryDelay << (attempts - 1)
+	if delay <= 0 || delay > notificationMaxRetryDelay {
+		return notificationMaxRetryDelay
+	}
+	return delay
+}
+
+// GetUserNotifications retrieves the notifications of a user, newest first.
+func (n *notifier) GetUserNotifications(userUID string, req dto.ListRequest) ([]entity.Notification, dto.PageInfo, error) {
+	statuses, err := listStatuses(req.Status, entity.NotificationStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	filter := repository.ListFilter{Statuses: statuses, UserUID: userUID}
+	notifications, info, err := n.notificationRepo.List(listQuery(req, filter))
+	if err != nil {
+		return nil, dto.PageInfo{}, listError(err, fmt.Sprintf("failed to fetch notifications for user %s", userUID))
+	}
+	return notifications, pageInfo(info), nil
+}
+
+// GetOrderNotifications retrieves the notifications of an order, newest first. They hold the
+// contact details of the customer, so only the customer and admins may read them.
+func (n *notifier) GetOrderNotifications(orderID uint, actor entity.Actor, req dto.ListRequest) ([]entity.Notification, dto.PageInfo, error) {
+	order, err := n.orderRepo.FindByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dto.PageInfo{}, ierrors.ErrOrderNotFound
+		}
+		return nil, dto.PageInfo{}, fmt.Errorf("getting order by id %d: %w", orderID, err)
+	}
+	if order.UserUID != actor.UID && actor.Role != entity.RoleAdmin {
+		return nil, dto.PageInfo{}, ierrors.ErrOrderAccessDenied
+	}
+
+	statuses, err := listStatuses(req.Status, entity.NotificationStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	filter := repository.ListFilter{Statuses: statuses, OrderID: orderID}
+	notifications, info, err := n.notificationRepo.List(listQuery(req, filter))
+	if err != nil {
+		return nil, dto.PageInfo{}, listError(err, fmt.Sprintf("failed to fetch notifications for order %d", orderID))
+	}
+	return notifications, pageInfo(info), nil
+}
@@ -0,0 +1,395 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type NotifierTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	notificationRepo *mocks.MockNotificationRepository
+	orderRepo        *mocks.MockOrderRepository
+	userRepo         *mocks.MockUserRepository
+	printCenterRepo  *mocks.MockPrintCenterRepository
+	email            *mocks.MockNotificationSender
+	sms              *mocks.MockNotificationSender
+	notifier         service.Notifier
+}
+
+func (s *NotifierTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.notificationRepo = mocks.NewMockNotificationRepository(s.ctrl)
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.userRepo = mocks.NewMockUserRepository(s.ctrl)
+	s.printCenterRepo = mocks.NewMockPrintCenterRepository(s.ctrl)
+	s.email = mocks.NewMockNotificationSender(s.ctrl)
+	s.email.EXPECT().Channel().Return(entity.ChannelEmail).AnyTimes()
+	s.sms = mocks.NewMockNotificationSender(s.ctrl)
+	s.sms.EXPECT().Channel().Return(entity.ChannelSMS).AnyTimes()
+	s.notifier = s.newNotifier(s.email, s.sms)
+}
+
+func (s *NotifierTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestNotifier(t *testing.T) {
+	suite.Run(t, new(NotifierTestSuite))
+}
+
+// newNotifier creates a notifier delivering with the given senders, in English by default.
+func (s *NotifierTestSuite) newNotifier(senders ...service.NotificationSender) service.Notifier {
+	return service.NewNotifier(s.notificationRepo, s.orderRepo, s.userRepo, s.printCenterRepo, senders, "en", zap.NewNop())
+}
+
+// paidOrder returns a paid order of center 4 placed by test-user-123.
+func paidOrder() *entity.Order {
+	return &entity.Order{
+		ID:            1,
+		Code:          "ABC123",
+		UserUID:       "test-user-123",
+		PrintCenterID: 4,
+		Status:        entity.StatusPaid,
+		TotalCost:     450,
+		Currency:      "EUR",
+	}
+}
+
+// customer returns the customer of paidOrder.
+func customer(locale, phone string) *entity.User {
+	return &entity.User{UID: "test-user-123", FirstName: "Awa", Email: "awa@example.com", PhoneNumber: phone, Locale: locale}
+}
+
+// ============================================================================
+// NotifyOrder Tests
+// ============================================================================
+
+func (s *NotifierTestSuite) TestNotifyOrder_QueuesEveryChannelInUserLocale() {
+	// Arrange
+	s.notificationRepo.EXPECT().ExistsForOrder(uint(1), entity.NotifyOrderPaid).Return(false, nil)
+	s.userRepo.EXPECT().FindByUID("test-user-123").Return(customer("fr", "+22670000000"), nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(4)).Return(&entity.PrintCenter{ID: 4, Name: "Copy Center"}, nil)
+
+	var saved []entity.Notification
+	s.notificationRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(notifications []entity.Notification) error {
+		saved = notifications
+		return nil
+	})
+
+	// Act
+	err := s.notifier.NotifyOrder(service.OrderNotification{Event: entity.NotifyOrderPaid, Order: paidOrder()})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(saved, 2)
+	email, sms := saved[0], saved[1]
+	s.Equal(entity.ChannelEmail, email.Channel)
+	s.Equal("awa@example.com", email.Recipient)
+	s.Equal("Paiement reçu pour la commande ABC123", email.Subject)
+	s.Contains(email.Body, "4,50 EUR")
+	s.Contains(email.Body, "Copy Center")
+	s.Equal(entity.ChannelSMS, sms.Channel)
+	s.Equal("+22670000000", sms.Recipient)
+	s.Empty(sms.Subject)
+	s.Equal(email.Body, sms.Body)
+	for _, notification := range saved {
+		s.Equal(entity.NotifyOrderPaid, notification.Event)
+		s.Equal("test-user-123", notification.UserUID)
+		s.Equal(uint(1), *notification.OrderID)
+		s.Equal(uint(4), *notification.PrintCenterID)
+		s.Equal("fr", notification.Locale)
+		s.Equal(entity.NotificationPending, notification.Status)
+		s.WithinDuration(time.Now(), notification.NextAttemptAt, time.Minute)
+	}
+}
+
+func (s *NotifierTestSuite) TestNotifyOrder_SkipsMissingAddressesAndDisabledChannels() {
+	// Arrange: SMS is disabled, and the unknown locale falls back to the default
+	notifier := s.newNotifier(s.email)
+	s.notificationRepo.EXPECT().ExistsForOrder(uint(1), entity.NotifyOrderCancelled).Return(false, nil)
+	s.userRepo.EXPECT().FindByUID("test-user-123").Return(customer("de", "+22670000000"), nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(4)).Return(&entity.PrintCenter{ID: 4, Name: "Copy Center"}, nil)
+	s.notificationRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(notifications []entity.Notification) error {
+		s.Require().Len(notifications, 1)
+		s.Equal(entity.ChannelEmail, notifications[0].Channel)
+		s.Equal("en", notifications[0].Locale)
+		s.Equal("Order ABC123 cancelled", notifications[0].Subject)
+		return nil
+	})
+
+	// Act
+	err := notifier.NotifyOrder(service.OrderNotification{Event: entity.NotifyOrderCancelled, Order: paidOrder()})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *NotifierTestSuite) TestNotifyOrder_ExpiryWarningGivesDeadline() {
+	// Arrange
+	expiresAt := time.Date(2025, time.March, 14, 18, 30, 0, 0, time.Local)
+	s.notificationRepo.EXPECT().ExistsForOrder(uint(1), entity.NotifyOrderExpiringSoon).Return(false, nil)
+	s.userRepo.EXPECT().FindByUID("test-user-123").Return(customer("", ""), nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(4)).Return(&entity.PrintCenter{ID: 4, Name: "Copy Center"}, nil)
+	s.notificationRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(notifications []entity.Notification) error {
+		s.Require().Len(notifications, 1)
+		s.Contains(notifications[0].Body, "will be cancelled on Mar 14 at 18:30")
+		return nil
+	})
+
+	// Act
+	err := s.notifier.NotifyOrder(service.OrderNotification{Event: entity.NotifyOrderExpiringSoon, Order: paidOrder(), ExpiresAt: &expiresAt})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *NotifierTestSuite) TestNotifyOrder_EveryEventIsTranslated() {
+	events := []entity.NotificationEvent{
+		entity.NotifyOrderPaid,
+		entity.NotifyOrderReadyForPickup,
+		entity.NotifyOrderExpiringSoon,
+		entity.NotifyOrderCancelled,
+		entity.NotifyCenterApproved,
+	}
+	for _, locale := range []string{"en", "fr"} {
+		for _, event := range events {
+			// Arrange
+			s.notificationRepo.EXPECT().ExistsForOrder(uint(1), event).Return(false, nil)
+			s.userRepo.EXPECT().FindByUID("test-user-123").Return(customer(locale, ""), nil)
+			s.printCenterRepo.EXPECT().FindByID(uint(4)).Return(&entity.PrintCenter{ID: 4, Name: "Copy Center"}, nil)
+			s.notificationRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(notifications []entity.Notification) error {
+				s.Equal(locale, notifications[0].Locale, event)
+				s.NotEmpty(notifications[0].Subject, event)
+				s.Contains(notifications[0].Body, "Copy Center", event)
+				return nil
+			})
+
+			// Act
+			err := s.notifier.NotifyOrder(service.OrderNotification{Event: event, Order: paidOrder()})
+
+			// Assert
+			s.NoError(err, "%s %s", locale, event)
+		}
+	}
+}
+
+func (s *NotifierTestSuite) TestNotifyOrder_AlreadyNotified() {
+	// Arrange
+	s.notificationRepo.EXPECT().ExistsForOrder(uint(1), entity.NotifyOrderPaid).Return(true, nil)
+
+	// Act
+	err := s.notifier.NotifyOrder(service.OrderNotification{Event: entity.NotifyOrderPaid, Order: paidOrder()})
+
+	// Assert
+	s.NoError(err)
+}
+
+// ============================================================================
+// NotifyCenter Tests
+// ============================================================================
+
+func (s *NotifierTestSuite) TestNotifyCenter_WritesToCenterInOwnerLocale() {
+	// Arrange
+	center := &entity.PrintCenter{ID: 4, Name: "Copy Center", Email: "contact@copy.example", PhoneNumber: "+22671000000", OwnerUID: "owner-123"}
+	s.userRepo.EXPECT().FindByUID("owner-123").Return(&entity.User{UID: "owner-123", FirstName: "Issa", Locale: "fr"}, nil)
+	s.notificationRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(notifications []entity.Notification) error {
+		s.Require().Len(notifications, 2)
+		s.Equal("contact@copy.example", notifications[0].Recipient)
+		s.Equal("Copy Center est validé", notifications[0].Subject)
+		s.Equal("+22671000000", notifications[1].Recipient)
+		for _, notification := range notifications {
+			s.Equal(entity.NotifyCenterApproved, notification.Event)
+			s.Equal("owner-123", notification.UserUID)
+			s.Nil(notification.OrderID)
+			s.Equal(uint(4), *notification.PrintCenterID)
+		}
+		return nil
+	})
+
+	// Act
+	err := s.notifier.NotifyCenter(entity.NotifyCenterApproved, center)
+
+	// Assert
+	s.NoError(err)
+}
+
+// ============================================================================
+// DeliverDue Tests
+// ============================================================================
+
+func (s *NotifierTestSuite) TestDeliverDue_RecordsOutcomes() {
+	// Arrange
+	due := []entity.Notification{
+		{ID: 1, Channel: entity.ChannelEmail, Recipient: "awa@example.com", Subject: "Hi", Body: "Paid"},
+		{ID: 2, Channel: entity.ChannelSMS, Recipient: "+22670000000", Body: "Paid", Attempts: 2},
+		{ID: 3, Channel: entity.ChannelSMS, Recipient: "+22670000001", Body: "Paid", Attempts: 5},
+	}
+	s.notificationRepo.EXPECT().FindDue(gomock.Any(), gomock.Any()).Return(due, nil)
+	s.email.EXPECT().Send(gomock.Any(), service.NotificationMessage{To: "awa@example.com", Subject: "Hi", Body: "Paid"}).Return(nil)
+	s.sms.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("provider unavailable")).Times(2)
+
+	s.notificationRepo.EXPECT().Update(uint(1), gomock.Any()).DoAndReturn(func(id uint, updates map[string]any) error {
+		s.Equal(entity.NotificationSent, updates["status"])
+		s.Equal(1, updates["attempts"])
+		s.NotNil(updates["sent_at"])
+		return nil
+	})
+	s.notificationRepo.EXPECT().Update(uint(2), gomock.Any()).DoAndReturn(func(id uint, updates map[string]any) error {
+		s.NotContains(updates, "status")
+		s.Equal(3, updates["attempts"])
+		s.Equal("provider unavailable", updates["last_error"])
+		// Third attempt failed: retried in 4 minutes
+		s.WithinDuration(time.Now().Add(4*time.Minute), updates["next_attempt_at"].(time.Time), 5*time.Second)
+		return nil
+	})
+	s.notificationRepo.EXPECT().Update(uint(3), gomock.Any()).DoAndReturn(func(id uint, updates map[string]any) error {
+		s.Equal(entity.NotificationFailed, updates["status"])
+		s.Equal(6, updates["attempts"])
+		return nil
+	})
+
+	// Act
+	report, err := s.notifier.DeliverDue(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(1, report.Sent)
+	s.Equal(1, report.Retried)
+	s.Equal(1, report.Failed)
+	s.Require().Len(report.Failures, 1)
+	s.Contains(report.Failures[0], "notification 3")
+}
+
+func (s *NotifierTestSuite) TestDeliverDue_ChannelWithoutProvider() {
+	// Arrange: SMS was disabled after the notification was queued
+	notifier := s.newNotifier(s.email)
+	s.notificationRepo.EXPECT().FindDue(gomock.Any(), gomock.Any()).Return([]entity.Notification{{ID: 1, Channel: entity.ChannelSMS}}, nil)
+	s.notificationRepo.EXPECT().Update(uint(1), gomock.Any()).DoAndReturn(func(id uint, updates map[string]any) error {
+		s.Contains(updates["last_error"], "no provider enabled for channel sms")
+		return nil
+	})
+
+	// Act
+	report, err := notifier.DeliverDue(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(1, report.Retried)
+}
+
+// ============================================================================
+// GetOrderNotifications Tests
+// ============================================================================
+
+func (s *NotifierTestSuite) TestGetOrderNotifications_Success() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(paidOrder(), nil)
+	s.notificationRepo.EXPECT().List(gomock.Any()).DoAndReturn(func(q repository.ListQuery) ([]entity.Notification, repository.PageInfo, error) {
+		s.Equal(uint(1), q.Filter.OrderID)
+		s.Equal([]string{"FAILED"}, q.Filter.Statuses)
+		return []entity.Notification{{ID: 7}}, repository.PageInfo{Total: 1}, nil
+	})
+
+	// Act
+	notifications, info, err := s.notifier.GetOrderNotifications(1, entity.Actor{UID: "test-user-123", Role: entity.RoleUser}, dto.ListRequest{Status: []string{"failed"}})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Len(notifications, 1)
+	s.Equal(int64(1), info.Total)
+}
+
+func (s *NotifierTestSuite) TestGetOrderNotifications_CenterManagerDenied() {
+	// Arrange: notifications hold the contact details of the customer
+	centerID := uint(4)
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(paidOrder(), nil)
+
+	// Act
+	_, _, err := s.notifier.GetOrderNotifications(1, entity.Actor{UID: "manager-123", Role: entity.RoleManager, CenterID: &centerID}, dto.ListRequest{})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+// ============================================================================
+// Sender Tests
+// ============================================================================
+
+func (s *NotifierTestSuite) TestLogSender_AppendsToFile() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "notifications.log")
+	senders, err := service.GetNotificationSenders(config.NotificationConfig{
+		EmailProvider: config.NotificationProviderLog,
+		SMSProvider:   config.NotificationProviderNone,
+		LogFile:       path,
+	}, zap.NewNop())
+	s.Require().NoError(err)
+	s.Require().Len(senders, 1)
+
+	// Act
+	err = senders[0].Send(context.Background(), service.NotificationMessage{To: "awa@example.com", Subject: "Hi", Body: "Paid"})
+
+	// Assert
+	s.Require().NoError(err)
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	var line map[string]any
+	s.Require().NoError(json.Unmarshal(content, &line))
+	s.Equal("email", line["channel"])
+	s.Equal("awa@example.com", line["to"])
+	s.Equal("Paid", line["body"])
+}
+
+func (s *NotifierTestSuite) TestSMSHTTPSender_PostsMessage() {
+	// Arrange
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer sms-key", r.Header.Get("Authorization"))
+		s.NoError(json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	sender := service.NewSMSHTTPSender(config.SMSConfig{URL: server.URL, APIKey: "sms-key", Sender: "Printly"})
+
+	// Act
+	err := sender.Send(context.Background(), service.NotificationMessage{To: "+22670000000", Body: "Paid"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"from": "Printly", "to": "+22670000000", "message": "Paid"}, received)
+}
+
+func (s *NotifierTestSuite) TestSMSHTTPSender_ProviderError() {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid number", http.StatusBadRequest)
+	}))
+	defer server.Close()
+	sender := service.NewSMSHTTPSender(config.SMSConfig{URL: server.URL})
+
+	// Act
+	err := sender.Send(context.Background(), service.NotificationMessage{To: "+1", Body: "Paid"})
+
+	// Assert
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400 Bad Request: invalid number")
+}
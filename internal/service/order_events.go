@@ -0,0 +1,150 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+//go:generate mockgen -destination=../mocks/mock_order_event_bus.go -package=mocks github.com/kimbasn/printly/internal/service OrderEventBus
+
+// OrderEventBus delivers order events to the live streams of print centers within the process.
+// It retains the latest events so that a stream can resume after a reconnection.
+type OrderEventBus interface {
+	// Publish numbers the event and delivers it to the subscribers of its print center
+	Publish(event dto.OrderEvent)
+	// Subscribe follows the events of a print center published after the given event ID
+	Subscribe(centerID uint, lastEventID uint64) *OrderSubscription
+}
+
+const (
+	// DefaultOrderEventHistory is the number of events retained for resumed streams
+	DefaultOrderEventHistory = 1000
+	// orderSubscriptionBuffer is the number of events a subscriber may fall behind
+	// before it is dropped
+	orderSubscriptionBuffer = 64
+)
+
+// OrderSubscription is a live stream of the events of a print center.
+type OrderSubscription struct {
+	// Missed are the retained events published after the event ID given on subscription
+	Missed []dto.OrderEvent
+	// Events delivers the events published since the subscription. It is closed once the
+	// subscription is closed, or when the subscriber falls behind and should resume
+	// from the ID of the last event it received.
+	Events <-chan dto.OrderEvent
+
+	close func()
+}
+
+// Close stops the delivery of events to the subscription.
+func (s *OrderSubscription) Close() {
+	s.close()
+}
+
+type orderSubscriber struct {
+	centerID uint
+	events   chan dto.OrderEvent
+}
+
+type orderEventBus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []dto.OrderEvent
+	size        int
+	subscribers map[*orderSubscriber]struct{}
+}
+
+// NewOrderEventBus creates a new instance of OrderEventBus retaining the given number of events.
+func NewOrderEventBus(history int) OrderEventBus {
+	return &orderEventBus{
+		size:        max(history, 1),
+		subscribers: make(map[*orderSubscriber]struct{}),
+	}
+}
+
+// Publish never blocks: subscribers whose buffer is full are dropped, and pick up
+// the events they missed from the history when they resume.
+func (b *orderEventBus) Publish(event dto.OrderEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	if len(b.history) == b.size {
+		copy(b.history, b.history[1:])
+		b.history = b.history[:b.size-1]
+	}
+	b.history = append(b.history, event)
+
+	for sub := range b.subscribers {
+		if sub.centerID != event.PrintCenterID {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe replays the retained events after lastEventID. An ID the bus did not issue,
+// such as one from before a restart, replays all the retained events of the center.
+func (b *orderEventBus) Subscribe(centerID uint, lastEventID uint64) *OrderSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	missed := []dto.OrderEvent{}
+	if lastEventID > 0 {
+		if lastEventID > b.lastID {
+			lastEventID = 0
+		}
+		for _, event := range b.history {
+			if event.ID > lastEventID && event.PrintCenterID == centerID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	sub := &orderSubscriber{centerID: centerID, events: make(chan dto.OrderEvent, orderSubscriptionBuffer)}
+	b.subscribers[sub] = struct{}{}
+
+	return &OrderSubscription{
+		Missed: missed,
+		Events: sub.events,
+		close: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.remove(sub)
+		},
+	}
+}
+
+// remove drops a subscriber and closes its channel. It must be called with the lock held.
+func (b *orderEventBus) remove(sub *orderSubscriber) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// newOrderEvent describes the current state of an order for its live stream.
+func newOrderEvent(eventType dto.OrderEventType, order *entity.Order, from entity.OrderStatus) dto.OrderEvent {
+	return dto.OrderEvent{
+		Type:           eventType,
+		OrderID:        order.ID,
+		PrintCenterID:  order.PrintCenterID,
+		Code:           order.Code,
+		Status:         order.Status,
+		PreviousStatus: from,
+		TotalCost:      order.TotalCost,
+		Currency:       order.Currency,
+	}
+}
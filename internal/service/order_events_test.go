@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrderEventBusTestSuite struct {
+	suite.Suite
+	bus service.OrderEventBus
+}
+
+func (s *OrderEventBusTestSuite) SetupTest() {
+	s.bus = service.NewOrderEventBus(3)
+}
+
+func TestOrderEventBus(t *testing.T) {
+	suite.Run(t, new(OrderEventBusTestSuite))
+}
+
+// publish publishes an event for an order of the given center.
+func (s *OrderEventBusTestSuite) publish(centerID, orderID uint) {
+	s.bus.Publish(dto.OrderEvent{Type: dto.OrderEventCreated, OrderID: orderID, PrintCenterID: centerID})
+}
+
+// eventOrders returns the order IDs of the given events.
+func eventOrders(events []dto.OrderEvent) []uint {
+	ids := []uint{}
+	for _, event := range events {
+		ids = append(ids, event.OrderID)
+	}
+	return ids
+}
+
+// ============================================================================
+// Publish Tests
+// ============================================================================
+
+func (s *OrderEventBusTestSuite) TestPublish_DeliversToCenterSubscribers() {
+	// Arrange
+	sub := s.bus.Subscribe(1, 0)
+	defer sub.Close()
+	other := s.bus.Subscribe(2, 0)
+	defer other.Close()
+
+	// Act
+	s.publish(1, 10)
+
+	// Assert
+	event := <-sub.Events
+	s.Equal(uint64(1), event.ID)
+	s.Equal(uint(10), event.OrderID)
+	s.False(event.OccurredAt.IsZero())
+	s.Empty(sub.Missed)
+	s.Empty(other.Events)
+}
+
+func (s *OrderEventBusTestSuite) TestPublish_DropsSlowSubscribers() {
+	// Arrange
+	bus := service.NewOrderEventBus(service.DefaultOrderEventHistory)
+	sub := bus.Subscribe(1, 0)
+	defer sub.Close()
+
+	// Act: publish more events than the subscriber buffers
+	var received []dto.OrderEvent
+	for i := range 100 {
+		bus.Publish(dto.OrderEvent{OrderID: uint(i + 1), PrintCenterID: 1})
+	}
+	for event := range sub.Events {
+		received = append(received, event)
+	}
+
+	// Assert: the channel is closed, and the events after the last one received can be resumed
+	s.NotEmpty(received)
+	s.Less(len(received), 100)
+	resumed := bus.Subscribe(1, received[len(received)-1].ID)
+	defer resumed.Close()
+	s.Len(resumed.Missed, 100-len(received))
+}
+
+// ============================================================================
+// Subscribe Tests
+// ============================================================================
+
+func (s *OrderEventBusTestSuite) TestSubscribe_ResumesAfterLastEventID() {
+	// Arrange
+	s.publish(1, 10)
+	s.publish(2, 20)
+	s.publish(1, 11)
+
+	// Act
+	sub := s.bus.Subscribe(1, 1)
+	defer sub.Close()
+
+	// Assert
+	s.Equal([]uint{11}, eventOrders(sub.Missed))
+}
+
+func (s *OrderEventBusTestSuite) TestSubscribe_HistoryIsBounded() {
+	// Arrange
+	for i := range 5 {
+		s.publish(1, uint(10+i))
+	}
+
+	// Act
+	sub := s.bus.Subscribe(1, 1)
+	defer sub.Close()
+
+	// Assert: only the last 3 events are retained
+	s.Equal([]uint{12, 13, 14}, eventOrders(sub.Missed))
+}
+
+func (s *OrderEventBusTestSuite) TestSubscribe_UnknownEventIDReplaysHistory() {
+	// Arrange: an ID issued before a restart
+	s.publish(1, 10)
+	s.publish(1, 11)
+
+	// Act
+	sub := s.bus.Subscribe(1, 42)
+	defer sub.Close()
+
+	// Assert
+	s.Equal([]uint{10, 11}, eventOrders(sub.Missed))
+}
+
+func (s *OrderEventBusTestSuite) TestSubscribe_CloseStopsDelivery() {
+	// Arrange
+	sub := s.bus.Subscribe(1, 0)
+
+	// Act
+	sub.Close()
+	s.publish(1, 10)
+
+	// Assert
+	_, open := <-sub.Events
+	s.False(open)
+	s.NotPanics(sub.Close)
+}
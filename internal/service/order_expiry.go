@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_order_expirer.go -package=mocks github.com/kimbasn/printly/internal/service OrderExpirer
+
+// OrderExpirer cancels the orders left unpaid for too long and releases their documents.
+// Customers are warned before their order expires.
+type OrderExpirer interface {
+	ExpireStaleOrders(ctx context.Context) (*dto.OrderExpiryReport, error)
+}
+
+// ExpiryPolicy gives how long an order may stay in each status before it is cancelled.
+// Statuses missing from the policy never expire.
+type ExpiryPolicy map[entity.OrderStatus]time.Duration
+
+// NewExpiryPolicy builds the policy configured for orders awaiting their documents or their payment.
+func NewExpiryPolicy(cfg config.OrderExpiryConfig) ExpiryPolicy {
+	return ExpiryPolicy{
+		entity.StatusAwaitingDocument: cfg.AwaitingDocumentTTL,
+		entity.StatusPendingPayment:   cfg.PendingPaymentTTL,
+	}
+}
+
+// expiryBatchSize bounds the number of orders expired per status in one run.
+const expiryBatchSize = 200
+
+type orderExpirer struct {
+	orderRepo    repository.OrderRepository
+	stateMachine OrderStateMachine
+	purger       DocumentPurger
+	notifier     Notifier
+	policy       ExpiryPolicy
+	warnBefore   time.Duration
+	logger       *zap.Logger
+}
+
+// NewOrderExpirer creates a new instance of OrderExpirer. Customers are warned the given
+// duration before their order expires; a zero duration disables the warnings.
+func NewOrderExpirer(orderRepo repository.OrderRepository, stateMachine OrderStateMachine, purger DocumentPurger, notifier Notifier, policy ExpiryPolicy, warnBefore time.Duration, logger *zap.Logger) OrderExpirer {
+	return &orderExpirer{
+		orderRepo:    orderRepo,
+		stateMachine: stateMachine,
+		purger:       purger,
+		notifier:     notifier,
+		policy:       policy,
+		warnBefore:   warnBefore,
+		logger:       logger,
+	}
+}
+
+// ExpireStaleOrders cancels the stale orders of every status in the policy, then deletes their files.
+// Orders that changed status meanwhile, e.g. paid during the run, are left untouched.
+func (e *orderExpirer) ExpireStaleOrders(ctx context.Context) (*dto.OrderExpiryReport, error) {
+	report := &dto.OrderExpiryReport{ExpiredOrders: []uint{}}
+
+	// Iterate in a stable order so that runs are reproducible
+	statuses := make([]entity.OrderStatus, 0, len(e.policy))
+	for status := range e.policy {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+
+	for _, status := range statuses {
+		ttl := e.policy[status]
+		orders, err := e.orderRepo.FindStale(status, time.Now().Add(-ttl), expiryBatchSize)
+		if err != nil {
+			return report, err
+		}
+
+		for i := range orders {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			e.expire(&orders[i], ttl, report)
+		}
+
+		if err := e.warn(ctx, status, ttl); err != nil {
+			return report, err
+		}
+	}
+
+	e.logger.Info("Stale orders expired",
+		zap.Int("expired", len(report.ExpiredOrders)),
+		zap.Int("documentsDeleted", report.DeletedDocuments),
+		zap.Int("failures", len(report.Failures)))
+	return report, nil
+}
+
+// expire cancels one order and purges its documents, recording the outcome in the report
+func (e *orderExpirer) expire(order *entity.Order, ttl time.Duration, report *dto.OrderExpiryReport) {
+	reason := fmt.Sprintf("expired after %s in %s", ttl, order.Status)
+	if err := e.stateMachine.Transition(order, entity.StatusCancelled, entity.SystemActor, reason, nil); err != nil {
+		if errors.Is(err, ierrors.ErrOrderStatusConflict) {
+			return
+		}
+		report.Failures = append(report.Failures, fmt.Sprintf("order %d: %s", order.ID, err.Error()))
+		return
+	}
+	report.ExpiredOrders = append(report.ExpiredOrders, order.ID)
+
+	purged, err := e.purger.Purge(order)
+	report.DeletedDocuments += purged
+	if err != nil {
+		report.Failures = append(report.Failures, fmt.Sprintf("order %d: %s", order.ID, err.Error()))
+	}
+}
+
+// warn notifies the customers of the orders that will expire within the warning period.
+// Orders are warned once, and only when their TTL is longer than the warning period.
+func (e *orderExpirer) warn(ctx context.Context, status entity.OrderStatus, ttl time.Duration) error {
+	if e.warnBefore <= 0 || ttl <= e.warnBefore {
+		return nil
+	}
+
+	orders, err := e.orderRepo.FindStale(status, time.Now().Add(e.warnBefore-ttl), expiryBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		expiresAt := orders[i].UpdatedAt.Add(ttl)
+		notification := OrderNotification{Event: entity.NotifyOrderExpiringSoon, Order: &orders[i], ExpiresAt: &expiresAt}
+		if err := e.notifier.NotifyOrder(notification); err != nil {
+			e.logger.Error("Failed to warn of order expiry", zap.Uint("orderID", orders[i].ID), zap.Error(err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrderExpiryTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	orderRepo      *mocks.MockOrderRepository
+	storageService *mocks.MockStorageService
+	notifier       *mocks.MockNotifier
+	expirer        service.OrderExpirer
+}
+
+func (s *OrderExpiryTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.storageService = mocks.NewMockStorageService(s.ctrl)
+	s.storageService.EXPECT().Type().Return(service.StorageTypeLocal).AnyTimes()
+	s.notifier = mocks.NewMockNotifier(s.ctrl)
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).Return(nil).AnyTimes()
+	logger := zap.NewNop()
+
+	s.expirer = service.NewOrderExpirer(
+		s.orderRepo,
+		service.NewOrderStateMachine(s.orderRepo, service.NewOrderEventBus(service.DefaultOrderEventHistory), s.notifier, logger),
+		service.NewDocumentPurger(s.orderRepo, s.storageService, service.NewDeletionReceiptSigner([]byte("secret")), logger),
+		s.notifier,
+		service.ExpiryPolicy{entity.StatusPendingPayment: time.Hour},
+		0,
+		logger,
+	)
+}
+
+func (s *OrderExpiryTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrderExpiry(t *testing.T) {
+	suite.Run(t, new(OrderExpiryTestSuite))
+}
+
+func unpaidOrder(id uint, paths ...string) entity.Order {
+	order := entity.Order{ID: id, Status: entity.StatusPendingPayment}
+	for i, path := range paths {
+		order.Documents = append(order.Documents, entity.Document{ID: id*10 + uint(i), OrderID: id, StoragePath: path})
+	}
+	return order
+}
+
+// ============================================================================
+// ExpireStaleOrders Tests
+// ============================================================================
+
+func (s *OrderExpiryTestSuite) TestExpireStaleOrders_CancelsAndPurges() {
+	// Arrange
+	orders := []entity.Order{unpaidOrder(1, "a.pdf", "b.pdf")}
+	s.orderRepo.EXPECT().
+		FindStale(entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(status entity.OrderStatus, before time.Time, limit int) ([]entity.Order, error) {
+			s.WithinDuration(time.Now().Add(-time.Hour), before, time.Minute)
+			return orders, nil
+		})
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusCancelled, updates["status"])
+			s.Equal(entity.SystemActor.UID, history.ActorUID)
+			s.Contains(history.Reason, "expired after 1h0m0s")
+			return nil
+		})
+	s.storageService.EXPECT().DeleteFile("a.pdf").Return(nil)
+	// Already gone files count as deleted
+	s.storageService.EXPECT().DeleteFile("b.pdf").Return(fmt.Errorf("%w: b.pdf", service.ErrFileNotFound))
+	s.orderRepo.EXPECT().MarkDocumentDeleted(receiptOf(uint(10))).Return(nil)
+	s.orderRepo.EXPECT().MarkDocumentDeleted(receiptOf(uint(11))).Return(nil)
+
+	// Act
+	report, err := s.expirer.ExpireStaleOrders(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]uint{1}, report.ExpiredOrders)
+	s.Equal(2, report.DeletedDocuments)
+	s.Empty(report.Failures)
+}
+
+func (s *OrderExpiryTestSuite) TestExpireStaleOrders_SkipsOrdersPaidMeanwhile() {
+	// Arrange
+	s.orderRepo.EXPECT().FindStale(entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return([]entity.Order{unpaidOrder(1, "a.pdf")}, nil)
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return(repository.ErrStaleStatus)
+
+	// Act
+	report, err := s.expirer.ExpireStaleOrders(context.Background())
+
+	// Assert: the files of the order are kept
+	s.Require().NoError(err)
+	s.Empty(report.ExpiredOrders)
+	s.Empty(report.Failures)
+}
+
+func (s *OrderExpiryTestSuite) TestExpireStaleOrders_ReportsStorageFailures() {
+	// Arrange
+	s.orderRepo.EXPECT().FindStale(entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return([]entity.Order{unpaidOrder(1, "a.pdf")}, nil)
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return(nil)
+	s.storageService.EXPECT().DeleteFile("a.pdf").Return(errors.New("bucket unavailable"))
+
+	// Act
+	report, err := s.expirer.ExpireStaleOrders(context.Background())
+
+	// Assert: the document is not marked as deleted
+	s.Require().NoError(err)
+	s.Equal([]uint{1}, report.ExpiredOrders)
+	s.Equal(0, report.DeletedDocuments)
+	s.Require().Len(report.Failures, 1)
+	s.Contains(report.Failures[0], "bucket unavailable")
+}
+
+func (s *OrderExpiryTestSuite) TestExpireStaleOrders_UsesTTLPerStatus() {
+	// Arrange
+	logger := zap.NewNop()
+	expirer := service.NewOrderExpirer(
+		s.orderRepo,
+		service.NewOrderStateMachine(s.orderRepo, service.NewOrderEventBus(service.DefaultOrderEventHistory), s.notifier, logger),
+		service.NewDocumentPurger(s.orderRepo, s.storageService, service.NewDeletionReceiptSigner([]byte("secret")), logger),
+		s.notifier,
+		service.ExpiryPolicy{
+			entity.StatusAwaitingDocument: 2 * time.Hour,
+			entity.StatusPendingPayment:   24 * time.Hour,
+		},
+		0,
+		logger,
+	)
+	expectStale := func(status entity.OrderStatus, ttl time.Duration) {
+		s.orderRepo.EXPECT().
+			FindStale(status, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(status entity.OrderStatus, before time.Time, limit int) ([]entity.Order, error) {
+				s.WithinDuration(time.Now().Add(-ttl), before, time.Minute)
+				return nil, nil
+			})
+	}
+	expectStale(entity.StatusAwaitingDocument, 2*time.Hour)
+	expectStale(entity.StatusPendingPayment, 24*time.Hour)
+
+	// Act
+	report, err := expirer.ExpireStaleOrders(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Empty(report.ExpiredOrders)
+}
+
+func (s *OrderExpiryTestSuite) TestExpireStaleOrders_WarnsBeforeExpiry() {
+	// Arrange: orders awaiting their documents expire before the warning, so they are not warned
+	logger := zap.NewNop()
+	notifier := mocks.NewMockNotifier(s.ctrl)
+	expirer := service.NewOrderExpirer(
+		s.orderRepo,
+		service.NewOrderStateMachine(s.orderRepo, service.NewOrderEventBus(service.DefaultOrderEventHistory), notifier, logger),
+		service.NewDocumentPurger(s.orderRepo, s.storageService, service.NewDeletionReceiptSigner([]byte("secret")), logger),
+		notifier,
+		service.ExpiryPolicy{
+			entity.StatusAwaitingDocument: time.Hour,
+			entity.StatusPendingPayment:   24 * time.Hour,
+		},
+		2*time.Hour,
+		logger,
+	)
+	updatedAt := time.Now().Add(-23 * time.Hour)
+	expiring := unpaidOrder(2)
+	expiring.UpdatedAt = updatedAt
+
+	gomock.InOrder(
+		s.orderRepo.EXPECT().FindStale(entity.StatusAwaitingDocument, gomock.Any(), gomock.Any()).Return(nil, nil),
+		s.orderRepo.EXPECT().FindStale(entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return(nil, nil),
+		s.orderRepo.EXPECT().
+			FindStale(entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(status entity.OrderStatus, before time.Time, limit int) ([]entity.Order, error) {
+				s.WithinDuration(time.Now().Add(-22*time.Hour), before, time.Minute)
+				return []entity.Order{expiring}, nil
+			}),
+	)
+	notifier.EXPECT().NotifyOrder(gomock.Any()).DoAndReturn(func(n service.OrderNotification) error {
+		s.Equal(entity.NotifyOrderExpiringSoon, n.Event)
+		s.Equal(uint(2), n.Order.ID)
+		s.Require().NotNil(n.ExpiresAt)
+		s.True(updatedAt.Add(24 * time.Hour).Equal(*n.ExpiresAt))
+		return nil
+	})
+
+	// Act
+	report, err := expirer.ExpireStaleOrders(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Empty(report.ExpiredOrders)
+	s.Empty(report.Failures)
+}
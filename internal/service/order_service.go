@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
-
+	"net/http"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -22,76 +25,546 @@ import (
 // OrderService defines the interface for order-related business logic.
 type OrderService interface {
 	CreateOrder(userUID string, centerID uint, req dto.CreateOrderRequest) (*entity.Order, error)
+	CreateDirectUploadOrder(userUID string, centerID uint, req dto.DirectUploadOrderRequest) (*dto.DirectUploadOrder, error)
+	ConfirmDocumentUploads(orderID uint, actor entity.Actor) (*entity.Order, error)
+	CheckDocumentUpload(storagePath string) error
 	GetOrderByID(id uint) (*entity.Order, error)
 	GetOrderByCode(code string) (*entity.Order, error)
-	GetOrdersForCenter(centerID uint) ([]entity.Order, error)
-	GetOrdersForUser(userUID string) ([]entity.Order, error)
-	GetAllOrders() ([]entity.Order, error)
-	UpdateOrderStatus(orderID uint, status entity.OrderStatus, updatedBy string) error
-	CancelOrder(orderID uint, userUID string) error
+	GetOrdersForCenter(centerID uint, req dto.ListRequest, actor entity.Actor) ([]entity.Order, dto.PageInfo, error)
+	GetOrdersForUser(userUID string, req dto.ListRequest) ([]dto.UserOrder, dto.PageInfo, error)
+	GetUserOrder(orderID uint, actor entity.Actor) (*dto.UserOrder, error)
+	GetAllOrders(req dto.ListRequest) ([]entity.Order, dto.PageInfo, error)
+	UpdateOrderStatus(orderID uint, status entity.OrderStatus, actor entity.Actor, reason string) error
+	CancelOrder(orderID uint, actor entity.Actor, reason string) (*dto.CancelOrderResponse, error)
+	GetOrderHistory(orderID uint, actor entity.Actor) ([]entity.OrderStatusHistory, error)
+	GetPurgeReport(orderID uint, actor entity.Actor) (*dto.DocumentPurgeReport, error)
+	GetDeletionReceipt(orderID, documentID uint, actor entity.Actor) (*entity.DeletionReceipt, error)
+	VerifyDeletionReceipt(receipt *entity.DeletionReceipt) bool
 	DeleteOrder(orderID uint) error
 	CalculateOrderCost(orderID uint) (int64, error)
+	QuoteOrder(centerID uint, req dto.QuoteRequest) (*dto.Quote, error)
+	VerifyPickup(centerID uint, req dto.VerifyPickupRequest, actor entity.Actor) (*entity.Order, error)
+	GetPickupToken(orderID uint, actor entity.Actor) (token string, expiresAt time.Time, err error)
+	ScheduleOrder(orderID uint, req dto.ScheduleOrderRequest, actor entity.Actor) (*entity.Order, error)
+	SubscribeCenterOrders(centerID uint, lastEventID uint64, actor entity.Actor) (*OrderSubscription, error)
+}
+
+// uploadURLTTL is how long the signed upload URLs of a direct upload order stay valid
+const uploadURLTTL = 10 * time.Minute
+
+// scheduleTargets gives the status a paid order moves to once its pickup is scheduled.
+var scheduleTargets = map[entity.PrintMode]entity.OrderStatus{
+	// The center prints ahead of the pickup time
+	entity.PrePrint: entity.StatusReadyToPrint,
+	// The center prints once the customer shows up with the pickup code
+	entity.PrintUponArrival: entity.StatusAwaitingUser,
+}
+
+// pickupTargets gives the status an order moves to once its pickup code is verified at the counter.
+var pickupTargets = map[entity.OrderStatus]entity.OrderStatus{
+	// Printed ahead of time, handed over to the customer
+	entity.StatusReadyForPickup: entity.StatusCompleted,
+	// Printed upon arrival, the customer is now at the counter
+	entity.StatusAwaitingUser: entity.StatusPrinting,
+}
+
+// cancellableStatuses lists the statuses each role may cancel an order from.
+// Admins may cancel any order the lifecycle allows.
+var cancellableStatuses = map[entity.Role][]entity.OrderStatus{
+	// Customers may change their mind until printing starts
+	entity.RoleUser: {
+		entity.StatusCreated,
+		entity.StatusAwaitingDocument,
+		entity.StatusPendingPayment,
+		entity.StatusPaid,
+		entity.StatusAwaitingUser,
+		entity.StatusReadyToPrint,
+	},
+	// Managers may turn down paid orders their center can not print
+	entity.RoleManager: {entity.StatusPaid, entity.StatusAwaitingUser, entity.StatusReadyToPrint},
 }
 
 type orderService struct {
 	orderRepo       repository.OrderRepository
 	printCenterRepo repository.PrintCenterRepository
 	userRepo        repository.UserRepository
+	stateMachine    OrderStateMachine
+	storageService  StorageService
+	inspector       DocumentInspector
+	pricing         PricingEngine
+	pickupThrottle  PickupThrottle
+	pickupSigner    PickupTokenSigner
+	receiptSigner   DeletionReceiptSigner
+	payments        PaymentService
+	purger          DocumentPurger
+	events          OrderEventBus
 	logger          *zap.Logger
 }
 
 // NewOrderService creates a new instance of OrderService.
-func NewOrderService(orderRepo repository.OrderRepository, printCenterRepo repository.PrintCenterRepository, userRepo repository.UserRepository, logger *zap.Logger) OrderService {
+func NewOrderService(orderRepo repository.OrderRepository, printCenterRepo repository.PrintCenterRepository, userRepo repository.UserRepository, stateMachine OrderStateMachine, storageService StorageService, inspector DocumentInspector, pricing PricingEngine, pickupThrottle PickupThrottle, pickupSigner PickupTokenSigner, receiptSigner DeletionReceiptSigner, payments PaymentService, purger DocumentPurger, events OrderEventBus, logger *zap.Logger) OrderService {
 	return &orderService{
 		orderRepo:       orderRepo,
 		printCenterRepo: printCenterRepo,
 		userRepo:        userRepo,
+		stateMachine:    stateMachine,
+		storageService:  storageService,
+		inspector:       inspector,
+		pricing:         pricing,
+		pickupThrottle:  pickupThrottle,
+		pickupSigner:    pickupSigner,
+		receiptSigner:   receiptSigner,
+		payments:        payments,
+		purger:          purger,
+		events:          events,
 		logger:          logger,
 	}
 }
 
 // CreateOrder handles the business logic for creating a new order.
-func (s *orderService) CreateOrder(userUID string, centerID uint, req dto.CreateOrderRequest) (*entity.Order, error) {
+// The documents of the request must already be uploaded. If the order can not be created,
+// the uploaded files are deleted from storage.
+func (s *orderService) CreateOrder(userUID string, centerID uint, req dto.CreateOrderRequest) (order *entity.Order, err error) {
 	s.logger.Info("Creating order", zap.String("userUID", userUID), zap.Uint("centerID", centerID))
 
+	defer func() {
+		if err != nil {
+			s.rollbackUploads(req.Documents)
+		}
+	}()
+
 	// 1. Verify print center exists and is operational
-	center, err := s.printCenterRepo.FindByID(centerID)
+	center, err := s.getOperationalCenter(centerID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ierrors.ErrPrintCenterNotFound
+		return nil, err
+	}
+
+	// 2. Build the documents and check the selected pages against their page count
+	now := time.Now()
+	documents := make([]entity.Document, len(req.Documents))
+	for i, doc := range req.Documents {
+		documents[i] = entity.Document{
+			FileName:      doc.FileName,
+			MimeType:      doc.MimeType,
+			StoragePath:   doc.StoragePath,
+			Size:          doc.Size,
+			UploadedAt:    &now,
+			PageCount:     doc.PageCount,
+			PageWidth:     doc.PageWidth,
+			PageHeight:    doc.PageHeight,
+			Encrypted:     doc.Encrypted,
+			ContentSHA256: doc.SHA256,
+			Scan:          doc.Scan,
+			PrintMode:     doc.PrintMode,
+			PrintOptions:  doc.PrintOptions,
+		}
+		if err := documents[i].ResolvePages(); err != nil {
+			return nil, ierrors.NewWithCause(ierrors.InvalidArgument,
+				fmt.Sprintf("%s for document %s: %s", ierrors.ErrInvalidPageRange.Error(), doc.FileName, err.Error()), err)
 		}
-		return nil, fmt.Errorf("failed to verify print center: %w", err)
 	}
-	if center.Status != entity.StatusApproved {
-		return nil, ierrors.ErrPrintCenterNotOperational
+
+	// 3. Price the documents with the center's services
+	quote, err := s.pricing.Quote(center, documents)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Generate a unique pickup code
+	// 4. Generate a unique pickup code
 	code, err := s.generateUniquePickupCode(6)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate pickup code: %w", err)
 	}
 
-	// 3. Create and save the order
-	order := &entity.Order{
+	// 5. Issue the token the customer follows the order with
+	trackingToken, err := newTrackingToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Create and save the order together with its documents
+	order = &entity.Order{
 		UserUID:       userUID,
 		PrintCenterID: centerID,
 		Status:        entity.StatusPendingPayment,
+		TotalCost:     quote.Total,
+		Currency:      quote.Currency,
 		Code:          code,
+		TrackingToken: trackingToken,
 		CreatedBy:     userUID,
 		UpdatedBy:     userUID,
-		Documents:     make([]entity.Document, len(req.Documents)),
+		Documents:     documents,
 	}
 
 	if err := s.orderRepo.Save(order); err != nil {
 		return nil, fmt.Errorf("failed to save order: %w", err)
 	}
 
+	s.events.Publish(newOrderEvent(dto.OrderEventCreated, order, ""))
 	s.logger.Info("Order created successfully", zap.Uint("orderID", order.ID), zap.String("code", order.Code))
 
 	return order, nil
 }
 
+// CreateDirectUploadOrder creates an order from the metadata of its documents and signs an upload URL
+// for each of them, so the client uploads the files straight to storage. The order waits in
+// AWAITING_DOCUMENT until the uploads are confirmed, priced from the declared page counts until then.
+func (s *orderService) CreateDirectUploadOrder(userUID string, centerID uint, req dto.DirectUploadOrderRequest) (*dto.DirectUploadOrder, error) {
+	s.logger.Info("Creating direct upload order", zap.String("userUID", userUID), zap.Uint("centerID", centerID))
+
+	// 1. Verify print center exists and is operational
+	center, err := s.getOperationalCenter(centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Build the documents, each with the storage path its file is uploaded to
+	documents := make([]entity.Document, len(req.Documents))
+	for i, doc := range req.Documents {
+		storagePath, err := s.storageService.NewUploadPath(doc.FileName, userUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve storage path: %w", err)
+		}
+		documents[i] = entity.Document{
+			FileName:     doc.FileName,
+			MimeType:     doc.MimeType,
+			StoragePath:  storagePath,
+			Size:         doc.Size,
+			PageCount:    doc.PageCount,
+			PrintMode:    doc.PrintMode,
+			PrintOptions: doc.PrintOptions,
+		}
+		if err := documents[i].ResolvePages(); err != nil {
+			return nil, ierrors.NewWithCause(ierrors.InvalidArgument,
+				fmt.Sprintf("%s for document %s: %s", ierrors.ErrInvalidPageRange.Error(), doc.FileName, err.Error()), err)
+		}
+	}
+
+	// 3. Price the documents with the center's services
+	quote, err := s.pricing.Quote(center, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Sign the upload URLs, nothing is saved if one can not be signed
+	expiresAt := time.Now().Add(uploadURLTTL)
+	uploads := make([]dto.DocumentUpload, len(documents))
+	for i, doc := range documents {
+		signed, err := s.storageService.GetSignedUploadURL(doc.StoragePath, doc.MimeType, uploadURLTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign upload URL for document %s: %w", doc.FileName, err)
+		}
+		uploads[i] = dto.DocumentUpload{
+			FileName:  doc.FileName,
+			Method:    http.MethodPut,
+			URL:       signed.URL,
+			Headers:   signed.Headers,
+			ExpiresAt: expiresAt,
+		}
+	}
+
+	// 5. Generate a unique pickup code and the tracking token
+	code, err := s.generateUniquePickupCode(6)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pickup code: %w", err)
+	}
+	trackingToken, err := newTrackingToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Save the order, waiting for its documents
+	order := &entity.Order{
+		UserUID:       userUID,
+		PrintCenterID: centerID,
+		Status:        entity.StatusAwaitingDocument,
+		TotalCost:     quote.Total,
+		Currency:      quote.Currency,
+		Code:          code,
+		TrackingToken: trackingToken,
+		CreatedBy:     userUID,
+		UpdatedBy:     userUID,
+		Documents:     documents,
+	}
+
+	if err := s.orderRepo.Save(order); err != nil {
+		return nil, fmt.Errorf("failed to save order: %w", err)
+	}
+	for i := range uploads {
+		uploads[i].DocumentID = order.Documents[i].ID
+	}
+
+	s.events.Publish(newOrderEvent(dto.OrderEventCreated, order, ""))
+	s.logger.Info("Direct upload order created", zap.Uint("orderID", order.ID), zap.String("code", order.Code))
+
+	return &dto.DirectUploadOrder{
+		Order:   dto.CreatedOrder{Order: *order, TrackingToken: order.TrackingToken},
+		Uploads: uploads,
+	}, nil
+}
+
+// ConfirmDocumentUploads checks the files uploaded for an order awaiting its documents and moves
+// the order to PENDING_PAYMENT. Each file must have the declared size and type, and is inspected
+// like a file sent with the order. The order is then priced again from the real page counts.
+// Only the owner of the order can confirm its uploads.
+func (s *orderService) ConfirmDocumentUploads(orderID uint, actor entity.Actor) (*entity.Order, error) {
+	s.logger.Info("Confirming document uploads", zap.Uint("orderID", orderID), zap.String("actor", actor.UID))
+
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserUID != actor.UID {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+	if order.Status != entity.StatusAwaitingDocument {
+		return nil, fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotAwaitingDocument, order.Status)
+	}
+
+	center, err := s.getOperationalCenter(order.PrintCenterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Check every file against its declaration and read its pages
+	now := time.Now()
+	for i := range order.Documents {
+		doc := &order.Documents[i]
+		if err := s.inspectUpload(doc); err != nil {
+			if doc.Scan.Infected() {
+				s.failInfectedOrder(order, doc)
+			}
+			return nil, err
+		}
+		if err := doc.ResolvePages(); err != nil {
+			return nil, ierrors.NewWithCause(ierrors.InvalidArgument,
+				fmt.Sprintf("%s for document %s: %s", ierrors.ErrInvalidPageRange.Error(), doc.FileName, err.Error()), err)
+		}
+		doc.UploadedAt = &now
+	}
+
+	// 2. Price the documents from their real page counts
+	quote, err := s.pricing.Quote(center, order.Documents)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Record what was read from the files and move the order on, in a single transaction
+	documents := make(map[uint]map[string]any, len(order.Documents))
+	for _, doc := range order.Documents {
+		documents[doc.ID] = map[string]any{
+			"uploaded_at":    now,
+			"mime_type":      doc.MimeType,
+			"page_count":     doc.PageCount,
+			"page_width":     doc.PageWidth,
+			"page_height":    doc.PageHeight,
+			"encrypted":      doc.Encrypted,
+			"content_sha256": doc.ContentSHA256,
+			"scan_status":    doc.Scan.Status,
+			"scan_signature": doc.Scan.Signature,
+			"scan_engine":    doc.Scan.Engine,
+			"scan_time":      doc.Scan.Time,
+		}
+	}
+
+	updates := map[string]any{
+		"total_cost": quote.Total,
+		"currency":   quote.Currency,
+	}
+	if err := s.stateMachine.TransitionWithDocuments(order, entity.StatusPendingPayment, actor, "documents uploaded", updates, documents); err != nil {
+		return nil, err
+	}
+	order.TotalCost = quote.Total
+	order.Currency = quote.Currency
+
+	s.logger.Info("Document uploads confirmed", zap.Uint("orderID", orderID), zap.Int64("totalCost", quote.Total))
+	return order, nil
+}
+
+// CheckDocumentUpload tells whether a file may still be sent to the storage path of a document.
+// Files are only accepted while the order awaits its documents, never once the uploads are confirmed.
+func (s *orderService) CheckDocumentUpload(storagePath string) error {
+	doc, err := s.orderRepo.FindDocumentByStoragePath(storagePath)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ierrors.ErrInvalidUploadURL
+	}
+	if err != nil {
+		return err
+	}
+	if doc.Order.Status != entity.StatusAwaitingDocument {
+		return fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotAwaitingDocument, doc.Order.Status)
+	}
+	return nil
+}
+
+// inspectUpload checks the uploaded file of a document against its declared size and type,
+// then reads its pages, digest and scan verdict into the document. A file that does not match,
+// can not be printed or is infected is deleted, so the client may upload it again while its URL is valid.
+func (s *orderService) inspectUpload(doc *entity.Document) error {
+	stat, err := s.storageService.StatFile(doc.StoragePath)
+	if errors.Is(err, ErrFileNotFound) {
+		return ierrors.New(ierrors.FailedPrecondition,
+			fmt.Sprintf("%s: %s", ierrors.ErrDocumentNotUploaded.Error(), doc.FileName))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check upload of document %d: %w", doc.ID, err)
+	}
+
+	var mismatch string
+	switch {
+	case stat.Size != doc.Size:
+		mismatch = fmt.Sprintf("%d bytes uploaded, %d declared", stat.Size, doc.Size)
+	case stat.ContentType != "" && stat.ContentType != doc.MimeType:
+		mismatch = fmt.Sprintf("%s uploaded, %s declared", stat.ContentType, doc.MimeType)
+	}
+	if mismatch != "" {
+		s.discardUpload(doc)
+		return ierrors.New(ierrors.InvalidArgument,
+			fmt.Sprintf("%s %s: %s", ierrors.ErrUploadMismatch.Error(), doc.FileName, mismatch))
+	}
+
+	info, err := s.readUpload(doc)
+	if err != nil {
+		if info != nil {
+			// Infected files are rejected with their verdict
+			doc.Scan = info.Scan
+		}
+		var appErr *ierrors.AppError
+		if errors.As(err, &appErr) {
+			// Files that could not be scanned are kept for the client to confirm again
+			if !errors.Is(err, ierrors.ErrScannerUnavailable) {
+				s.discardUpload(doc)
+			}
+			return ierrors.NewWithCause(appErr.Code, fmt.Sprintf("document %s: %s", doc.FileName, appErr.Error()), err)
+		}
+		return err
+	}
+
+	doc.MimeType = info.MimeType
+	doc.PageCount = info.PageCount
+	doc.PageWidth = info.PageWidth
+	doc.PageHeight = info.PageHeight
+	doc.Encrypted = info.Encrypted
+	doc.ContentSHA256 = info.SHA256
+	doc.Scan = info.Scan
+	return nil
+}
+
+// failInfectedOrder records the verdict of an infected document and fails its order, then deletes
+// the files of the order. The infected file itself is already deleted, being rejected.
+func (s *orderService) failInfectedOrder(order *entity.Order, doc *entity.Document) {
+	updates := map[string]any{
+		"scan_status":    doc.Scan.Status,
+		"scan_signature": doc.Scan.Signature,
+		"scan_engine":    doc.Scan.Engine,
+		"scan_time":      doc.Scan.Time,
+	}
+	if err := s.orderRepo.UpdateDocument(doc.ID, updates); err != nil {
+		s.logger.Error("Failed to record scan verdict",
+			zap.Uint("documentID", doc.ID),
+			zap.Error(err))
+	}
+
+	reason := fmt.Sprintf("malware detected in document %s", doc.FileName)
+	if err := s.stateMachine.Transition(order, entity.StatusFailed, entity.SystemActor, reason, nil); err != nil {
+		s.logger.Error("Failed to fail order with infected document",
+			zap.Uint("orderID", order.ID),
+			zap.Error(err))
+		return
+	}
+
+	// Documents left in storage are retried by the document retention job
+	purged, _ := s.purger.Purge(order)
+
+	s.logger.Warn("Order failed, malware detected",
+		zap.Uint("orderID", order.ID),
+		zap.Uint("documentID", doc.ID),
+		zap.String("signature", doc.Scan.Signature),
+		zap.Int("documentsDeleted", purged))
+}
+
+// readUpload inspects the stored file of a document. The inspector needs random access,
+// so the file is first copied to a temporary file.
+func (s *orderService) readUpload(doc *entity.Document) (*DocumentInfo, error) {
+	file, err := s.storageService.OpenFile(doc.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload of document %d: %w", doc.ID, err)
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "printly-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload of document %d: %w", doc.ID, err)
+	}
+	return s.inspector.Inspect(tmp, size, doc.MimeType)
+}
+
+// discardUpload deletes the rejected upload of a document
+func (s *orderService) discardUpload(doc *entity.Document) {
+	if err := s.storageService.DeleteFile(doc.StoragePath); err != nil && !errors.Is(err, ErrFileNotFound) {
+		s.logger.Error("Failed to delete rejected upload",
+			zap.String("storagePath", doc.StoragePath),
+			zap.Error(err))
+	}
+}
+
+// QuoteOrder prices documents at a print center without creating an order.
+// It uses the same pricing as CreateOrder so the quote matches the final charge.
+func (s *orderService) QuoteOrder(centerID uint, req dto.QuoteRequest) (*dto.Quote, error) {
+	center, err := s.getOperationalCenter(centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]entity.Document, len(req.Documents))
+	for i, doc := range req.Documents {
+		documents[i] = entity.Document{
+			FileName:     doc.FileName,
+			PageCount:    doc.PageCount,
+			PrintOptions: doc.PrintOptions,
+		}
+	}
+
+	return s.pricing.Quote(center, documents)
+}
+
+// getOperationalCenter retrieves a print center that accepts orders.
+func (s *orderService) getOperationalCenter(centerID uint) (*entity.PrintCenter, error) {
+	center, err := s.printCenterRepo.FindByID(centerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrPrintCenterNotFound
+		}
+		return nil, fmt.Errorf("failed to verify print center: %w", err)
+	}
+	if center.Status != entity.StatusApproved {
+		return nil, ierrors.ErrPrintCenterNotOperational
+	}
+	return center, nil
+}
+
+// rollbackUploads deletes the stored files of documents that could not be attached to an order.
+func (s *orderService) rollbackUploads(documents []dto.CreateDocumentRequest) {
+	for _, doc := range documents {
+		if doc.StoragePath == "" {
+			continue
+		}
+		if err := s.storageService.DeleteFile(doc.StoragePath); err != nil {
+			// The file is orphaned and must be removed manually
+			s.logger.Error("Failed to roll back uploaded file",
+				zap.String("storagePath", doc.StoragePath),
+				zap.Error(err))
+		}
+	}
+}
+
 // GetOrderByID retrieves an order by its ID.
 func (s *orderService) GetOrderByID(id uint) (*entity.Order, error) {
 	order, err := s.orderRepo.FindByID(id)
@@ -113,91 +586,480 @@ func (s *orderService) GetOrderByCode(code string) (*entity.Order, error) {
 		}
 		return nil, fmt.Errorf("getting order by code %s: %w", code, err)
 	}
+	if order == nil {
+		// The repository finds no order for an unknown code without an error
+		return nil, ierrors.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// VerifyPickup checks a pickup code or QR code presented at the counter of a print center and
+// moves the matching order to its next status. Only managers of the center may verify codes, and
+// wrong codes are throttled per center to prevent guessing.
+func (s *orderService) VerifyPickup(centerID uint, req dto.VerifyPickupRequest, actor entity.Actor) (*entity.Order, error) {
+	if !actor.ManagesCenter(centerID) {
+		return nil, ierrors.ErrNotCenterManager
+	}
+
+	if !s.pickupThrottle.Allow(centerID) {
+		s.logger.Warn("Pickup code verification throttled", zap.Uint("centerID", centerID), zap.String("actor", actor.UID))
+		return nil, ierrors.ErrTooManyVerificationAttempts
+	}
+
+	// 1. A scanned QR code carries the pickup code, signed for one center
+	code := req.Code
+	if req.Token != "" {
+		claims, err := s.pickupSigner.Verify(req.Token)
+		if errors.Is(err, ierrors.ErrPickupTokenExpired) {
+			return nil, err
+		}
+		if err != nil || claims.CenterID != centerID {
+			s.pickupThrottle.Fail(centerID)
+			s.logger.Warn("Invalid pickup QR code", zap.Uint("centerID", centerID), zap.String("actor", actor.UID))
+			return nil, ierrors.ErrInvalidPickupCode
+		}
+		code = claims.Code
+	}
+
+	// 2. Codes of other centers are rejected exactly like unknown codes
+	order, err := s.orderRepo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("getting order by code: %w", err)
+	}
+	if order == nil || order.PrintCenterID != centerID {
+		s.pickupThrottle.Fail(centerID)
+		s.logger.Warn("Invalid pickup code", zap.Uint("centerID", centerID), zap.String("actor", actor.UID))
+		return nil, ierrors.ErrInvalidPickupCode
+	}
+
+	// 3. Only orders waiting for their customer can be handed over
+	next, ok := pickupTargets[order.Status]
+	if !ok {
+		return nil, fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotReadyForPickup, order.Status)
+	}
+
+	// 4. Record the verification with the transition
+	now := time.Now()
+	updates := map[string]any{
+		"verified_by": actor.UID,
+		"verified_at": now,
+	}
+	if err := s.stateMachine.Transition(order, next, actor, "pickup code verified", updates); err != nil {
+		return nil, err
+	}
+	order.VerifiedBy = actor.UID
+	order.VerifiedAt = &now
+
+	s.logger.Info("Pickup code verified",
+		zap.Uint("orderID", order.ID),
+		zap.Uint("centerID", centerID),
+		zap.String("status", string(next)),
+		zap.String("verifiedBy", actor.UID))
+	return order, nil
+}
+
+// GetPickupToken issues the signed payload of the pickup QR code of an order.
+// Only the owner of the order can get it, while the order is still active.
+func (s *orderService) GetPickupToken(orderID uint, actor entity.Actor) (string, time.Time, error) {
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if order.UserUID != actor.UID {
+		return "", time.Time{}, ierrors.ErrOrderAccessDenied
+	}
+	if !order.IsActive() {
+		return "", time.Time{}, ierrors.ErrOrderNotActive
+	}
+
+	token, expiresAt, err := s.pickupSigner.Sign(order)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign pickup token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// ScheduleOrder sets the pickup time and print mode of a paid order and moves it to the print queue.
+// The pickup time must fall within the working hours of the center, expressed in the server time zone.
+func (s *orderService) ScheduleOrder(orderID uint, req dto.ScheduleOrderRequest, actor entity.Actor) (*entity.Order, error) {
+	s.logger.Info("Scheduling order pickup",
+		zap.Uint("orderID", orderID),
+		zap.Time("pickupTime", req.PickupTime),
+		zap.String("printMode", string(req.PrintMode)))
+
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Only the owner schedules, once the order is paid
+	if order.UserUID != actor.UID {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+	if order.Status != entity.StatusPaid {
+		return nil, fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotSchedulable, order.Status)
+	}
+	next, ok := scheduleTargets[req.PrintMode]
+	if !ok {
+		return nil, ierrors.New(ierrors.InvalidArgument, fmt.Sprintf("unknown print mode %q", req.PrintMode))
+	}
+
+	// 2. Check the pickup time against the working hours of the center
+	pickupTime := req.PickupTime.In(time.Local)
+	if !pickupTime.After(time.Now()) {
+		return nil, ierrors.ErrPickupTimeInPast
+	}
+	center, err := s.printCenterRepo.FindByID(order.PrintCenterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrPrintCenterNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch print center %d: %w", order.PrintCenterID, err)
+	}
+	if !center.IsOpenAt(pickupTime) {
+		return nil, outsideWorkingHoursError(center, pickupTime)
+	}
+
+	// 3. Move the order to the print queue
+	updates := map[string]any{
+		"pickup_time": pickupTime,
+		"print_mode":  req.PrintMode,
+	}
+	if err := s.stateMachine.Transition(order, next, actor, "pickup scheduled", updates); err != nil {
+		return nil, err
+	}
+	order.PickupTime = &pickupTime
+	order.PrintMode = req.PrintMode
+
+	s.logger.Info("Order pickup scheduled", zap.Uint("orderID", orderID), zap.String("status", string(next)))
 	return order, nil
 }
 
-// GetOrdersForCenter retrieves all orders for a specific print center.
-func (s *orderService) GetOrdersForCenter(centerID uint) ([]entity.Order, error) {
-	orders, err := s.orderRepo.FindByCenterID(centerID)
+// outsideWorkingHoursError tells the customer when the center is open on the requested day
+func outsideWorkingHoursError(center *entity.PrintCenter, pickupTime time.Time) error {
+	day := entity.Weekday(pickupTime.Weekday().String())
+	hours := center.HoursOn(day)
+	if len(hours) == 0 {
+		return ierrors.New(ierrors.InvalidArgument,
+			fmt.Sprintf("%s: closed on %s", ierrors.ErrPickupOutsideWorkingHours.Error(), day))
+	}
+
+	ranges := make([]string, len(hours))
+	for i, wh := range hours {
+		ranges[i] = wh.Start + "-" + wh.End
+	}
+	return ierrors.New(ierrors.InvalidArgument,
+		fmt.Sprintf("%s: open on %s %s", ierrors.ErrPickupOutsideWorkingHours.Error(), day, strings.Join(ranges, ", ")))
+}
+
+// GetOrdersForCenter retrieves one page of the orders of a print center, filtered by status and creation date.
+// Only admins and the managers of the center may list its orders, as they may follow them.
+func (s *orderService) GetOrdersForCenter(centerID uint, req dto.ListRequest, actor entity.Actor) ([]entity.Order, dto.PageInfo, error) {
+	if actor.Role != entity.RoleAdmin && !actor.ManagesCenter(centerID) {
+		return nil, dto.PageInfo{}, ierrors.ErrNotCenterStaff
+	}
+
+	statuses, err := listStatuses(req.Status, entity.OrderStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	orders, info, err := s.orderRepo.List(listQuery(req, repository.ListFilter{Statuses: statuses, CenterID: centerID}))
+	if err != nil {
+		return nil, dto.PageInfo{}, listError(err, fmt.Sprintf("failed to fetch orders for center %d", centerID))
+	}
+	return orders, pageInfo(info), nil
+}
+
+// SubscribeCenterOrders follows the live order events of a print center, resuming after
+// the given event ID when it is still retained. Only admins and the managers of the center
+// may follow its orders. The caller must close the subscription.
+func (s *orderService) SubscribeCenterOrders(centerID uint, lastEventID uint64, actor entity.Actor) (*OrderSubscription, error) {
+	if actor.Role != entity.RoleAdmin && !actor.ManagesCenter(centerID) {
+		return nil, ierrors.ErrNotCenterStaff
+	}
+
+	if _, err := s.printCenterRepo.FindByID(centerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrPrintCenterNotFound
+		}
+		return nil, fmt.Errorf("failed to verify print center: %w", err)
+	}
+
+	sub := s.events.Subscribe(centerID, lastEventID)
+	s.logger.Info("Order stream opened",
+		zap.Uint("centerID", centerID),
+		zap.String("actor", actor.UID),
+		zap.Uint64("lastEventID", lastEventID),
+		zap.Int("replayed", len(sub.Missed)))
+	return sub, nil
+}
+
+// GetOrdersForUser retrieves one page of the orders of a user, newest first by default,
+// filtered by status and creation date. Each order comes with its documents and their current price.
+func (s *orderService) GetOrdersForUser(userUID string, req dto.ListRequest) ([]dto.UserOrder, dto.PageInfo, error) {
+	statuses, err := listStatuses(req.Status, entity.OrderStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	orders, info, err := s.orderRepo.List(listQuery(req, repository.ListFilter{Statuses: statuses, UserUID: userUID}))
+	if err != nil {
+		return nil, dto.PageInfo{}, listError(err, fmt.Sprintf("failed to fetch orders for user %s", userUID))
+	}
+
+	centers := make(map[uint]*entity.PrintCenter)
+	result := make([]dto.UserOrder, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, dto.UserOrder{Order: order, Price: s.priceOrder(&order, centers)})
+	}
+	return result, pageInfo(info), nil
+}
+
+// GetUserOrder retrieves one order of the actor with its documents and their current price.
+func (s *orderService) GetUserOrder(orderID uint, actor entity.Actor) (*dto.UserOrder, error) {
+	order, err := s.GetOrderByID(orderID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch orders for center %d: %w", centerID, err)
+		return nil, err
 	}
-	return orders, nil
+	if order.UserUID != actor.UID {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+
+	return &dto.UserOrder{Order: *order, Price: s.priceOrder(order, map[uint]*entity.PrintCenter{})}, nil
 }
 
-// GetOrdersForUser retrieves all orders for a specific user.
-func (s *orderService) GetOrdersForUser(userUID string) ([]entity.Order, error) {
-	orders, err := s.orderRepo.FindByUserUID(userUID)
+// priceOrder quotes the documents of an order at the current rates of its print center.
+// Centers are cached by ID across calls. An order that can not be priced, for instance
+// because its center no longer offers a service, is listed without a price.
+func (s *orderService) priceOrder(order *entity.Order, centers map[uint]*entity.PrintCenter) *dto.Quote {
+	if len(order.Documents) == 0 {
+		return nil
+	}
+
+	center, ok := centers[order.PrintCenterID]
+	if !ok {
+		var err error
+		center, err = s.printCenterRepo.FindByID(order.PrintCenterID)
+		if err != nil {
+			s.logger.Warn("Failed to fetch print center for pricing", zap.Uint("orderID", order.ID), zap.Uint("centerID", order.PrintCenterID), zap.Error(err))
+		}
+		// A missing center is cached too, so it is only looked up once
+		centers[order.PrintCenterID] = center
+	}
+	if center == nil {
+		return nil
+	}
+
+	quote, err := s.pricing.Quote(center, order.Documents)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch orders for user %s: %w", userUID, err)
+		s.logger.Warn("Failed to price order", zap.Uint("orderID", order.ID), zap.Error(err))
+		return nil
 	}
-	return orders, nil
+	return quote
 }
 
-// GetAllOrders retrieves all orders (for admin use).
-func (s *orderService) GetAllOrders() ([]entity.Order, error) {
-	orders, err := s.orderRepo.FindAll()
+// GetAllOrders retrieves one page of the orders of all print centers (for admin use),
+// filtered by status, print center, owner and creation date.
+func (s *orderService) GetAllOrders(req dto.ListRequest) ([]entity.Order, dto.PageInfo, error) {
+	statuses, err := listStatuses(req.Status, entity.OrderStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	filter := repository.ListFilter{Statuses: statuses, CenterID: req.CenterID, UserUID: req.UserUID}
+	orders, info, err := s.orderRepo.List(listQuery(req, filter))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch all orders: %w", err)
+		return nil, dto.PageInfo{}, listError(err, "failed to fetch all orders")
 	}
-	return orders, nil
+	return orders, pageInfo(info), nil
 }
 
-// UpdateOrderStatus updates the status of a specific order.
-func (s *orderService) UpdateOrderStatus(orderID uint, status entity.OrderStatus, updatedBy string) error {
+// UpdateOrderStatus moves an order to a new status through the state machine.
+// Cancellations go through CancelOrder so that the order is refunded and purged.
+func (s *orderService) UpdateOrderStatus(orderID uint, status entity.OrderStatus, actor entity.Actor, reason string) error {
 	s.logger.Info("Updating order status",
 		zap.Uint("orderID", orderID),
 		zap.String("status", string(status)),
-		zap.String("updatedBy", updatedBy))
+		zap.String("updatedBy", actor.UID))
 
-	if _, err := s.GetOrderByID(orderID); err != nil {
-		return err // Return ErrOrderNotFound if it doesn't exist
+	if status == entity.StatusCancelled {
+		_, err := s.CancelOrder(orderID, actor, reason)
+		return err
 	}
 
-	updates := map[string]any{
-		"status":     status,
-		"updated_by": updatedBy,
-		"updated_at": time.Now(),
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return err // Return ErrOrderNotFound if it doesn't exist
 	}
 
-	err := s.orderRepo.Update(orderID, updates)
-	if err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
+	if err := s.stateMachine.Transition(order, status, actor, reason, nil); err != nil {
+		return err
 	}
 
 	s.logger.Info("Order status updated successfully", zap.Uint("orderID", orderID), zap.String("status", string(status)))
 	return nil
 }
 
-// CancelOrder cancels an order if it's in a cancellable state.
-func (s *orderService) CancelOrder(orderID uint, userUID string) error {
-	s.logger.Info("Canceling order", zap.Uint("orderID", orderID), zap.String("userUID", userUID))
+// CancelOrder cancels an order following the rules of the actor's role, refunds it if it was paid
+// and deletes its documents. The order stays cancelled when the refund or the purge fails,
+// the failures are reported in the response.
+func (s *orderService) CancelOrder(orderID uint, actor entity.Actor, reason string) (*dto.CancelOrderResponse, error) {
+	s.logger.Info("Canceling order", zap.Uint("orderID", orderID), zap.String("actor", actor.UID))
 
 	order, err := s.GetOrderByID(orderID)
 	if err != nil {
-		return err // Return ErrOrderNotFound if it doesn't exist
+		return nil, err // Return ErrOrderNotFound if it doesn't exist
 	}
 
-	// Verify that the user owns this order
-	// TODO: Need to handle admin cancelling
-	if order.UserUID != userUID {
-		return ierrors.ErrUnauthorized
+	if err := authorizeCancellation(order, actor, reason); err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = "cancelled by customer"
 	}
 
-	// Check if the order can be cancelled
-	if !order.CanCancel() {
-		return ierrors.ErrOrderCannotBeCancelled
+	if err := s.stateMachine.Transition(order, entity.StatusCancelled, actor, reason, nil); err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
 	}
 
-	err = s.UpdateOrderStatus(orderID, entity.StatusCancelled, userUID)
+	response := &dto.CancelOrderResponse{Order: order}
+
+	refund, err := s.payments.RefundOrder(order, reason)
+	response.Refund = refund
 	if err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
+		response.Failures = append(response.Failures, err.Error())
 	}
 
-	s.logger.Info("Order cancelled successfully", zap.Uint("orderID", orderID))
+	purged, err := s.purger.Purge(order)
+	response.DeletedDocuments = purged
+	if err != nil {
+		// Documents left in storage are retried by the document retention job
+		response.Failures = append(response.Failures, err.Error())
+	}
+
+	s.logger.Info("Order cancelled successfully",
+		zap.Uint("orderID", orderID),
+		zap.Bool("refunded", refund != nil),
+		zap.Int("documentsDeleted", purged),
+		zap.Int("failures", len(response.Failures)))
+	return response, nil
+}
+
+// authorizeCancellation checks that the actor may cancel the order in its current status.
+// Managers and admins must give the customer a reason.
+func authorizeCancellation(order *entity.Order, actor entity.Actor, reason string) error {
+	switch actor.Role {
+	case entity.RoleUser:
+		if order.UserUID != actor.UID {
+			return ierrors.ErrOrderAccessDenied
+		}
+	case entity.RoleManager:
+		if !actor.ManagesCenter(order.PrintCenterID) {
+			return ierrors.ErrOrderAccessDenied
+		}
+		if reason == "" {
+			return ierrors.ErrCancellationReasonRequired
+		}
+	case entity.RoleAdmin:
+		if reason == "" {
+			return ierrors.ErrCancellationReasonRequired
+		}
+	default:
+		return ierrors.ErrOrderAccessDenied
+	}
+
+	if !order.CanTransitionTo(entity.StatusCancelled) {
+		return ierrors.ErrOrderCannotBeCancelled
+	}
+	if statuses, limited := cancellableStatuses[actor.Role]; limited && !slices.Contains(statuses, order.Status) {
+		return ierrors.ErrOrderCannotBeCancelled
+	}
 	return nil
 }
 
+// GetOrderHistory retrieves the status transitions of an order the actor has access to.
+func (s *orderService) GetOrderHistory(orderID uint, actor entity.Actor) ([]entity.OrderStatusHistory, error) {
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanAccessOrder(order) {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+
+	history, err := s.orderRepo.FindStatusHistory(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history for order %d: %w", orderID, err)
+	}
+	return history, nil
+}
+
+// GetPurgeReport tells whether the files of an order the actor has access to are deleted from storage.
+func (s *orderService) GetPurgeReport(orderID uint, actor entity.Actor) (*dto.DocumentPurgeReport, error) {
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanAccessOrder(order) {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+
+	report := &dto.DocumentPurgeReport{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		AllPurged: true,
+		Documents: make([]dto.DocumentPurgeState, 0, len(order.Documents)),
+	}
+	for _, doc := range order.Documents {
+		deleted := doc.StorageDeletedAt != nil
+		report.AllPurged = report.AllPurged && deleted
+		report.Documents = append(report.Documents, dto.DocumentPurgeState{
+			ID:               doc.ID,
+			FileName:         doc.FileName,
+			PrintedAt:        doc.PrintedAt,
+			StorageDeletedAt: doc.StorageDeletedAt,
+			Deleted:          deleted,
+		})
+	}
+	return report, nil
+}
+
+// GetDeletionReceipt retrieves the receipt of a purged document of an order the actor has access to.
+func (s *orderService) GetDeletionReceipt(orderID, documentID uint, actor entity.Actor) (*entity.DeletionReceipt, error) {
+	order, err := s.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanAccessOrder(order) {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+
+	idx := slices.IndexFunc(order.Documents, func(doc entity.Document) bool { return doc.ID == documentID })
+	if idx < 0 {
+		return nil, ierrors.ErrDocumentNotFound
+	}
+	if order.Documents[idx].StorageDeletedAt == nil {
+		return nil, ierrors.ErrDocumentNotPurged
+	}
+
+	receipt, err := s.orderRepo.FindDeletionReceipt(documentID)
+	if err != nil {
+		// Documents purged before receipts were issued have none
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrDeletionReceiptNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch deletion receipt of document %d: %w", documentID, err)
+	}
+	return receipt, nil
+}
+
+// VerifyDeletionReceipt reports whether the receipt was issued by this server and left unchanged.
+func (s *orderService) VerifyDeletionReceipt(receipt *entity.DeletionReceipt) bool {
+	return s.receiptSigner.Verify(receipt)
+}
+
 // DeleteOrder removes an order from the database.
 func (s *orderService) DeleteOrder(orderID uint) error {
 	s.logger.Info("Deleting order", zap.Uint("orderID", orderID))
@@ -214,48 +1076,28 @@ func (s *orderService) DeleteOrder(orderID uint) error {
 	return nil
 }
 
-// CalculateOrderCost calculates the total cost of an order based on its documents and print options.
+// CalculateOrderCost prices an order with the current services of its print center.
 func (s *orderService) CalculateOrderCost(orderID uint) (int64, error) {
 	order, err := s.GetOrderByID(orderID)
 	if err != nil {
 		return 0, err
 	}
 
-	var totalCost int64
-
-	for _, doc := range order.Documents {
-		// Base cost calculation (example: $0.10 per page)
-		baseCostPerPage := int64(10) // 10 cents in the smallest currency unit
-
-		// Calculate pages based on document size (this is a simplified example)
-		// In a real implementation, you would need to determine the actual page count
-		estimatedPages := int64(1) // Default to 1 page
-		if doc.Size > 0 {
-			// Rough estimation: 50KB per page (adjust based on your requirements)
-			estimatedPages = (doc.Size + 50000 - 1) / 50000
-			if estimatedPages == 0 {
-				estimatedPages = 1
-			}
-		}
-
-		docCost := baseCostPerPage * estimatedPages
-
-		// Apply print options modifiers
-		if doc.PrintOptions.Color == entity.Color {
-			docCost *= 3 // Color printing costs 3x more
-		}
-		if doc.PrintOptions.DoubleSided {
-			docCost = docCost * 6 / 10 // 40% discount for double-sided
-		}
-		if doc.PrintOptions.Copies > 1 {
-			docCost *= int64(doc.PrintOptions.Copies)
+	center, err := s.printCenterRepo.FindByID(order.PrintCenterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ierrors.ErrPrintCenterNotFound
 		}
+		return 0, fmt.Errorf("failed to fetch print center %d: %w", order.PrintCenterID, err)
+	}
 
-		totalCost += docCost
+	quote, err := s.pricing.Quote(center, order.Documents)
+	if err != nil {
+		return 0, err
 	}
 
-	s.logger.Info("Order cost calculated", zap.Uint("orderID", orderID), zap.Int64("totalCost", totalCost))
-	return totalCost, nil
+	s.logger.Info("Order cost calculated", zap.Uint("orderID", orderID), zap.Int64("totalCost", quote.Total))
+	return quote.Total, nil
 }
 
 // generateUniquePickupCode creates a random alphanumeric string of a given length.
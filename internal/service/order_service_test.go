@@ -1,14 +1,20 @@
 package service_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
 	"github.com/kimbasn/printly/internal/service"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -21,6 +27,13 @@ type OrderServiceTestSuite struct {
 	orderRepo       *mocks.MockOrderRepository
 	printCenterRepo *mocks.MockPrintCenterRepository
 	userRepo        *mocks.MockUserRepository
+	storageService  *mocks.MockStorageService
+	inspector       *mocks.MockDocumentInspector
+	pickupSigner    service.PickupTokenSigner
+	payments        *mocks.MockPaymentService
+	purger          *mocks.MockDocumentPurger
+	events          service.OrderEventBus
+	notifier        *mocks.MockNotifier
 	service         service.OrderService
 	logger          *zap.Logger
 }
@@ -30,16 +43,48 @@ func (s *OrderServiceTestSuite) SetupTest() {
 	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
 	s.printCenterRepo = mocks.NewMockPrintCenterRepository(s.ctrl)
 	s.userRepo = mocks.NewMockUserRepository(s.ctrl)
+	s.storageService = mocks.NewMockStorageService(s.ctrl)
+	s.inspector = mocks.NewMockDocumentInspector(s.ctrl)
 	s.logger = zap.NewNop()
+	s.pickupSigner = service.NewPickupTokenSigner([]byte("test-secret"), time.Hour)
+	s.payments = mocks.NewMockPaymentService(s.ctrl)
+	s.purger = mocks.NewMockDocumentPurger(s.ctrl)
+	s.events = service.NewOrderEventBus(service.DefaultOrderEventHistory)
+	s.notifier = mocks.NewMockNotifier(s.ctrl)
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).Return(nil).AnyTimes()
 
 	s.service = service.NewOrderService(
 		s.orderRepo,
 		s.printCenterRepo,
 		s.userRepo,
+		service.NewOrderStateMachine(s.orderRepo, s.events, s.notifier, s.logger),
+		s.storageService,
+		s.inspector,
+		service.NewPricingEngine(),
+		service.NewPickupThrottle(2, time.Minute),
+		s.pickupSigner,
+		service.NewDeletionReceiptSigner([]byte("secret")),
+		s.payments,
+		s.purger,
+		s.events,
 		s.logger,
 	)
 }
 
+// approvedCenter returns an operational center printing A4 in black and white
+// for 10 cents and in color for 30 cents per side.
+func approvedCenter(id uint) *entity.PrintCenter {
+	return &entity.PrintCenter{
+		ID:       id,
+		Status:   entity.StatusApproved,
+		Currency: "EUR",
+		Services: []entity.Service{
+			{Name: "A4 black and white", PaperSize: "A4", ColorMode: entity.BlackAndWhite, Price: 10},
+			{Name: "A4 color", PaperSize: "A4", ColorMode: entity.Color, Price: 30},
+		},
+	}
+}
+
 func (s *OrderServiceTestSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
@@ -59,22 +104,24 @@ func (s *OrderServiceTestSuite) TestCreateOrder_Success() {
 	req := dto.CreateOrderRequest{
 		Documents: []dto.CreateDocumentRequest{
 			{
-				FileName: "test.pdf",
-				Size:     1024,
-				MimeType: "application/pdf",
+				FileName:    "test.pdf",
+				Size:        1024,
+				MimeType:    "application/pdf",
+				StoragePath: "documents/test-user-123/test.pdf",
+				PrintMode:   entity.PrePrint,
+				PageCount:   3,
 				PrintOptions: entity.PrintOptions{
+					Pages:       "all",
 					Color:       entity.BlackAndWhite,
+					PaperSize:   entity.A4,
 					DoubleSided: false,
-					Copies:      1,
+					Copies:      2,
 				},
 			},
 		},
 	}
 
-	center := &entity.PrintCenter{
-		ID:     centerID,
-		Status: entity.StatusApproved,
-	}
+	center := approvedCenter(centerID)
 
 	// Mock expectations
 	s.printCenterRepo.EXPECT().
@@ -88,10 +135,25 @@ func (s *OrderServiceTestSuite) TestCreateOrder_Success() {
 	s.orderRepo.EXPECT().
 		Save(gomock.Any()).
 		DoAndReturn(func(order *entity.Order) error {
+			// Documents are saved along with the order
+			s.Require().Len(order.Documents, 1)
+			doc := order.Documents[0]
+			s.Equal("test.pdf", doc.FileName)
+			s.Equal("application/pdf", doc.MimeType)
+			s.Equal(int64(1024), doc.Size)
+			s.Equal("documents/test-user-123/test.pdf", doc.StoragePath)
+			s.Equal(entity.PrePrint, doc.PrintMode)
+			s.Equal(2, doc.PrintOptions.Copies)
+			s.Equal([]int{1, 2, 3}, doc.SelectedPages)
+			s.NotNil(doc.UploadedAt)
+
 			order.ID = 1 // Simulate database ID assignment
 			return nil
 		})
 
+	sub := s.events.Subscribe(centerID, 0)
+	defer sub.Close()
+
 	// Act
 	result, err := s.service.CreateOrder(userUID, centerID, req)
 
@@ -103,6 +165,39 @@ func (s *OrderServiceTestSuite) TestCreateOrder_Success() {
 	s.Equal(entity.StatusPendingPayment, result.Status)
 	s.NotEmpty(result.Code)
 	s.Len(result.Code, 6)
+	s.Len(result.TrackingToken, 32)
+	s.Equal(int64(60), result.TotalCost) // 3 pages * 2 copies * 10 cents
+	s.Equal("EUR", result.Currency)
+
+	event := <-sub.Events
+	s.Equal(dto.OrderEventCreated, event.Type)
+	s.Equal(uint(1), event.OrderID)
+	s.Equal(entity.StatusPendingPayment, event.Status)
+	s.Equal(int64(60), event.TotalCost)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_ServiceNotOffered() {
+	// Arrange
+	centerID := uint(1)
+	req := dto.CreateOrderRequest{
+		Documents: []dto.CreateDocumentRequest{
+			{
+				FileName:     "poster.pdf",
+				StoragePath:  "documents/test-user-123/poster.pdf",
+				PageCount:    1,
+				PrintOptions: entity.PrintOptions{Pages: "all", Color: entity.Color, PaperSize: entity.A3, Copies: 1},
+			},
+		},
+	}
+
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+	s.storageService.EXPECT().DeleteFile("documents/test-user-123/poster.pdf").Return(nil)
+
+	// Act
+	_, err := s.service.CreateOrder("test-user-123", centerID, req)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrServiceNotOffered)
 }
 
 func (s *OrderServiceTestSuite) TestCreateOrder_PrintCenterNotFound() {
@@ -183,6 +278,457 @@ func (s *OrderServiceTestSuite) TestCreateOrder_SaveOrderError() {
 	s.Contains(err.Error(), "failed to save order")
 }
 
+func (s *OrderServiceTestSuite) TestCreateOrder_SaveOrderError_RollsBackUploads() {
+	// Arrange
+	userUID := "test-user-123"
+	centerID := uint(1)
+	req := dto.CreateOrderRequest{
+		Documents: []dto.CreateDocumentRequest{
+			{FileName: "a.pdf", StoragePath: "documents/test-user-123/a.pdf", PrintOptions: entity.PrintOptions{PaperSize: entity.A4, Color: entity.BlackAndWhite}},
+			{FileName: "b.pdf", StoragePath: "documents/test-user-123/b.pdf", PrintOptions: entity.PrintOptions{PaperSize: entity.A4, Color: entity.BlackAndWhite}},
+		},
+	}
+
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+	s.orderRepo.EXPECT().FindByCode(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	s.orderRepo.EXPECT().Save(gomock.Any()).Return(errors.New("database error"))
+
+	// Every uploaded object is deleted, even if one deletion fails
+	s.storageService.EXPECT().DeleteFile("documents/test-user-123/a.pdf").Return(errors.New("storage error"))
+	s.storageService.EXPECT().DeleteFile("documents/test-user-123/b.pdf").Return(nil)
+
+	// Act
+	result, err := s.service.CreateOrder(userUID, centerID, req)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_PrintCenterNotFound_RollsBackUploads() {
+	// Arrange
+	centerID := uint(999)
+	req := dto.CreateOrderRequest{
+		Documents: []dto.CreateDocumentRequest{{FileName: "a.pdf", StoragePath: "documents/test-user-123/a.pdf"}},
+	}
+
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(nil, gorm.ErrRecordNotFound)
+	s.storageService.EXPECT().DeleteFile("documents/test-user-123/a.pdf").Return(nil)
+
+	// Act
+	_, err := s.service.CreateOrder("test-user-123", centerID, req)
+
+	// Assert
+	s.Equal(ierrors.ErrPrintCenterNotFound, err)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_PageRangeOutOfDocument() {
+	// Arrange
+	centerID := uint(1)
+	req := dto.CreateOrderRequest{
+		Documents: []dto.CreateDocumentRequest{
+			{
+				FileName:     "a.pdf",
+				StoragePath:  "documents/test-user-123/a.pdf",
+				PageCount:    3,
+				PrintOptions: entity.PrintOptions{Pages: "2-5", Copies: 1},
+			},
+		},
+	}
+
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+	s.storageService.EXPECT().DeleteFile("documents/test-user-123/a.pdf").Return(nil)
+
+	// Act
+	_, err := s.service.CreateOrder("test-user-123", centerID, req)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidPageRange)
+	s.ErrorContains(err, "out of range")
+}
+
+// ============================================================================
+// Direct Upload Tests
+// ============================================================================
+
+// directUploadRequest declares one 3 page PDF printed twice in black and white
+func directUploadRequest() dto.DirectUploadOrderRequest {
+	return dto.DirectUploadOrderRequest{
+		Documents: []dto.DirectUploadDocumentRequest{
+			{
+				FileName:  "thesis.pdf",
+				MimeType:  "application/pdf",
+				Size:      2048,
+				PageCount: 3,
+				PrintMode: entity.PrePrint,
+				PrintOptions: entity.PrintOptions{
+					Pages:     "all",
+					Color:     entity.BlackAndWhite,
+					PaperSize: entity.A4,
+					Copies:    2,
+				},
+			},
+		},
+	}
+}
+
+// awaitingDocumentOrder returns an order of user-1 waiting for the upload of one 2048 bytes PDF
+func awaitingDocumentOrder() *entity.Order {
+	return &entity.Order{
+		ID:            8,
+		UserUID:       "user-1",
+		PrintCenterID: 1,
+		Status:        entity.StatusAwaitingDocument,
+		TotalCost:     60,
+		Currency:      "EUR",
+		Documents: []entity.Document{
+			{
+				ID:           80,
+				OrderID:      8,
+				FileName:     "thesis.pdf",
+				MimeType:     "application/pdf",
+				StoragePath:  "user-1/thesis.pdf",
+				Size:         2048,
+				PageCount:    3,
+				PrintOptions: entity.PrintOptions{Pages: "all", Color: entity.BlackAndWhite, PaperSize: entity.A4, Copies: 2},
+			},
+		},
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCreateDirectUploadOrder_Success() {
+	// Arrange
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().NewUploadPath("thesis.pdf", "user-1").Return("user-1/thesis_1.pdf", nil)
+	s.storageService.EXPECT().
+		GetSignedUploadURL("user-1/thesis_1.pdf", "application/pdf", gomock.Any()).
+		Return(&service.SignedUpload{
+			URL:     "https://storage.example/user-1/thesis_1.pdf?signature=abc",
+			Headers: map[string]string{"Content-Type": "application/pdf", "x-goog-if-generation-match": "0"},
+		}, nil)
+	s.orderRepo.EXPECT().FindByCode(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	s.orderRepo.EXPECT().
+		Save(gomock.Any()).
+		DoAndReturn(func(order *entity.Order) error {
+			s.Equal(entity.StatusAwaitingDocument, order.Status)
+			s.Require().Len(order.Documents, 1)
+			s.Equal("user-1/thesis_1.pdf", order.Documents[0].StoragePath)
+			s.Nil(order.Documents[0].UploadedAt)
+
+			order.ID = 8
+			order.Documents[0].ID = 80
+			return nil
+		})
+
+	// Act
+	result, err := s.service.CreateDirectUploadOrder("user-1", 1, directUploadRequest())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusAwaitingDocument, result.Order.Status)
+	s.Equal(int64(60), result.Order.TotalCost) // 3 declared pages * 2 copies * 10 cents
+	s.Require().Len(result.Uploads, 1)
+	upload := result.Uploads[0]
+	s.Equal(uint(80), upload.DocumentID)
+	s.Equal("PUT", upload.Method)
+	s.Equal("https://storage.example/user-1/thesis_1.pdf?signature=abc", upload.URL)
+	s.Equal("application/pdf", upload.Headers["Content-Type"])
+	s.Equal("0", upload.Headers["x-goog-if-generation-match"]) // The headers the URL was signed with
+	s.True(upload.ExpiresAt.After(time.Now()))
+
+	// The tracking token is returned to the owner on creation, but never serialized with the order
+	s.Len(result.Order.TrackingToken, 32)
+	created, err := json.Marshal(result)
+	s.Require().NoError(err)
+	s.Contains(string(created), `"tracking_token":"`+result.Order.TrackingToken+`"`)
+	order, err := json.Marshal(result.Order.Order)
+	s.Require().NoError(err)
+	s.NotContains(string(order), "tracking_token")
+}
+
+func (s *OrderServiceTestSuite) TestCreateDirectUploadOrder_SigningErrorSavesNothing() {
+	// Arrange
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().NewUploadPath(gomock.Any(), gomock.Any()).Return("user-1/thesis_1.pdf", nil)
+	s.storageService.EXPECT().
+		GetSignedUploadURL(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("no signing key"))
+	// No Save expected
+
+	// Act
+	_, err := s.service.CreateDirectUploadOrder("user-1", 1, directUploadRequest())
+
+	// Assert
+	s.ErrorContains(err, "no signing key")
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_Success() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048, ContentType: "application/pdf"}, nil)
+	s.storageService.EXPECT().OpenFile("user-1/thesis.pdf").Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+	s.inspector.EXPECT().
+		Inspect(gomock.Any(), int64(8), "application/pdf").
+		Return(&service.DocumentInfo{
+			MimeType: "application/pdf", PageCount: 5, PageWidth: 595, PageHeight: 842, SHA256: "abc123",
+			Scan: entity.ScanVerdict{Status: entity.ScanClean, Engine: "stub"},
+		}, nil)
+	s.orderRepo.EXPECT().
+		UpdateStatusAndDocuments(order.ID, entity.StatusAwaitingDocument, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, documents map[uint]map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusPendingPayment, updates["status"])
+			s.Equal(int64(100), updates["total_cost"])
+			s.Require().Len(documents, 1)
+			doc := documents[80]
+			s.Equal("application/pdf", doc["mime_type"])
+			s.Equal(5, doc["page_count"])
+			s.Equal("abc123", doc["content_sha256"])
+			s.Equal(entity.ScanClean, doc["scan_status"])
+			s.NotNil(doc["uploaded_at"])
+			return nil
+		})
+
+	// Act
+	result, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusPendingPayment, result.Status)
+	s.Equal(int64(100), result.TotalCost) // Priced again from the 5 real pages
+	s.Equal([]int{1, 2, 3, 4, 5}, result.Documents[0].SelectedPages)
+	s.NotNil(result.Documents[0].UploadedAt)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_StatusConflictRecordsNothing() {
+	// Arrange: the order was cancelled while its files were inspected
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048, ContentType: "application/pdf"}, nil)
+	s.storageService.EXPECT().OpenFile("user-1/thesis.pdf").Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+	s.inspector.EXPECT().
+		Inspect(gomock.Any(), int64(8), "application/pdf").
+		Return(&service.DocumentInfo{MimeType: "application/pdf", PageCount: 5, Scan: entity.ScanVerdict{Status: entity.ScanClean}}, nil)
+	// The documents are only written in the transaction of the status change, no UpdateDocument expected
+	s.orderRepo.EXPECT().
+		UpdateStatusAndDocuments(order.ID, entity.StatusAwaitingDocument, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(repository.ErrStaleStatus)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderStatusConflict)
+	s.Equal(entity.StatusAwaitingDocument, order.Status)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_NotUploaded() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(nil, service.ErrFileNotFound)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrDocumentNotUploaded)
+	s.ErrorContains(err, "thesis.pdf")
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_SizeMismatchDeletesFile() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 4096}, nil)
+	s.storageService.EXPECT().DeleteFile("user-1/thesis.pdf").Return(nil)
+	// The order stays awaiting its documents, no UpdateStatus expected
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadMismatch)
+	s.ErrorContains(err, "4096 bytes uploaded, 2048 declared")
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_TypeMismatchDeletesFile() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048, ContentType: "image/png"}, nil)
+	s.storageService.EXPECT().DeleteFile("user-1/thesis.pdf").Return(nil)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadMismatch)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_UnreadableFileDeleted() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048}, nil)
+	s.storageService.EXPECT().OpenFile("user-1/thesis.pdf").Return(io.NopCloser(strings.NewReader("garbage")), nil)
+	s.inspector.EXPECT().Inspect(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, ierrors.ErrUnreadableDocument)
+	s.storageService.EXPECT().DeleteFile("user-1/thesis.pdf").Return(nil)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUnreadableDocument)
+	s.ErrorContains(err, "thesis.pdf")
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_InfectedFileFailsOrder() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+	infected := entity.ScanVerdict{Status: entity.ScanInfected, Signature: "Eicar-Test-Signature", Engine: "stub"}
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048}, nil)
+	s.storageService.EXPECT().OpenFile("user-1/thesis.pdf").Return(io.NopCloser(strings.NewReader("infected")), nil)
+	s.inspector.EXPECT().
+		Inspect(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&service.DocumentInfo{Scan: infected}, ierrors.New(ierrors.MalwareDetected, "malware detected in uploaded file: Eicar-Test-Signature"))
+	s.storageService.EXPECT().DeleteFile("user-1/thesis.pdf").Return(nil)
+	s.orderRepo.EXPECT().
+		UpdateDocument(uint(80), gomock.Any()).
+		DoAndReturn(func(id uint, updates map[string]any) error {
+			s.Equal(entity.ScanInfected, updates["scan_status"])
+			s.Equal("Eicar-Test-Signature", updates["scan_signature"])
+			s.Nil(updates["page_count"]) // Nothing else is read from an infected file
+			return nil
+		})
+	s.orderRepo.EXPECT().
+		UpdateStatus(order.ID, entity.StatusAwaitingDocument, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusFailed, updates["status"])
+			s.Equal(entity.SystemActor.UID, updates["updated_by"])
+			s.Contains(history.Reason, "thesis.pdf")
+			return nil
+		})
+	s.purger.EXPECT().Purge(order).Return(1, nil)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrMalwareDetected)
+	s.ErrorContains(err, "thesis.pdf")
+	s.Equal(entity.StatusFailed, order.Status)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_ScannerUnavailableKeepsFile() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(approvedCenter(1), nil)
+	s.storageService.EXPECT().StatFile("user-1/thesis.pdf").Return(&service.ObjectInfo{Size: 2048}, nil)
+	s.storageService.EXPECT().OpenFile("user-1/thesis.pdf").Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+	s.inspector.EXPECT().Inspect(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, ierrors.ErrScannerUnavailable)
+	// No DeleteFile nor UpdateStatus expected, the upload can be confirmed again
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrScannerUnavailable)
+	s.Equal(entity.StatusAwaitingDocument, order.Status)
+}
+
+func (s *OrderServiceTestSuite) TestCheckDocumentUpload_AwaitingDocument() {
+	// Arrange
+	order := awaitingDocumentOrder()
+	doc := order.Documents[0]
+	doc.Order = *order
+	s.orderRepo.EXPECT().FindDocumentByStoragePath("user-1/thesis.pdf").Return(&doc, nil)
+
+	// Act
+	err := s.service.CheckDocumentUpload("user-1/thesis.pdf")
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *OrderServiceTestSuite) TestCheckDocumentUpload_AfterConfirmationRejected() {
+	// Arrange: the uploads were checked and the order moved on
+	order := awaitingDocumentOrder()
+	order.Status = entity.StatusPendingPayment
+	doc := order.Documents[0]
+	doc.Order = *order
+	s.orderRepo.EXPECT().FindDocumentByStoragePath("user-1/thesis.pdf").Return(&doc, nil)
+
+	// Act
+	err := s.service.CheckDocumentUpload("user-1/thesis.pdf")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderNotAwaitingDocument)
+}
+
+func (s *OrderServiceTestSuite) TestCheckDocumentUpload_UnknownPath() {
+	// Arrange
+	s.orderRepo.EXPECT().FindDocumentByStoragePath("user-1/other.pdf").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := s.service.CheckDocumentUpload("user-1/other.pdf")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidUploadURL)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_NotOwner() {
+	// Arrange
+	other := entity.Actor{UID: "user-2", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, other)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderAccessDenied)
+}
+
+func (s *OrderServiceTestSuite) TestConfirmDocumentUploads_AlreadyConfirmed() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := awaitingDocumentOrder()
+	order.Status = entity.StatusPendingPayment
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, err := s.service.ConfirmDocumentUploads(order.ID, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderNotAwaitingDocument)
+}
+
 // ============================================================================
 // GetOrderByID Tests
 // ============================================================================
@@ -269,6 +815,23 @@ func (s *OrderServiceTestSuite) TestGetOrderByCode_NotFound() {
 	s.Equal(ierrors.ErrOrderNotFound, err)
 }
 
+func (s *OrderServiceTestSuite) TestGetOrderByCode_UnknownCode() {
+	// Arrange: the repository returns no order and no error for an unknown code
+	code := "UNKNOWN"
+
+	// Mock expectations
+	s.orderRepo.EXPECT().
+		FindByCode(code).
+		Return(nil, nil)
+
+	// Act
+	result, err := s.service.GetOrderByCode(code)
+
+	// Assert: reported as not found (404) instead of handing a nil order to the controller
+	s.Nil(result)
+	s.Equal(ierrors.ErrOrderNotFound, err)
+}
+
 // ============================================================================
 // GetOrdersForCenter Tests
 // ============================================================================
@@ -282,57 +845,273 @@ func (s *OrderServiceTestSuite) TestGetOrdersForCenter_Success() {
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
-		FindByCenterID(centerID).
-		Return(expectedOrders, nil)
+		List(repository.ListQuery{Filter: repository.ListFilter{CenterID: centerID}}).
+		Return(expectedOrders, repository.PageInfo{Total: 2}, nil)
 
 	// Act
-	result, err := s.service.GetOrdersForCenter(centerID)
+	result, info, err := s.service.GetOrdersForCenter(centerID, dto.ListRequest{}, managerOf(centerID))
 
 	// Assert
 	s.NoError(err)
 	s.Equal(expectedOrders, result)
+	s.Equal(int64(2), info.Total)
 }
 
-func (s *OrderServiceTestSuite) TestGetOrdersForCenter_Error() {
+func (s *OrderServiceTestSuite) TestGetOrdersForCenter_Filters() {
 	// Arrange
 	centerID := uint(1)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	req := dto.ListRequest{
+		Limit:     50,
+		Cursor:    "cursor",
+		Status:    []string{"ready_to_print", "PRINTING"},
+		CenterID:  9, // the center of the path wins
+		CreatedTo: &to,
+	}
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
-		FindByCenterID(centerID).
-		Return(nil, errors.New("database error"))
+		List(repository.ListQuery{
+			Limit:  50,
+			Cursor: "cursor",
+			Filter: repository.ListFilter{
+				Statuses:  []string{"READY_TO_PRINT", "PRINTING"},
+				CenterID:  centerID,
+				CreatedTo: &to,
+			},
+		}).
+		Return([]entity.Order{}, repository.PageInfo{}, nil)
 
 	// Act
-	result, err := s.service.GetOrdersForCenter(centerID)
+	_, _, err := s.service.GetOrdersForCenter(centerID, req, entity.Actor{UID: "admin-1", Role: entity.RoleAdmin})
 
 	// Assert
-	s.Error(err)
+	s.NoError(err)
+}
+
+func (s *OrderServiceTestSuite) TestGetOrdersForCenter_UnknownStatus() {
+	// Act
+	result, _, err := s.service.GetOrdersForCenter(1, dto.ListRequest{Status: []string{"LOST"}}, managerOf(1))
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidListFilter)
 	s.Nil(result)
-	s.Contains(err.Error(), "failed to fetch orders for center")
 }
 
-// ============================================================================
-// GetOrdersForUser Tests
-// ============================================================================
-func (s *OrderServiceTestSuite) TestGetOrdersForUser_Success() {
+func (s *OrderServiceTestSuite) TestGetOrdersForCenter_OtherCenterManager() {
+	// Act: no List expected
+	result, _, err := s.service.GetOrdersForCenter(1, dto.ListRequest{}, managerOf(2))
+
+	// Assert
+	s.Equal(ierrors.ErrNotCenterStaff, err)
+	s.Nil(result)
+}
+
+func (s *OrderServiceTestSuite) TestGetOrdersForCenter_Error() {
 	// Arrange
-	userUID := "test-user-123"
-	expectedOrders := []entity.Order{
-		{ID: 1, UserUID: userUID},
-		{ID: 2, UserUID: userUID},
-	}
+	centerID := uint(1)
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
-		FindByUserUID(userUID).
-		Return(expectedOrders, nil)
+		List(gomock.Any()).
+		Return(nil, repository.PageInfo{}, errors.New("database error"))
 
 	// Act
-	result, err := s.service.GetOrdersForUser(userUID)
+	result, _, err := s.service.GetOrdersForCenter(centerID, dto.ListRequest{}, managerOf(centerID))
 
 	// Assert
-	s.NoError(err)
-	s.Equal(expectedOrders, result)
+	s.Error(err)
+	s.Nil(result)
+	s.Contains(err.Error(), "failed to fetch orders for center")
+}
+
+// ============================================================================
+// SubscribeCenterOrders Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestSubscribeCenterOrders_ResumesForManager() {
+	// Arrange
+	centerID := uint(1)
+	s.events.Publish(dto.OrderEvent{OrderID: 10, PrintCenterID: centerID})
+	s.events.Publish(dto.OrderEvent{OrderID: 11, PrintCenterID: centerID})
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+
+	// Act
+	sub, err := s.service.SubscribeCenterOrders(centerID, 1, managerOf(centerID))
+
+	// Assert
+	s.Require().NoError(err)
+	defer sub.Close()
+	s.Require().Len(sub.Missed, 1)
+	s.Equal(uint(11), sub.Missed[0].OrderID)
+}
+
+func (s *OrderServiceTestSuite) TestSubscribeCenterOrders_OtherCenterManager() {
+	// Act
+	_, err := s.service.SubscribeCenterOrders(1, 0, managerOf(2))
+
+	// Assert
+	s.Equal(ierrors.ErrNotCenterStaff, err)
+}
+
+func (s *OrderServiceTestSuite) TestSubscribeCenterOrders_Customer() {
+	// Act
+	_, err := s.service.SubscribeCenterOrders(1, 0, entity.Actor{UID: "test-user-123", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrNotCenterStaff, err)
+}
+
+func (s *OrderServiceTestSuite) TestSubscribeCenterOrders_CenterNotFound() {
+	// Arrange
+	s.printCenterRepo.EXPECT().FindByID(uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := s.service.SubscribeCenterOrders(99, 0, entity.Actor{UID: "admin-1", Role: entity.RoleAdmin})
+
+	// Assert
+	s.Equal(ierrors.ErrPrintCenterNotFound, err)
+}
+
+// ============================================================================
+// GetOrdersForUser Tests
+// ============================================================================
+func (s *OrderServiceTestSuite) TestGetOrdersForUser_Success() {
+	// Arrange
+	userUID := "test-user-123"
+	doc := entity.Document{
+		FileName:     "a.pdf",
+		PageCount:    3,
+		PrintOptions: entity.PrintOptions{Pages: "all", Color: entity.BlackAndWhite, PaperSize: entity.A4, Copies: 1},
+	}
+	orders := []entity.Order{
+		{ID: 2, UserUID: userUID, PrintCenterID: 3, Status: entity.StatusPaid, Documents: []entity.Document{doc}},
+		{ID: 1, UserUID: userUID, PrintCenterID: 3, Status: entity.StatusPrinting, Documents: []entity.Document{doc, doc}},
+	}
+
+	// Mock expectations: the center is fetched once for both orders
+	s.orderRepo.EXPECT().
+		List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"PAID", "PRINTING"}, UserUID: userUID}}).
+		Return(orders, repository.PageInfo{Total: 3, NextCursor: "next"}, nil)
+	s.printCenterRepo.EXPECT().
+		FindByID(uint(3)).
+		Return(approvedCenter(3), nil).
+		Times(1)
+
+	// Act
+	result, info, err := s.service.GetOrdersForUser(userUID, dto.ListRequest{Status: []string{"PAID", "PRINTING"}})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(dto.PageInfo{Total: 3, NextCursor: "next"}, info)
+	s.Require().Len(result, 2)
+	s.Equal(orders[0], result[0].Order)
+	s.Require().NotNil(result[0].Price)
+	s.Equal(int64(30), result[0].Price.Total) // 3 sides at 10 cents
+	s.Require().NotNil(result[1].Price)
+	s.Len(result[1].Price.Lines, 2)
+	s.Equal(int64(60), result[1].Price.Total)
+}
+
+func (s *OrderServiceTestSuite) TestGetOrdersForUser_UnpricedOrder() {
+	// Arrange
+	userUID := "test-user-123"
+	orders := []entity.Order{
+		// The center no longer offers A3
+		{ID: 2, UserUID: userUID, PrintCenterID: 3, Documents: []entity.Document{
+			{PageCount: 1, PrintOptions: entity.PrintOptions{Pages: "all", PaperSize: entity.A3, Copies: 1}},
+		}},
+		// No documents uploaded yet
+		{ID: 1, UserUID: userUID, PrintCenterID: 4},
+	}
+
+	// Mock expectations
+	s.orderRepo.EXPECT().
+		List(gomock.Any()).
+		Return(orders, repository.PageInfo{Total: 2}, nil)
+	s.printCenterRepo.EXPECT().
+		FindByID(uint(3)).
+		Return(approvedCenter(3), nil)
+
+	// Act
+	result, _, err := s.service.GetOrdersForUser(userUID, dto.ListRequest{})
+
+	// Assert
+	s.NoError(err)
+	s.Require().Len(result, 2)
+	s.Nil(result[0].Price)
+	s.Nil(result[1].Price)
+}
+
+func (s *OrderServiceTestSuite) TestGetOrdersForUser_RepositoryError() {
+	// Arrange
+	userUID := "test-user-123"
+	s.orderRepo.EXPECT().
+		List(gomock.Any()).
+		Return(nil, repository.PageInfo{}, errors.New("database error"))
+
+	// Act
+	result, _, err := s.service.GetOrdersForUser(userUID, dto.ListRequest{})
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+	s.Contains(err.Error(), "failed to fetch orders for user")
+}
+
+// ============================================================================
+// GetUserOrder Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestGetUserOrder_Success() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{
+		ID:            orderID,
+		UserUID:       userUID,
+		PrintCenterID: 3,
+		Documents: []entity.Document{
+			{PageCount: 2, PrintOptions: entity.PrintOptions{Pages: "all", Color: entity.Color, PaperSize: entity.A4, Copies: 1}},
+		},
+	}, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(3)).Return(approvedCenter(3), nil)
+
+	// Act
+	order, err := s.service.GetUserOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(orderID, order.ID)
+	s.Len(order.Documents, 1)
+	s.Require().NotNil(order.Price)
+	s.Equal(int64(60), order.Price.Total)
+}
+
+func (s *OrderServiceTestSuite) TestGetUserOrder_NotOwner() {
+	// Arrange
+	orderID := uint(1)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: "test-user-123"}, nil)
+
+	// Act: managers and admins are not owners either
+	order, err := s.service.GetUserOrder(orderID, entity.Actor{UID: "someone-else", Role: entity.RoleAdmin})
+
+	// Assert
+	s.Nil(order)
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+func (s *OrderServiceTestSuite) TestGetUserOrder_NotFound() {
+	// Arrange
+	orderID := uint(1)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	order, err := s.service.GetUserOrder(orderID, entity.Actor{UID: "test-user-123", Role: entity.RoleUser})
+
+	// Assert
+	s.Nil(order)
+	s.Equal(ierrors.ErrOrderNotFound, err)
 }
 
 // ============================================================================
@@ -347,15 +1126,29 @@ func (s *OrderServiceTestSuite) TestGetAllOrders_Success() {
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
-		FindAll().
-		Return(expectedOrders, nil)
+		List(repository.ListQuery{Sort: "-total_cost", Filter: repository.ListFilter{CenterID: 3, UserUID: "user1"}}).
+		Return(expectedOrders, repository.PageInfo{Total: 2}, nil)
 
 	// Act
-	result, err := s.service.GetAllOrders()
+	result, info, err := s.service.GetAllOrders(dto.ListRequest{Sort: "-total_cost", CenterID: 3, UserUID: "user1"})
 
 	// Assert
 	s.NoError(err)
 	s.Equal(expectedOrders, result)
+	s.Equal(int64(2), info.Total)
+}
+
+func (s *OrderServiceTestSuite) TestGetAllOrders_UnsupportedSort() {
+	// Arrange
+	s.orderRepo.EXPECT().
+		List(gomock.Any()).
+		Return(nil, repository.PageInfo{}, fmt.Errorf("failed to list orders: %w", repository.ErrUnsupportedSort))
+
+	// Act
+	_, _, err := s.service.GetAllOrders(dto.ListRequest{Sort: "code"})
+
+	// Assert
+	s.Equal(ierrors.ErrUnsupportedSort, err)
 }
 
 // ============================================================================
@@ -364,13 +1157,15 @@ func (s *OrderServiceTestSuite) TestGetAllOrders_Success() {
 func (s *OrderServiceTestSuite) TestUpdateOrderStatus_Success() {
 	// Arrange
 	orderID := uint(1)
-	status := entity.StatusCompleted
-	updatedBy := "admin-123"
+	centerID := uint(3)
+	status := entity.StatusPrinting
+	actor := entity.Actor{UID: "manager-123", Role: entity.RoleManager, CenterID: &centerID}
 
 	existingOrder := &entity.Order{
-		ID:      orderID,
-		Status:  entity.StatusPendingPayment,
-		UserUID: "test-user-123",
+		ID:            orderID,
+		Status:        entity.StatusReadyToPrint,
+		UserUID:       "test-user-123",
+		PrintCenterID: centerID,
 	}
 
 	// Mock expectations
@@ -379,27 +1174,35 @@ func (s *OrderServiceTestSuite) TestUpdateOrderStatus_Success() {
 		Return(existingOrder, nil)
 
 	s.orderRepo.EXPECT().
-		Update(orderID, gomock.Any()).
-		DoAndReturn(func(id uint, updates map[string]any) error {
+		UpdateStatus(orderID, entity.StatusReadyToPrint, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
 			// Verify the updates contain the expected fields
 			s.Equal(status, updates["status"])
-			s.Equal(updatedBy, updates["updated_by"])
+			s.Equal(actor.UID, updates["updated_by"])
 			s.NotNil(updates["updated_at"])
+
+			// Verify the transition is recorded
+			s.Equal(entity.StatusReadyToPrint, history.FromStatus)
+			s.Equal(status, history.ToStatus)
+			s.Equal(actor.UID, history.ActorUID)
+			s.Equal(entity.RoleManager, history.ActorRole)
+			s.Equal("paper loaded", history.Reason)
 			return nil
 		})
 
 	// Act
-	err := s.service.UpdateOrderStatus(orderID, status, updatedBy)
+	err := s.service.UpdateOrderStatus(orderID, status, actor, "paper loaded")
 
 	// Assert
 	s.NoError(err)
+	s.Equal(status, existingOrder.Status)
 }
 
 func (s *OrderServiceTestSuite) TestUpdateOrderStatus_OrderNotFound() {
 	// Arrange
 	orderID := uint(999)
 	status := entity.StatusCompleted
-	updatedBy := "admin-123"
+	actor := entity.Actor{UID: "admin-123", Role: entity.RoleAdmin}
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
@@ -407,25 +1210,41 @@ func (s *OrderServiceTestSuite) TestUpdateOrderStatus_OrderNotFound() {
 		Return(nil, gorm.ErrRecordNotFound)
 
 	// Act
-	err := s.service.UpdateOrderStatus(orderID, status, updatedBy)
+	err := s.service.UpdateOrderStatus(orderID, status, actor, "")
 
 	// Assert
 	s.Error(err)
 	s.Equal(ierrors.ErrOrderNotFound, err)
 }
 
+func (s *OrderServiceTestSuite) TestUpdateOrderStatus_InvalidTransition() {
+	// Arrange
+	orderID := uint(1)
+	actor := entity.Actor{UID: "admin-123", Role: entity.RoleAdmin}
+
+	s.orderRepo.EXPECT().
+		FindByID(orderID).
+		Return(&entity.Order{ID: orderID, Status: entity.StatusCreated}, nil)
+
+	// Act
+	err := s.service.UpdateOrderStatus(orderID, entity.StatusCompleted, actor, "")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidStatusTransition)
+}
+
 func (s *OrderServiceTestSuite) TestUpdateOrderStatus_UpdateError() {
 	// Arrange
 	var orderID uint = 1
-	userUID := "test-user-123"
+	actor := entity.Actor{UID: "admin-123", Role: entity.RoleAdmin}
 	newStatus := entity.StatusPaid
 	dbErr := errors.New("db update error")
 
-	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID}, nil)
-	s.orderRepo.EXPECT().Update(orderID, gomock.Any()).Return(dbErr)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, Status: entity.StatusPendingPayment}, nil)
+	s.orderRepo.EXPECT().UpdateStatus(orderID, entity.StatusPendingPayment, gomock.Any(), gomock.Any()).Return(dbErr)
 
 	// Act
-	err := s.service.UpdateOrderStatus(orderID, newStatus, userUID)
+	err := s.service.UpdateOrderStatus(orderID, newStatus, actor, "")
 
 	// Assert
 	s.Error(err)
@@ -450,18 +1269,73 @@ func (s *OrderServiceTestSuite) TestCancelOrder_Success() {
 	// Mock expectations
 	s.orderRepo.EXPECT().
 		FindByID(orderID).
-		Return(existingOrder, nil).
-		Times(2)
+		Return(existingOrder, nil)
 
 	s.orderRepo.EXPECT().
-		Update(orderID, gomock.Any()).
-		Return(nil)
+		UpdateStatus(orderID, entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusCancelled, updates["status"])
+			s.NotNil(updates["cancelled_at"])
+			s.Equal(userUID, history.ActorUID)
+			s.Equal("cancelled by customer", history.Reason)
+			return nil
+		})
+	s.payments.EXPECT().RefundOrder(existingOrder, "cancelled by customer").Return(nil, nil)
+	s.purger.EXPECT().Purge(existingOrder).Return(1, nil)
 
 	// Act
-	err := s.service.CancelOrder(orderID, userUID)
+	response, err := s.service.CancelOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser}, "")
 
 	// Assert
 	s.NoError(err)
+	s.Equal(entity.StatusCancelled, response.Order.Status)
+	s.Nil(response.Refund)
+	s.Equal(1, response.DeletedDocuments)
+	s.Empty(response.Failures)
+}
+
+func (s *OrderServiceTestSuite) TestCancelOrder_PaidOrderIsRefunded() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	existingOrder := &entity.Order{ID: orderID, UserUID: userUID, Status: entity.StatusReadyToPrint}
+	refund := &entity.Payment{ID: 7, OrderID: orderID, Status: entity.PaymentRefunded}
+
+	s.orderRepo.EXPECT().FindByID(orderID).Return(existingOrder, nil)
+	s.orderRepo.EXPECT().UpdateStatus(orderID, entity.StatusReadyToPrint, gomock.Any(), gomock.Any()).Return(nil)
+	s.payments.EXPECT().RefundOrder(existingOrder, "wrong file").Return(refund, nil)
+	s.purger.EXPECT().Purge(existingOrder).Return(2, nil)
+
+	// Act
+	response, err := s.service.CancelOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser}, "wrong file")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(refund, response.Refund)
+	s.Equal(2, response.DeletedDocuments)
+}
+
+func (s *OrderServiceTestSuite) TestCancelOrder_RefundFailureIsReported() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	existingOrder := &entity.Order{ID: orderID, UserUID: userUID, Status: entity.StatusPaid}
+	pending := &entity.Payment{ID: 7, OrderID: orderID, Status: entity.PaymentRefundPending}
+
+	s.orderRepo.EXPECT().FindByID(orderID).Return(existingOrder, nil)
+	s.orderRepo.EXPECT().UpdateStatus(orderID, entity.StatusPaid, gomock.Any(), gomock.Any()).Return(nil)
+	s.payments.EXPECT().RefundOrder(existingOrder, gomock.Any()).Return(pending, errors.New("provider unavailable"))
+	s.purger.EXPECT().Purge(existingOrder).Return(1, nil)
+
+	// Act
+	response, err := s.service.CancelOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser}, "")
+
+	// Assert: the order stays cancelled
+	s.NoError(err)
+	s.Equal(entity.StatusCancelled, response.Order.Status)
+	s.Equal(pending, response.Refund)
+	s.Require().Len(response.Failures, 1)
+	s.Contains(response.Failures[0], "provider unavailable")
 }
 
 func (s *OrderServiceTestSuite) TestCancelOrder_Unauthorized() {
@@ -481,11 +1355,11 @@ func (s *OrderServiceTestSuite) TestCancelOrder_Unauthorized() {
 		Return(existingOrder, nil)
 
 	// Act
-	err := s.service.CancelOrder(orderID, userUID)
+	_, err := s.service.CancelOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser}, "")
 
 	// Assert
 	s.Error(err)
-	s.Equal(ierrors.ErrUnauthorized, err)
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
 }
 
 func (s *OrderServiceTestSuite) TestCancelOrder_CannotBeCancelled() {
@@ -505,13 +1379,201 @@ func (s *OrderServiceTestSuite) TestCancelOrder_CannotBeCancelled() {
 		Return(existingOrder, nil)
 
 	// Act
-	err := s.service.CancelOrder(orderID, userUID)
+	_, err := s.service.CancelOrder(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser}, "")
 
 	// Assert
 	s.Error(err)
 	s.Equal(ierrors.ErrOrderCannotBeCancelled, err)
 }
 
+func (s *OrderServiceTestSuite) TestCancelOrder_RoleRules() {
+	centerID := uint(3)
+	manager := entity.Actor{UID: "manager-123", Role: entity.RoleManager, CenterID: &centerID}
+	admin := entity.Actor{UID: "admin-123", Role: entity.RoleAdmin}
+
+	tests := []struct {
+		name    string
+		status  entity.OrderStatus
+		actor   entity.Actor
+		reason  string
+		wantErr error
+	}{
+		{"owner cannot cancel once printing", entity.StatusPrinting, entity.Actor{UID: "test-user-123", Role: entity.RoleUser}, "", ierrors.ErrOrderCannotBeCancelled},
+		{"manager needs a reason", entity.StatusPaid, manager, "", ierrors.ErrCancellationReasonRequired},
+		{"manager cannot cancel unpaid orders", entity.StatusPendingPayment, manager, "printer broken", ierrors.ErrOrderCannotBeCancelled},
+		{"manager of another center", entity.StatusPaid, entity.Actor{UID: "manager-456", Role: entity.RoleManager}, "printer broken", ierrors.ErrOrderAccessDenied},
+		{"admin needs a reason", entity.StatusPrinting, admin, "", ierrors.ErrCancellationReasonRequired},
+		{"admin cannot cancel printed orders", entity.StatusPrinted, admin, "fraud", ierrors.ErrOrderCannotBeCancelled},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			order := &entity.Order{ID: 1, UserUID: "test-user-123", PrintCenterID: centerID, Status: tt.status}
+			s.orderRepo.EXPECT().FindByID(uint(1)).Return(order, nil)
+
+			// Act
+			_, err := s.service.CancelOrder(1, tt.actor, tt.reason)
+
+			// Assert
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestUpdateOrderStatus_CancelRefunds() {
+	// Arrange
+	centerID := uint(3)
+	manager := entity.Actor{UID: "manager-123", Role: entity.RoleManager, CenterID: &centerID}
+	existingOrder := &entity.Order{ID: 1, UserUID: "test-user-123", PrintCenterID: centerID, Status: entity.StatusPaid}
+
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(existingOrder, nil)
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), entity.StatusPaid, gomock.Any(), gomock.Any()).Return(nil)
+	s.payments.EXPECT().RefundOrder(existingOrder, "out of toner").Return(&entity.Payment{ID: 7}, nil)
+	s.purger.EXPECT().Purge(existingOrder).Return(1, nil)
+
+	// Act
+	err := s.service.UpdateOrderStatus(1, entity.StatusCancelled, manager, "out of toner")
+
+	// Assert
+	s.NoError(err)
+}
+
+// ============================================================================
+// GetOrderHistory Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestGetOrderHistory_Owner() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	expected := []entity.OrderStatusHistory{
+		{ID: 1, OrderID: orderID, FromStatus: entity.StatusPendingPayment, ToStatus: entity.StatusPaid, ActorUID: "system"},
+	}
+
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: userUID}, nil)
+	s.orderRepo.EXPECT().FindStatusHistory(orderID).Return(expected, nil)
+
+	// Act
+	history, err := s.service.GetOrderHistory(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(expected, history)
+}
+
+func (s *OrderServiceTestSuite) TestGetOrderHistory_AccessDenied() {
+	// Arrange
+	orderID := uint(1)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: "test-user-123"}, nil)
+
+	// Act
+	_, err := s.service.GetOrderHistory(orderID, entity.Actor{UID: "someone-else", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+// ============================================================================
+// GetPurgeReport Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestGetPurgeReport_PartiallyPurged() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	printedAt := time.Now().Add(-time.Hour)
+	deletedAt := time.Now()
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{
+		ID:      orderID,
+		UserUID: userUID,
+		Status:  entity.StatusPrinted,
+		Documents: []entity.Document{
+			{ID: 10, FileName: "a.pdf", PrintedAt: &printedAt, StorageDeletedAt: &deletedAt},
+			{ID: 11, FileName: "b.pdf", PrintedAt: &printedAt},
+		},
+	}, nil)
+
+	// Act
+	report, err := s.service.GetPurgeReport(orderID, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(entity.StatusPrinted, report.Status)
+	s.False(report.AllPurged)
+	s.Require().Len(report.Documents, 2)
+	s.True(report.Documents[0].Deleted)
+	s.Equal(&deletedAt, report.Documents[0].StorageDeletedAt)
+	s.False(report.Documents[1].Deleted)
+}
+
+func (s *OrderServiceTestSuite) TestGetPurgeReport_AccessDenied() {
+	// Arrange
+	orderID := uint(1)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: "test-user-123"}, nil)
+
+	// Act
+	_, err := s.service.GetPurgeReport(orderID, entity.Actor{UID: "someone-else", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+// ============================================================================
+// GetDeletionReceipt Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestGetDeletionReceipt_Success() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	deletedAt := time.Now()
+	expected := &entity.DeletionReceipt{DocumentID: 10, OrderCode: "ABC123", Signature: "sig"}
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{
+		ID:        orderID,
+		UserUID:   userUID,
+		Documents: []entity.Document{{ID: 10, StorageDeletedAt: &deletedAt}},
+	}, nil)
+	s.orderRepo.EXPECT().FindDeletionReceipt(uint(10)).Return(expected, nil)
+
+	// Act
+	receipt, err := s.service.GetDeletionReceipt(orderID, 10, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(expected, receipt)
+}
+
+func (s *OrderServiceTestSuite) TestGetDeletionReceipt_NotPurgedYet() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{
+		ID:        orderID,
+		UserUID:   userUID,
+		Documents: []entity.Document{{ID: 10}},
+	}, nil)
+
+	// Act
+	_, err := s.service.GetDeletionReceipt(orderID, 10, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrDocumentNotPurged, err)
+}
+
+func (s *OrderServiceTestSuite) TestGetDeletionReceipt_DocumentOfAnotherOrder() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: userUID}, nil)
+
+	// Act
+	_, err := s.service.GetDeletionReceipt(orderID, 99, entity.Actor{UID: userUID, Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrDocumentNotFound, err)
+}
+
 // ============================================================================
 // DeleteOrder Tests
 // ============================================================================
@@ -556,21 +1618,28 @@ func (s *OrderServiceTestSuite) TestDeleteOrder_NotFound() {
 func (s *OrderServiceTestSuite) TestCalculateOrderCost_Success() {
 	// Arrange
 	orderID := uint(1)
+	centerID := uint(3)
 	order := &entity.Order{
-		ID: orderID,
+		ID:            orderID,
+		PrintCenterID: centerID,
 		Documents: []entity.Document{
 			{
-				Size: 100000, // 100KB
+				Size:      2 * 1024 * 1024, // A large one-page scan
+				PageCount: 1,
 				PrintOptions: entity.PrintOptions{
+					Pages:       "all",
 					Color:       entity.BlackAndWhite,
+					PaperSize:   entity.A4,
 					DoubleSided: false,
 					Copies:      1,
 				},
 			},
 			{
-				Size: 200000, // 200KB
+				PageCount: 10,
 				PrintOptions: entity.PrintOptions{
+					Pages:       "2-4,7",
 					Color:       entity.Color,
+					PaperSize:   entity.A4,
 					DoubleSided: true,
 					Copies:      2,
 				},
@@ -582,18 +1651,20 @@ func (s *OrderServiceTestSuite) TestCalculateOrderCost_Success() {
 	s.orderRepo.EXPECT().
 		FindByID(orderID).
 		Return(order, nil)
+	s.printCenterRepo.EXPECT().
+		FindByID(centerID).
+		Return(approvedCenter(centerID), nil)
 
 	// Act
 	cost, err := s.service.CalculateOrderCost(orderID)
 
 	// Assert
 	s.NoError(err)
-	s.Greater(cost, int64(0))
-	
+
 	// Verify cost calculation logic
-	// First document: 2 pages * 10 cents = 20 cents
-	// Second document: 4 pages * 10 cents * 3 (color) * 0.6 (double-sided) * 2 (copies) = 144 cents
-	expectedCost := int64(20 + 144)
+	// First document: 1 page * 10 cents = 10 cents
+	// Second document: 4 selected pages * 2 copies * 30 cents (color, per side) = 240 cents
+	expectedCost := int64(10 + 240)
 	s.Equal(expectedCost, cost)
 }
 
@@ -619,14 +1690,18 @@ func (s *OrderServiceTestSuite) TestCalculateOrderCost_EmptyDocuments() {
 	// Arrange
 	orderID := uint(1)
 	order := &entity.Order{
-		ID:        orderID,
-		Documents: []entity.Document{}, // No documents
+		ID:            orderID,
+		PrintCenterID: 3,
+		Documents:     []entity.Document{}, // No documents
 	}
 
 	// Mock expectations
 	s.orderRepo.EXPECT().
 		FindByID(orderID).
 		Return(order, nil)
+	s.printCenterRepo.EXPECT().
+		FindByID(uint(3)).
+		Return(approvedCenter(3), nil)
 
 	// Act
 	cost, err := s.service.CalculateOrderCost(orderID)
@@ -636,9 +1711,405 @@ func (s *OrderServiceTestSuite) TestCalculateOrderCost_EmptyDocuments() {
 	s.Equal(int64(0), cost)
 }
 
+// ============================================================================
+// QuoteOrder Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestQuoteOrder_Success() {
+	// Arrange
+	centerID := uint(1)
+	req := dto.QuoteRequest{
+		Documents: []dto.QuoteDocumentRequest{
+			{
+				FileName:     "thesis.pdf",
+				PageCount:    12,
+				PrintOptions: entity.PrintOptions{Pages: "1-10", Color: entity.BlackAndWhite, PaperSize: entity.A4, Copies: 3},
+			},
+			{
+				PageCount:    2,
+				PrintOptions: entity.PrintOptions{Pages: "all", Color: entity.Color, PaperSize: entity.A4, Copies: 1},
+			},
+		},
+	}
+
+	// Nothing is persisted
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+
+	// Act
+	quote, err := s.service.QuoteOrder(centerID, req)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("EUR", quote.Currency)
+	s.Require().Len(quote.Lines, 2)
+	s.Equal("thesis.pdf", quote.Lines[0].FileName)
+	s.Equal(int64(300), quote.Lines[0].Amount) // 10 pages * 3 copies * 10 cents
+	s.Equal(int64(60), quote.Lines[1].Amount)  // 2 pages * 30 cents
+	s.Equal(int64(360), quote.Total)
+}
+
+func (s *OrderServiceTestSuite) TestQuoteOrder_PageRangeOutOfDocument() {
+	// Arrange
+	centerID := uint(1)
+	req := dto.QuoteRequest{
+		Documents: []dto.QuoteDocumentRequest{
+			{PageCount: 2, PrintOptions: entity.PrintOptions{Pages: "1-3", Color: entity.BlackAndWhite, PaperSize: entity.A4, Copies: 1}},
+		},
+	}
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(approvedCenter(centerID), nil)
+
+	// Act
+	_, err := s.service.QuoteOrder(centerID, req)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidPageRange)
+}
+
+func (s *OrderServiceTestSuite) TestQuoteOrder_PrintCenterNotOperational() {
+	// Arrange
+	centerID := uint(1)
+	s.printCenterRepo.EXPECT().FindByID(centerID).Return(&entity.PrintCenter{ID: centerID, Status: entity.StatusSuspended}, nil)
+
+	// Act
+	_, err := s.service.QuoteOrder(centerID, dto.QuoteRequest{})
+
+	// Assert
+	s.Equal(ierrors.ErrPrintCenterNotOperational, err)
+}
+
+// ============================================================================
+// VerifyPickup Tests
+// ============================================================================
+
+func managerOf(centerID uint) entity.Actor {
+	return entity.Actor{UID: "manager-1", Role: entity.RoleManager, CenterID: &centerID}
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_ReadyForPickup_Completes() {
+	// Arrange
+	centerID := uint(1)
+	order := &entity.Order{ID: 7, Code: "ABC123", PrintCenterID: centerID, Status: entity.StatusReadyForPickup}
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+	s.orderRepo.EXPECT().
+		UpdateStatus(order.ID, entity.StatusReadyForPickup, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusCompleted, updates["status"])
+			s.Equal("manager-1", updates["verified_by"])
+			s.Contains(updates, "verified_at")
+			s.Equal("manager-1", history.ActorUID)
+			return nil
+		})
+
+	// Act
+	result, err := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: " abc123 "}, managerOf(centerID))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusCompleted, result.Status)
+	s.Equal("manager-1", result.VerifiedBy)
+	s.NotNil(result.VerifiedAt)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_AwaitingUser_StartsPrinting() {
+	// Arrange
+	centerID := uint(1)
+	order := &entity.Order{ID: 7, Code: "ABC123", PrintCenterID: centerID, Status: entity.StatusAwaitingUser}
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+	s.orderRepo.EXPECT().UpdateStatus(order.ID, entity.StatusAwaitingUser, gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	result, err := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: "ABC123"}, managerOf(centerID))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusPrinting, result.Status)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_NotReady() {
+	// Arrange
+	centerID := uint(1)
+	order := &entity.Order{ID: 7, Code: "ABC123", PrintCenterID: centerID, Status: entity.StatusPrinting}
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+
+	// Act
+	_, err := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: "ABC123"}, managerOf(centerID))
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderNotReadyForPickup)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_NotManagerOfCenter() {
+	// Arrange
+	otherCenter := uint(2)
+
+	// Act
+	_, err := s.service.VerifyPickup(1, dto.VerifyPickupRequest{Code: "ABC123"}, managerOf(otherCenter))
+
+	// Assert
+	s.Equal(ierrors.ErrNotCenterManager, err)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_WrongCodesAreThrottledPerCenter() {
+	// Arrange: the suite throttle allows 2 wrong codes per center
+	centerID := uint(1)
+	otherCenterOrder := &entity.Order{ID: 9, Code: "ZZZ999", PrintCenterID: 2, Status: entity.StatusReadyForPickup}
+	s.orderRepo.EXPECT().FindByCode("UNKNOWN").Return(nil, gorm.ErrRecordNotFound)
+	s.orderRepo.EXPECT().FindByCode("ZZZ999").Return(otherCenterOrder, nil)
+
+	// Act
+	_, err1 := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: "UNKNOWN"}, managerOf(centerID))
+	_, err2 := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: "ZZZ999"}, managerOf(centerID))
+	_, err3 := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Code: "ABC123"}, managerOf(centerID))
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidPickupCode, err1)
+	s.Equal(ierrors.ErrInvalidPickupCode, err2)
+	s.Equal(ierrors.ErrTooManyVerificationAttempts, err3)
+
+	// Other centers are not affected
+	s.orderRepo.EXPECT().FindByCode("UNKNOWN").Return(nil, gorm.ErrRecordNotFound)
+	_, err := s.service.VerifyPickup(3, dto.VerifyPickupRequest{Code: "UNKNOWN"}, managerOf(3))
+	s.Equal(ierrors.ErrInvalidPickupCode, err)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_WithQRToken() {
+	// Arrange
+	centerID := uint(1)
+	order := &entity.Order{ID: 7, Code: "ABC123", PrintCenterID: centerID, Status: entity.StatusReadyForPickup}
+	token, _, err := s.pickupSigner.Sign(order)
+	s.Require().NoError(err)
+
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+	s.orderRepo.EXPECT().UpdateStatus(order.ID, entity.StatusReadyForPickup, gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	result, err := s.service.VerifyPickup(centerID, dto.VerifyPickupRequest{Token: token}, managerOf(centerID))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusCompleted, result.Status)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_QRTokenOfAnotherCenter() {
+	// Arrange
+	token, _, err := s.pickupSigner.Sign(&entity.Order{Code: "ABC123", PrintCenterID: 2})
+	s.Require().NoError(err)
+
+	// Act: no lookup is made for tokens signed for another center
+	_, err = s.service.VerifyPickup(1, dto.VerifyPickupRequest{Token: token}, managerOf(1))
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidPickupCode, err)
+}
+
+func (s *OrderServiceTestSuite) TestVerifyPickup_ForgedQRToken() {
+	// Arrange
+	forger := service.NewPickupTokenSigner([]byte("guessed-secret"), time.Hour)
+	token, _, err := forger.Sign(&entity.Order{Code: "ABC123", PrintCenterID: 1})
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.service.VerifyPickup(1, dto.VerifyPickupRequest{Token: token}, managerOf(1))
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidPickupCode, err)
+}
+
+// ============================================================================
+// GetPickupToken Tests
+// ============================================================================
+
+func (s *OrderServiceTestSuite) TestGetPickupToken_Owner() {
+	// Arrange
+	order := &entity.Order{ID: 7, Code: "ABC123", UserUID: "user-1", PrintCenterID: 3, Status: entity.StatusPaid}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	token, expiresAt, err := s.service.GetPickupToken(order.ID, entity.Actor{UID: "user-1", Role: entity.RoleUser})
+
+	// Assert
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now().Add(time.Hour), expiresAt, time.Minute)
+	claims, err := s.pickupSigner.Verify(token)
+	s.Require().NoError(err)
+	s.Equal("ABC123", claims.Code)
+	s.Equal(uint(3), claims.CenterID)
+}
+
+func (s *OrderServiceTestSuite) TestGetPickupToken_NotOwner() {
+	// Arrange
+	order := &entity.Order{ID: 7, UserUID: "user-1", Status: entity.StatusPaid}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, _, err := s.service.GetPickupToken(order.ID, entity.Actor{UID: "user-2", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+func (s *OrderServiceTestSuite) TestGetPickupToken_OrderCompleted() {
+	// Arrange
+	order := &entity.Order{ID: 7, UserUID: "user-1", Status: entity.StatusCompleted}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, _, err := s.service.GetPickupToken(order.ID, entity.Actor{UID: "user-1", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderNotActive, err)
+}
+
+// ============================================================================
+// ScheduleOrder Tests
+// ============================================================================
+
+// nextMonday returns the next Monday at the given time of day, in the server time zone.
+func nextMonday(hour, minute int) time.Time {
+	now := time.Now()
+	days := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	d := now.AddDate(0, 0, days)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, time.Local)
+}
+
+// openOnMondays returns a center open on Mondays from 08:00 to 12:00 and from 14:00 to 18:00.
+func openOnMondays(id uint) *entity.PrintCenter {
+	center := approvedCenter(id)
+	center.WorkingHours = []entity.WorkingHour{
+		{Day: entity.Monday, Start: "08:00", End: "12:00"},
+		{Day: entity.Monday, Start: "14:00", End: "18:00"},
+	}
+	return center
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_PrePrint() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPaid}
+	pickup := nextMonday(10, 30)
+	req := dto.ScheduleOrderRequest{PickupTime: pickup, PrintMode: entity.PrePrint}
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(openOnMondays(1), nil)
+	s.orderRepo.EXPECT().
+		UpdateStatus(order.ID, entity.StatusPaid, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusReadyToPrint, updates["status"])
+			s.Equal(entity.PrePrint, updates["print_mode"])
+			s.True(pickup.Equal(updates["pickup_time"].(time.Time)))
+			return nil
+		})
+
+	// Act
+	result, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusReadyToPrint, result.Status)
+	s.Equal(entity.PrePrint, result.PrintMode)
+	s.True(pickup.Equal(*result.PickupTime))
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_PrintUponArrival() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPaid}
+	req := dto.ScheduleOrderRequest{PickupTime: nextMonday(17, 59), PrintMode: entity.PrintUponArrival}
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(openOnMondays(1), nil)
+	s.orderRepo.EXPECT().UpdateStatus(order.ID, entity.StatusPaid, gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	result, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(entity.StatusAwaitingUser, result.Status)
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_OutsideWorkingHours() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPaid}
+	req := dto.ScheduleOrderRequest{PickupTime: nextMonday(12, 0), PrintMode: entity.PrePrint}
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(openOnMondays(1), nil)
+
+	// Act
+	_, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrPickupOutsideWorkingHours)
+	s.ErrorContains(err, "open on Monday 08:00-12:00, 14:00-18:00")
+	s.Equal(entity.StatusPaid, order.Status)
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_ClosedDay() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPaid}
+	req := dto.ScheduleOrderRequest{PickupTime: nextMonday(10, 0).AddDate(0, 0, 1), PrintMode: entity.PrePrint}
+
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+	s.printCenterRepo.EXPECT().FindByID(uint(1)).Return(openOnMondays(1), nil)
+
+	// Act
+	_, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.ErrorContains(err, "closed on Tuesday")
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_InThePast() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPaid}
+	req := dto.ScheduleOrderRequest{PickupTime: time.Now().Add(-time.Hour), PrintMode: entity.PrePrint}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.Equal(ierrors.ErrPickupTimeInPast, err)
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_NotPaid() {
+	// Arrange
+	owner := entity.Actor{UID: "user-1", Role: entity.RoleUser}
+	order := &entity.Order{ID: 5, UserUID: owner.UID, PrintCenterID: 1, Status: entity.StatusPendingPayment}
+	req := dto.ScheduleOrderRequest{PickupTime: nextMonday(10, 0), PrintMode: entity.PrePrint}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, err := s.service.ScheduleOrder(order.ID, req, owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderNotSchedulable)
+}
+
+func (s *OrderServiceTestSuite) TestScheduleOrder_NotOwner() {
+	// Arrange
+	order := &entity.Order{ID: 5, UserUID: "user-1", PrintCenterID: 1, Status: entity.StatusPaid}
+	req := dto.ScheduleOrderRequest{PickupTime: nextMonday(10, 0), PrintMode: entity.PrePrint}
+	s.orderRepo.EXPECT().FindByID(order.ID).Return(order, nil)
+
+	// Act
+	_, err := s.service.ScheduleOrder(order.ID, req, entity.Actor{UID: "user-2", Role: entity.RoleUser})
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
 // ============================================================================
 // generateUniquePickupCode Tests
 // ============================================================================
+
 func (s *OrderServiceTestSuite) TestCreateOrder_GenerateUniqueCode_RetryLogic() {
 	// Arrange
 	userUID := "test-user-123"
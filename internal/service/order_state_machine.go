@@ -0,0 +1,199 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_order_state_machine.go -package=mocks github.com/kimbasn/printly/internal/service OrderStateMachine
+
+// OrderStateMachine is the single entry point for changing an order's status.
+// It enforces the lifecycle defined by entity.Order.CanTransitionTo, applies
+// per-role guards, records every accepted transition, publishes it to the
+// live order stream of the order's print center and notifies the customer.
+type OrderStateMachine interface {
+	// Authorize checks whether the actor may move the order to the given status
+	Authorize(order *entity.Order, to entity.OrderStatus, actor entity.Actor) error
+	// Transition moves the order to the given status. Extra column updates are applied in the same write.
+	Transition(order *entity.Order, to entity.OrderStatus, actor entity.Actor, reason string, updates map[string]any) error
+	// TransitionWithDocuments is Transition, also applying column updates to documents of the order,
+	// keyed by their ID, in the same transaction.
+	TransitionWithDocuments(order *entity.Order, to entity.OrderStatus, actor entity.Actor, reason string, updates map[string]any, documents map[uint]map[string]any) error
+}
+
+// roleTargets lists the statuses each role may move an order to.
+// Admins and the system are not restricted beyond the order lifecycle.
+var roleTargets = map[entity.Role][]entity.OrderStatus{
+	// Customers may only confirm the uploads of, cancel or schedule the pickup of their own orders
+	entity.RoleUser: {entity.StatusPendingPayment, entity.StatusCancelled, entity.StatusAwaitingUser, entity.StatusReadyToPrint},
+	// Managers run the print queue of their center, and may turn down orders they can not print
+	entity.RoleManager: {
+		entity.StatusCancelled,
+		entity.StatusReadyToPrint,
+		entity.StatusPrinting,
+		entity.StatusPrinted,
+		entity.StatusFailed,
+		entity.StatusReadyForPickup,
+		entity.StatusCompleted,
+	},
+}
+
+// statusNotifications lists the statuses the customer is notified of.
+var statusNotifications = map[entity.OrderStatus]entity.NotificationEvent{
+	entity.StatusPaid:           entity.NotifyOrderPaid,
+	entity.StatusReadyForPickup: entity.NotifyOrderReadyForPickup,
+	entity.StatusCancelled:      entity.NotifyOrderCancelled,
+}
+
+type orderStateMachine struct {
+	orderRepo repository.OrderRepository
+	events    OrderEventBus
+	notifier  Notifier
+	logger    *zap.Logger
+}
+
+// NewOrderStateMachine creates a new instance of OrderStateMachine.
+func NewOrderStateMachine(orderRepo repository.OrderRepository, events OrderEventBus, notifier Notifier, logger *zap.Logger) OrderStateMachine {
+	return &orderStateMachine{
+		orderRepo: orderRepo,
+		events:    events,
+		notifier:  notifier,
+		logger:    logger,
+	}
+}
+
+// Authorize checks the lifecycle first, then the role guards.
+func (m *orderStateMachine) Authorize(order *entity.Order, to entity.OrderStatus, actor entity.Actor) error {
+	if !order.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ierrors.ErrInvalidStatusTransition, order.Status, to)
+	}
+
+	switch actor.Role {
+	case entity.RoleAdmin, entity.RoleSystem:
+		return nil
+	case entity.RoleManager:
+		if !actor.ManagesCenter(order.PrintCenterID) {
+			return ierrors.ErrTransitionNotAllowed
+		}
+	case entity.RoleUser:
+		if order.UserUID != actor.UID {
+			return ierrors.ErrTransitionNotAllowed
+		}
+	default:
+		return ierrors.ErrTransitionNotAllowed
+	}
+
+	if !slices.Contains(roleTargets[actor.Role], to) {
+		return ierrors.ErrTransitionNotAllowed
+	}
+	return nil
+}
+
+// Transition validates and persists a status change together with its history row.
+// On success the given order is updated in place.
+func (m *orderStateMachine) Transition(order *entity.Order, to entity.OrderStatus, actor entity.Actor, reason string, updates map[string]any) error {
+	return m.TransitionWithDocuments(order, to, actor, reason, updates, nil)
+}
+
+// TransitionWithDocuments is Transition, the document updates being written in the transaction of the status change.
+func (m *orderStateMachine) TransitionWithDocuments(order *entity.Order, to entity.OrderStatus, actor entity.Actor, reason string, updates map[string]any, documents map[uint]map[string]any) error {
+	if err := m.Authorize(order, to, actor); err != nil {
+		m.logger.Warn("Order status transition rejected",
+			zap.Uint("orderID", order.ID),
+			zap.String("from", string(order.Status)),
+			zap.String("to", string(to)),
+			zap.String("actor", actor.UID),
+			zap.Error(err))
+		return err
+	}
+
+	from := order.Status
+	now := time.Now()
+
+	changes := map[string]any{
+		"status":     to,
+		"updated_by": actor.UID,
+		"updated_at": now,
+	}
+	if to == entity.StatusCancelled {
+		changes["cancelled_at"] = now
+	}
+	maps.Copy(changes, updates)
+
+	history := &entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorUID:   actor.UID,
+		ActorRole:  actor.Role,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+
+	var err error
+	if len(documents) == 0 {
+		err = m.orderRepo.UpdateStatus(order.ID, from, changes, history)
+	} else {
+		err = m.orderRepo.UpdateStatusAndDocuments(order.ID, from, changes, documents, history)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrStaleStatus) {
+			return ierrors.ErrOrderStatusConflict
+		}
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	order.Status = to
+	order.UpdatedBy = actor.UID
+	order.UpdatedAt = now
+	if to == entity.StatusCancelled {
+		order.CancelledAt = &now
+	}
+
+	// Printed documents become eligible for purge. Documents left unmarked are
+	// still purged once the order reaches a terminal status.
+	if to == entity.StatusPrinted {
+		if err := m.orderRepo.MarkDocumentsPrinted(order.ID, now); err != nil {
+			m.logger.Error("Failed to mark documents as printed", zap.Uint("orderID", order.ID), zap.Error(err))
+		}
+		for i := range order.Documents {
+			if order.Documents[i].PrintedAt == nil {
+				order.Documents[i].PrintedAt = &now
+			}
+		}
+	}
+
+	eventType := dto.OrderEventStatusChanged
+	switch to {
+	case entity.StatusPaid:
+		eventType = dto.OrderEventPaid
+	case entity.StatusCancelled:
+		eventType = dto.OrderEventCancelled
+	}
+	m.events.Publish(newOrderEvent(eventType, order, from))
+
+	// A failed notification never fails the transition
+	if event, ok := statusNotifications[to]; ok {
+		if err := m.notifier.NotifyOrder(OrderNotification{Event: event, Order: order}); err != nil {
+			m.logger.Error("Failed to notify order status change", zap.Uint("orderID", order.ID), zap.String("event", string(event)), zap.Error(err))
+		}
+	}
+
+	m.logger.Info("Order status changed",
+		zap.Uint("orderID", order.ID),
+		zap.String("from", string(from)),
+		zap.String("to", string(to)),
+		zap.String("actor", actor.UID),
+		zap.String("role", string(actor.Role)))
+	return nil
+}
@@ -0,0 +1,241 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrderStateMachineTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	orderRepo    *mocks.MockOrderRepository
+	events       service.OrderEventBus
+	notifier     *mocks.MockNotifier
+	stateMachine service.OrderStateMachine
+}
+
+func (s *OrderStateMachineTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.events = service.NewOrderEventBus(service.DefaultOrderEventHistory)
+	s.notifier = mocks.NewMockNotifier(s.ctrl)
+	s.stateMachine = service.NewOrderStateMachine(s.orderRepo, s.events, s.notifier, zap.NewNop())
+}
+
+func (s *OrderStateMachineTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrderStateMachine(t *testing.T) {
+	suite.Run(t, new(OrderStateMachineTestSuite))
+}
+
+// ============================================================================
+// Authorize Tests
+// ============================================================================
+
+func (s *OrderStateMachineTestSuite) TestAuthorize_RoleGuards() {
+	centerID := uint(3)
+	otherCenterID := uint(4)
+	owner := entity.Actor{UID: "test-user-123", Role: entity.RoleUser}
+	manager := entity.Actor{UID: "manager-123", Role: entity.RoleManager, CenterID: &centerID}
+	otherManager := entity.Actor{UID: "manager-456", Role: entity.RoleManager, CenterID: &otherCenterID}
+	admin := entity.Actor{UID: "admin-123", Role: entity.RoleAdmin}
+
+	tests := []struct {
+		name    string
+		from    entity.OrderStatus
+		to      entity.OrderStatus
+		actor   entity.Actor
+		wantErr error
+	}{
+		{"owner cancels", entity.StatusPendingPayment, entity.StatusCancelled, owner, nil},
+		{"owner schedules", entity.StatusPaid, entity.StatusAwaitingUser, owner, nil},
+		{"owner cannot complete", entity.StatusReadyForPickup, entity.StatusCompleted, owner, ierrors.ErrTransitionNotAllowed},
+		{"stranger cannot cancel", entity.StatusPendingPayment, entity.StatusCancelled, entity.Actor{UID: "someone-else", Role: entity.RoleUser}, ierrors.ErrTransitionNotAllowed},
+		{"manager prints", entity.StatusReadyToPrint, entity.StatusPrinting, manager, nil},
+		{"manager completes", entity.StatusReadyForPickup, entity.StatusCompleted, manager, nil},
+		{"manager turns down an order", entity.StatusPaid, entity.StatusCancelled, manager, nil},
+		{"manager cannot mark paid", entity.StatusPendingPayment, entity.StatusPaid, manager, ierrors.ErrTransitionNotAllowed},
+		{"manager of another center", entity.StatusReadyToPrint, entity.StatusPrinting, otherManager, ierrors.ErrTransitionNotAllowed},
+		{"system expires", entity.StatusPendingPayment, entity.StatusCancelled, entity.SystemActor, nil},
+		{"admin marks paid", entity.StatusPendingPayment, entity.StatusPaid, admin, nil},
+		{"admin cannot skip steps", entity.StatusCreated, entity.StatusCompleted, admin, ierrors.ErrInvalidStatusTransition},
+		{"terminal status", entity.StatusCompleted, entity.StatusCancelled, entity.SystemActor, ierrors.ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			order := &entity.Order{ID: 1, UserUID: "test-user-123", PrintCenterID: centerID, Status: tt.from}
+
+			// Act
+			err := s.stateMachine.Authorize(order, tt.to, tt.actor)
+
+			// Assert
+			if tt.wantErr == nil {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Transition Tests
+// ============================================================================
+
+func (s *OrderStateMachineTestSuite) TestTransition_AppliesExtraUpdates() {
+	// Arrange
+	order := &entity.Order{ID: 1, Status: entity.StatusPendingPayment}
+
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusPaid, updates["status"])
+			s.Equal("now", updates["paid_at"])
+			s.Equal(entity.RoleSystem, history.ActorRole)
+			s.Equal("payment succeeded", history.Reason)
+			return nil
+		})
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).Return(nil)
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusPaid, entity.SystemActor, "payment succeeded", map[string]any{"paid_at": "now"})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(entity.StatusPaid, order.Status)
+	s.Equal("system", order.UpdatedBy)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_PrintedMarksDocuments() {
+	// Arrange
+	order := &entity.Order{ID: 1, Status: entity.StatusPrinting, Documents: []entity.Document{{ID: 10, OrderID: 1}}}
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPrinting, gomock.Any(), gomock.Any()).
+		Return(nil)
+	s.orderRepo.EXPECT().MarkDocumentsPrinted(uint(1), gomock.Any()).Return(nil)
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusPrinted, entity.SystemActor, "", nil)
+
+	// Assert
+	s.NoError(err)
+	s.NotNil(order.Documents[0].PrintedAt)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_PublishesEvents() {
+	// Arrange
+	sub := s.events.Subscribe(4, 0)
+	defer sub.Close()
+	order := &entity.Order{ID: 1, Code: "ABC123", PrintCenterID: 4, Status: entity.StatusPendingPayment}
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).Return(nil).Times(2)
+
+	// Act
+	s.Require().NoError(s.stateMachine.Transition(order, entity.StatusPaid, entity.SystemActor, "", nil))
+	s.Require().NoError(s.stateMachine.Transition(order, entity.StatusReadyToPrint, entity.SystemActor, "", nil))
+	s.Require().NoError(s.stateMachine.Transition(order, entity.StatusCancelled, entity.SystemActor, "", nil))
+
+	// Assert
+	paid := <-sub.Events
+	s.Equal(dto.OrderEventPaid, paid.Type)
+	s.Equal(entity.StatusPendingPayment, paid.PreviousStatus)
+	s.Equal(entity.StatusPaid, paid.Status)
+	s.Equal("ABC123", paid.Code)
+	changed := <-sub.Events
+	s.Equal(dto.OrderEventStatusChanged, changed.Type)
+	s.Equal(entity.StatusReadyToPrint, changed.Status)
+	cancelled := <-sub.Events
+	s.Equal(dto.OrderEventCancelled, cancelled.Type)
+	s.Equal(entity.StatusReadyToPrint, cancelled.PreviousStatus)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_NotifiesCustomer() {
+	// Arrange
+	order := &entity.Order{ID: 1, Status: entity.StatusPrinted}
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	// Only the first transition is notified: completed orders are not
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).DoAndReturn(func(n service.OrderNotification) error {
+		s.Equal(entity.NotifyOrderReadyForPickup, n.Event)
+		s.Same(order, n.Order)
+		return nil
+	})
+
+	// Act
+	s.Require().NoError(s.stateMachine.Transition(order, entity.StatusReadyForPickup, entity.SystemActor, "", nil))
+	err := s.stateMachine.Transition(order, entity.StatusCompleted, entity.SystemActor, "", nil)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_NotificationFailureIsIgnored() {
+	// Arrange
+	order := &entity.Order{ID: 1, Status: entity.StatusPendingPayment}
+	s.orderRepo.EXPECT().UpdateStatus(uint(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	s.notifier.EXPECT().NotifyOrder(gomock.Any()).Return(errors.New("database is down"))
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusCancelled, entity.SystemActor, "", nil)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(entity.StatusCancelled, order.Status)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_StaleStatus() {
+	// Arrange
+	order := &entity.Order{ID: 1, Status: entity.StatusPendingPayment}
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		Return(repository.ErrStaleStatus)
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusCancelled, entity.SystemActor, "", nil)
+
+	// Assert
+	s.Equal(ierrors.ErrOrderStatusConflict, err)
+	s.Equal(entity.StatusPendingPayment, order.Status)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_RejectedIsNotPersisted() {
+	// Arrange
+	order := &entity.Order{ID: 1, UserUID: "test-user-123", Status: entity.StatusPrinting}
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusCancelled, entity.Actor{UID: "test-user-123", Role: entity.RoleUser}, "", nil)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidStatusTransition)
+	s.Equal(entity.StatusPrinting, order.Status)
+}
+
+func (s *OrderStateMachineTestSuite) TestTransition_RejectedIsNotPublished() {
+	// Arrange
+	sub := s.events.Subscribe(4, 0)
+	defer sub.Close()
+	order := &entity.Order{ID: 1, PrintCenterID: 4, Status: entity.StatusPrinting}
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPrinting, gomock.Any(), gomock.Any()).
+		Return(repository.ErrStaleStatus)
+
+	// Act
+	err := s.stateMachine.Transition(order, entity.StatusPrinted, entity.SystemActor, "", nil)
+
+	// Assert
+	s.Error(err)
+	s.Empty(sub.Events)
+}
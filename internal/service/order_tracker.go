@@ -0,0 +1,220 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../mocks/mock_order_tracker.go -package=mocks github.com/kimbasn/printly/internal/service OrderTracker
+
+// OrderTracker lets customers follow the status of an order live, with its pickup code and
+// the tracking token issued when the order was created. It only reveals status-level data.
+type OrderTracker interface {
+	// Follow streams the tracking status of an order, starting with its current status
+	Follow(code, token string) (*OrderTrackingStream, error)
+}
+
+// OrderTrackingStream delivers the tracking status of an order each time it changes.
+type OrderTrackingStream struct {
+	// Updates is closed once the order is over, once the stream is closed, or when the
+	// stream falls behind the events of the print center and should be reopened
+	Updates <-chan dto.OrderTracking
+
+	close func()
+}
+
+// Close stops the stream.
+func (s *OrderTrackingStream) Close() {
+	s.close()
+}
+
+// trackingTokenSize is the number of random bytes of a tracking token
+const trackingTokenSize = 24
+
+type orderTracker struct {
+	orderRepo repository.OrderRepository
+	events    OrderEventBus
+	printTime time.Duration
+	logger    *zap.Logger
+}
+
+// NewOrderTracker creates a new instance of OrderTracker. Ready times are estimated
+// from the average time the centers take to print an order.
+func NewOrderTracker(orderRepo repository.OrderRepository, events OrderEventBus, printTime time.Duration, logger *zap.Logger) OrderTracker {
+	return &orderTracker{
+		orderRepo: orderRepo,
+		events:    events,
+		printTime: printTime,
+		logger:    logger,
+	}
+}
+
+// newTrackingToken returns a random token to follow an order without signing in.
+func newTrackingToken() (string, error) {
+	b := make([]byte, trackingTokenSize)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to read random bytes for tracking token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Follow subscribes to the events of the order's print center, as the queue position of
+// the order changes with the other orders of the center. The tracking status is sent
+// again whenever its status, pickup time or queue position change.
+func (t *orderTracker) Follow(code, token string) (*OrderTrackingStream, error) {
+	order, err := t.findOrder(code, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribe first, so that no change is missed between the first status and the events
+	sub := t.events.Subscribe(order.PrintCenterID, 0)
+	current, err := t.tracking(order)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	updates := make(chan dto.OrderTracking, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(updates)
+		defer sub.Close()
+
+		send := func(tracking dto.OrderTracking) bool {
+			select {
+			case updates <- tracking:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		if !send(*current) {
+			return
+		}
+		for !slices.Contains(entity.TerminalStatuses, order.Status) {
+			var event dto.OrderEvent
+			var ok bool
+			select {
+			case <-done:
+				return
+			case event, ok = <-sub.Events:
+				if !ok {
+					return
+				}
+			}
+
+			if event.OrderID == order.ID {
+				if order, err = t.orderRepo.FindByID(order.ID); err != nil {
+					t.logger.Error("Failed to reload tracked order", zap.Uint("orderID", event.OrderID), zap.Error(err))
+					return
+				}
+			} else if !isQueued(order.Status) {
+				continue
+			}
+
+			next, err := t.tracking(order)
+			if err != nil {
+				t.logger.Error("Failed to track order", zap.Uint("orderID", order.ID), zap.Error(err))
+				return
+			}
+			if sameTracking(current, next) {
+				continue
+			}
+			if !send(*next) {
+				return
+			}
+			current = next
+		}
+	}()
+
+	return &OrderTrackingStream{
+		Updates: updates,
+		close:   sync.OnceFunc(func() { close(done) }),
+	}, nil
+}
+
+// findOrder looks up an order by its code and checks its tracking token. Unknown codes
+// and wrong tokens are rejected alike, so that codes can not be probed.
+func (t *orderTracker) findOrder(code, token string) (*entity.Order, error) {
+	order, err := t.orderRepo.FindByCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrInvalidTrackingToken
+		}
+		return nil, fmt.Errorf("getting order by code %s: %w", code, err)
+	}
+	if order == nil || order.TrackingToken == "" ||
+		subtle.ConstantTimeCompare([]byte(order.TrackingToken), []byte(token)) != 1 {
+		return nil, ierrors.ErrInvalidTrackingToken
+	}
+	return order, nil
+}
+
+// tracking describes the current status of the order. Orders waiting to be printed get
+// their queue position, and ready times are estimated from the average print time.
+func (t *orderTracker) tracking(order *entity.Order) (*dto.OrderTracking, error) {
+	tracking := &dto.OrderTracking{
+		Code:       order.Code,
+		Status:     order.Status,
+		PickupTime: order.PickupTime,
+		UpdatedAt:  order.UpdatedAt,
+	}
+
+	now := time.Now()
+	switch order.Status {
+	case entity.StatusReadyToPrint:
+		ahead, err := t.orderRepo.CountQueueAhead(order.PrintCenterID, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		tracking.QueuePosition = int(ahead) + 1
+		readyAt := now.Add(time.Duration(tracking.QueuePosition) * t.printTime)
+		tracking.EstimatedReadyAt = &readyAt
+	case entity.StatusPrinting:
+		// Printing started with the last status change
+		readyAt := order.UpdatedAt.Add(t.printTime)
+		if readyAt.Before(now) {
+			readyAt = now
+		}
+		tracking.EstimatedReadyAt = &readyAt
+	}
+	return tracking, nil
+}
+
+// isQueued reports whether the tracking status of an order depends on the other orders of its center.
+func isQueued(status entity.OrderStatus) bool {
+	return status == entity.StatusReadyToPrint
+}
+
+// sameTracking reports whether two tracking statuses differ only by their estimates.
+func sameTracking(a, b *dto.OrderTracking) bool {
+	return a.Status == b.Status &&
+		a.QueuePosition == b.QueuePosition &&
+		a.UpdatedAt.Equal(b.UpdatedAt) &&
+		timesEqual(a.PickupTime, b.PickupTime)
+}
+
+// timesEqual compares optional times.
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrderTrackerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	orderRepo *mocks.MockOrderRepository
+	events    service.OrderEventBus
+	tracker   service.OrderTracker
+}
+
+func (s *OrderTrackerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.events = service.NewOrderEventBus(service.DefaultOrderEventHistory)
+	s.tracker = service.NewOrderTracker(s.orderRepo, s.events, 5*time.Minute, zap.NewNop())
+}
+
+func (s *OrderTrackerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrderTracker(t *testing.T) {
+	suite.Run(t, new(OrderTrackerTestSuite))
+}
+
+// trackedOrder returns an order of center 4 waiting to be printed.
+func trackedOrder() *entity.Order {
+	return &entity.Order{
+		ID:            1,
+		Code:          "ABC123",
+		TrackingToken: "secret-token",
+		UserUID:       "test-user-123",
+		PrintCenterID: 4,
+		Status:        entity.StatusReadyToPrint,
+	}
+}
+
+// next waits for the next tracking update of the stream.
+func (s *OrderTrackerTestSuite) next(stream *service.OrderTrackingStream) (dto.OrderTracking, bool) {
+	select {
+	case tracking, ok := <-stream.Updates:
+		return tracking, ok
+	case <-time.After(time.Second):
+		s.FailNow("no tracking update")
+		return dto.OrderTracking{}, false
+	}
+}
+
+// ============================================================================
+// Follow Tests
+// ============================================================================
+
+func (s *OrderTrackerTestSuite) TestFollow_SendsQueuePosition() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(trackedOrder(), nil)
+	s.orderRepo.EXPECT().CountQueueAhead(uint(4), uint(1)).Return(int64(2), nil)
+
+	// Act
+	stream, err := s.tracker.Follow("ABC123", "secret-token")
+	s.Require().NoError(err)
+	defer stream.Close()
+	tracking, ok := s.next(stream)
+
+	// Assert
+	s.True(ok)
+	s.Equal("ABC123", tracking.Code)
+	s.Equal(entity.StatusReadyToPrint, tracking.Status)
+	s.Equal(3, tracking.QueuePosition)
+	s.Require().NotNil(tracking.EstimatedReadyAt)
+	s.WithinDuration(time.Now().Add(15*time.Minute), *tracking.EstimatedReadyAt, time.Minute)
+}
+
+func (s *OrderTrackerTestSuite) TestFollow_PushesChangesUntilOrderIsOver() {
+	// Arrange
+	order := trackedOrder()
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+	gomock.InOrder(
+		s.orderRepo.EXPECT().CountQueueAhead(uint(4), uint(1)).Return(int64(1), nil),
+		// Another order of the center changes without moving the queue
+		s.orderRepo.EXPECT().CountQueueAhead(uint(4), uint(1)).Return(int64(1), nil),
+		// The order ahead is printed
+		s.orderRepo.EXPECT().CountQueueAhead(uint(4), uint(1)).Return(int64(0), nil),
+	)
+	completed := *order
+	completed.Status = entity.StatusCompleted
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(&completed, nil)
+
+	stream, err := s.tracker.Follow("ABC123", "secret-token")
+	s.Require().NoError(err)
+	defer stream.Close()
+	first, _ := s.next(stream)
+
+	// Act
+	s.events.Publish(dto.OrderEvent{OrderID: 2, PrintCenterID: 4, Status: entity.StatusPaid})
+	s.events.Publish(dto.OrderEvent{OrderID: 3, PrintCenterID: 4, Status: entity.StatusPrinted})
+	moved, _ := s.next(stream)
+	s.events.Publish(dto.OrderEvent{OrderID: 1, PrintCenterID: 4, Status: entity.StatusCompleted})
+	over, _ := s.next(stream)
+	_, open := s.next(stream)
+
+	// Assert
+	s.Equal(2, first.QueuePosition)
+	s.Equal(1, moved.QueuePosition)
+	s.Equal(entity.StatusCompleted, over.Status)
+	s.Zero(over.QueuePosition)
+	s.Nil(over.EstimatedReadyAt)
+	s.False(open)
+}
+
+func (s *OrderTrackerTestSuite) TestFollow_IgnoresOtherCentersAndUnqueuedOrders() {
+	// Arrange: a paid order does not depend on the queue
+	order := trackedOrder()
+	order.Status = entity.StatusPaid
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+
+	stream, err := s.tracker.Follow("ABC123", "secret-token")
+	s.Require().NoError(err)
+	first, _ := s.next(stream)
+
+	// Act
+	s.events.Publish(dto.OrderEvent{OrderID: 2, PrintCenterID: 4, Status: entity.StatusPrinted})
+	s.events.Publish(dto.OrderEvent{OrderID: 1, PrintCenterID: 5, Status: entity.StatusPrinted})
+	stream.Close()
+	_, open := s.next(stream)
+
+	// Assert
+	s.Equal(entity.StatusPaid, first.Status)
+	s.Nil(first.EstimatedReadyAt)
+	s.False(open)
+}
+
+func (s *OrderTrackerTestSuite) TestFollow_WrongToken() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(trackedOrder(), nil)
+
+	// Act
+	_, err := s.tracker.Follow("ABC123", "guessed-token")
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidTrackingToken, err)
+}
+
+func (s *OrderTrackerTestSuite) TestFollow_UnknownCode() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByCode("UNKNOWN").Return(nil, nil)
+
+	// Act
+	_, err := s.tracker.Follow("UNKNOWN", "secret-token")
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidTrackingToken, err)
+}
+
+func (s *OrderTrackerTestSuite) TestFollow_OrderWithoutToken() {
+	// Arrange: orders placed before tracking tokens were issued
+	order := trackedOrder()
+	order.TrackingToken = ""
+	s.orderRepo.EXPECT().FindByCode("ABC123").Return(order, nil)
+
+	// Act
+	_, err := s.tracker.Follow("ABC123", "")
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidTrackingToken, err)
+}
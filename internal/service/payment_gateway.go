@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -destination=../mocks/mock_payment_gateway.go -package=mocks github.com/kimbasn/printly/internal/service PaymentGateway
+
+// PaymentGateway defines the interface for external payment providers
+type PaymentGateway interface {
+	// Name returns the provider identifier stored on each payment
+	Name() string
+	// CreateCheckoutSession opens a payment session the customer is redirected to
+	CreateCheckoutSession(req CheckoutRequest) (*CheckoutSession, error)
+	// VerifyWebhook authenticates a webhook payload and decodes the event it carries
+	VerifyWebhook(payload []byte, signature string) (*PaymentEvent, error)
+	// GetPaymentStatus queries the provider for the current state of a session
+	GetPaymentStatus(sessionID string) (entity.PaymentStatus, error)
+	// Refund returns the amount of a settled session to the customer
+	Refund(req RefundRequest) (*RefundResult, error)
+}
+
+// PaymentSimulator is implemented by gateways that can emit webhook events on demand.
+// It lets developers run the pay-then-print flow locally without a real provider.
+type PaymentSimulator interface {
+	SimulatePayment(sessionID string, status entity.PaymentStatus) (payload []byte, signature string, err error)
+}
+
+// CheckoutRequest holds what a provider needs to open a payment session
+type CheckoutRequest struct {
+	OrderID     uint
+	OrderCode   string
+	Amount      int64 // in cents
+	Currency    string
+	CustomerUID string
+}
+
+// RefundRequest holds what a provider needs to refund a settled session
+type RefundRequest struct {
+	SessionID string
+	Amount    int64 // in cents
+	Currency  string
+	Reason    string
+}
+
+// RefundResult is the provider's answer to a refund request.
+// Status is REFUNDED once the money is returned, REFUND_PENDING while the provider processes it.
+type RefundResult struct {
+	RefundID string
+	Status   entity.PaymentStatus
+}
+
+// CheckoutSession is the provider's answer to a checkout request
+type CheckoutSession struct {
+	SessionID   string
+	CheckoutURL string
+	ExpiresAt   time.Time
+}
+
+// PaymentEvent is a verified notification sent by a provider
+type PaymentEvent struct {
+	EventID       string               `json:"event_id"`
+	SessionID     string               `json:"session_id"`
+	Status        entity.PaymentStatus `json:"status"`
+	Amount        int64                `json:"amount"`
+	Currency      string               `json:"currency"`
+	FailureReason string               `json:"failure_reason,omitempty"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+}
+
+// GetPaymentGateway creates and returns a PaymentGateway instance based on the provided config
+func GetPaymentGateway(cfg *config.Config, logger *zap.Logger) (PaymentGateway, error) {
+	switch cfg.Payment.Provider {
+	case config.PaymentProviderNone:
+		return disabledPaymentGateway{}, nil
+	case config.PaymentProviderMock:
+		return NewMockPaymentGateway(cfg.Payment, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider: %s", cfg.Payment.Provider)
+	}
+}
+
+// disabledPaymentGateway stands in for a provider while payments are disabled.
+// Every call fails with ErrPaymentsDisabled, which the payment routes answer with 503.
+type disabledPaymentGateway struct{}
+
+func (disabledPaymentGateway) Name() string {
+	return string(config.PaymentProviderNone)
+}
+
+func (disabledPaymentGateway) CreateCheckoutSession(req CheckoutRequest) (*CheckoutSession, error) {
+	return nil, ierrors.ErrPaymentsDisabled
+}
+
+func (disabledPaymentGateway) VerifyWebhook(payload []byte, signature string) (*PaymentEvent, error) {
+	return nil, ierrors.ErrPaymentsDisabled
+}
+
+func (disabledPaymentGateway) GetPaymentStatus(sessionID string) (entity.PaymentStatus, error) {
+	return "", ierrors.ErrPaymentsDisabled
+}
+
+func (disabledPaymentGateway) Refund(req RefundRequest) (*RefundResult, error) {
+	return nil, ierrors.ErrPaymentsDisabled
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	mockSessionTTL      = 30 * time.Minute
+	mockSignaturePrefix = "sha256="
+)
+
+type mockSession struct {
+	request CheckoutRequest
+	status  entity.PaymentStatus
+}
+
+// mockPaymentGateway is an in-memory provider that never moves real money.
+// Webhook payloads are signed with HMAC-SHA256 using the configured secret,
+// exactly like a real provider would, so the verification path is exercised end to end.
+type mockPaymentGateway struct {
+	secret          []byte
+	checkoutBaseURL string
+	logger          *zap.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*mockSession
+}
+
+// NewMockPaymentGateway creates a new mock payment gateway
+func NewMockPaymentGateway(paymentConfig config.PaymentConfig, logger *zap.Logger) PaymentGateway {
+	return &mockPaymentGateway{
+		secret:          []byte(paymentConfig.WebhookSecret),
+		checkoutBaseURL: strings.TrimRight(paymentConfig.CheckoutBaseURL, "/"),
+		logger:          logger,
+		sessions:        make(map[string]*mockSession),
+	}
+}
+
+func (g *mockPaymentGateway) Name() string {
+	return string(config.PaymentProviderMock)
+}
+
+// CreateCheckoutSession registers a new pending session
+func (g *mockPaymentGateway) CreateCheckoutSession(req CheckoutRequest) (*CheckoutSession, error) {
+	sessionID, err := randomID("mock_sess_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session id: %w", err)
+	}
+
+	g.mu.Lock()
+	g.sessions[sessionID] = &mockSession{request: req, status: entity.PaymentPending}
+	g.mu.Unlock()
+
+	g.logger.Info("Mock checkout session created",
+		zap.String("sessionID", sessionID),
+		zap.Uint("orderID", req.OrderID),
+		zap.Int64("amount", req.Amount))
+
+	return &CheckoutSession{
+		SessionID:   sessionID,
+		CheckoutURL: fmt.Sprintf("%s/%s", g.checkoutBaseURL, sessionID),
+		ExpiresAt:   time.Now().Add(mockSessionTTL),
+	}, nil
+}
+
+// VerifyWebhook checks the HMAC signature of the payload and decodes the event
+func (g *mockPaymentGateway) VerifyWebhook(payload []byte, signature string) (*PaymentEvent, error) {
+	if !hmac.Equal([]byte(signature), []byte(g.sign(payload))) {
+		return nil, ierrors.ErrInvalidWebhookSignature
+	}
+
+	var event PaymentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, ierrors.NewWithCause(ierrors.InvalidArgument, "invalid webhook payload", err)
+	}
+	if event.EventID == "" || event.SessionID == "" {
+		return nil, ierrors.New(ierrors.InvalidArgument, "webhook payload is missing event or session id")
+	}
+	return &event, nil
+}
+
+// GetPaymentStatus returns the status of a known session
+func (g *mockPaymentGateway) GetPaymentStatus(sessionID string) (entity.PaymentStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	session, ok := g.sessions[sessionID]
+	if !ok {
+		return "", ierrors.ErrPaymentNotFound
+	}
+	return session.status, nil
+}
+
+// Refund returns the money of a session immediately. Unknown sessions, e.g. created before
+// a restart, are refunded as well since no money ever moved.
+func (g *mockPaymentGateway) Refund(req RefundRequest) (*RefundResult, error) {
+	refundID, err := randomID("mock_re_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refund id: %w", err)
+	}
+
+	g.mu.Lock()
+	if session, ok := g.sessions[req.SessionID]; ok {
+		session.status = entity.PaymentRefunded
+	}
+	g.mu.Unlock()
+
+	g.logger.Info("Mock refund issued",
+		zap.String("sessionID", req.SessionID),
+		zap.String("refundID", refundID),
+		zap.Int64("amount", req.Amount))
+
+	return &RefundResult{RefundID: refundID, Status: entity.PaymentRefunded}, nil
+}
+
+// SimulatePayment settles a session and returns the signed webhook the provider would send
+func (g *mockPaymentGateway) SimulatePayment(sessionID string, status entity.PaymentStatus) ([]byte, string, error) {
+	if status != entity.PaymentSucceeded && status != entity.PaymentFailed {
+		return nil, "", ierrors.New(ierrors.InvalidArgument, "simulated status must be SUCCEEDED or FAILED")
+	}
+
+	g.mu.Lock()
+	session, ok := g.sessions[sessionID]
+	if ok {
+		session.status = status
+	}
+	g.mu.Unlock()
+	if !ok {
+		return nil, "", ierrors.ErrPaymentNotFound
+	}
+
+	eventID, err := randomID("mock_evt_")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate event id: %w", err)
+	}
+
+	event := PaymentEvent{
+		EventID:    eventID,
+		SessionID:  sessionID,
+		Status:     status,
+		Amount:     session.request.Amount,
+		Currency:   session.request.Currency,
+		OccurredAt: time.Now().UTC(),
+	}
+	if status == entity.PaymentFailed {
+		event.FailureReason = "declined by mock provider"
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return payload, g.sign(payload), nil
+}
+
+// sign computes the signature header value for a payload
+func (g *mockPaymentGateway) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return mockSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomID returns a prefixed random hexadecimal identifier
+func randomID(prefix string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_payment_service.go -package=mocks github.com/kimbasn/printly/internal/service PaymentService
+
+// PaymentService defines the interface for payment-related business logic.
+type PaymentService interface {
+	InitiatePayment(orderID uint, userUID string) (*entity.Payment, error)
+	HandleWebhook(payload []byte, signature string) error
+	RefundOrder(order *entity.Order, reason string) (*entity.Payment, error)
+}
+
+type paymentService struct {
+	paymentRepo  repository.PaymentRepository
+	orderRepo    repository.OrderRepository
+	stateMachine OrderStateMachine
+	gateway      PaymentGateway
+	logger       *zap.Logger
+}
+
+// NewPaymentService creates a new instance of PaymentService.
+func NewPaymentService(paymentRepo repository.PaymentRepository, orderRepo repository.OrderRepository, stateMachine OrderStateMachine, gateway PaymentGateway, logger *zap.Logger) PaymentService {
+	return &paymentService{
+		paymentRepo:  paymentRepo,
+		orderRepo:    orderRepo,
+		stateMachine: stateMachine,
+		gateway:      gateway,
+		logger:       logger,
+	}
+}
+
+// InitiatePayment opens a checkout session for an order awaiting payment.
+// A pending session that already exists for the order is returned as is.
+func (s *paymentService) InitiatePayment(orderID uint, userUID string) (*entity.Payment, error) {
+	s.logger.Info("Initiating payment", zap.Uint("orderID", orderID), zap.String("userUID", userUID))
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("getting order by id %d: %w", orderID, err)
+	}
+	if order.UserUID != userUID {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+	if order.Status != entity.StatusPendingPayment {
+		return nil, ierrors.ErrOrderNotPayable
+	}
+
+	existing, err := s.paymentRepo.FindPendingByOrderID(orderID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up pending payment: %w", err)
+	}
+
+	session, err := s.gateway.CreateCheckoutSession(CheckoutRequest{
+		OrderID:     order.ID,
+		OrderCode:   order.Code,
+		Amount:      order.TotalCost,
+		Currency:    order.Currency,
+		CustomerUID: userUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	payment := &entity.Payment{
+		OrderID:     order.ID,
+		Provider:    s.gateway.Name(),
+		SessionID:   session.SessionID,
+		CheckoutURL: session.CheckoutURL,
+		Amount:      order.TotalCost,
+		Currency:    order.Currency,
+		Status:      entity.PaymentPending,
+	}
+	if err := s.paymentRepo.Save(payment); err != nil {
+		return nil, fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	s.logger.Info("Payment initiated", zap.Uint("orderID", orderID), zap.String("sessionID", payment.SessionID))
+	return payment, nil
+}
+
+// HandleWebhook verifies a provider notification and applies it to the matching payment.
+// Every applied event is recorded and replays are ignored, so providers can safely retry deliveries.
+// Events that would move the payment back, like a delayed success after a refund, are ignored too.
+func (s *paymentService) HandleWebhook(payload []byte, signature string) error {
+	event, err := s.gateway.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Payment webhook received",
+		zap.String("eventID", event.EventID),
+		zap.String("sessionID", event.SessionID),
+		zap.String("status", string(event.Status)))
+
+	payment, err := s.paymentRepo.FindBySessionID(event.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ierrors.ErrPaymentNotFound
+		}
+		return fmt.Errorf("getting payment by session id %s: %w", event.SessionID, err)
+	}
+
+	applied, err := s.paymentRepo.HasEvent(event.EventID)
+	if err != nil {
+		return err
+	}
+	if applied {
+		s.logger.Info("Ignoring replayed payment event", zap.String("eventID", event.EventID))
+		return nil
+	}
+
+	switch event.Status {
+	case entity.PaymentPending:
+		// Nothing to do until the provider settles the session.
+		return nil
+	case entity.PaymentSucceeded, entity.PaymentFailed, entity.PaymentRefunded:
+	default:
+		return ierrors.New(ierrors.InvalidArgument, fmt.Sprintf("unknown payment status %q", event.Status))
+	}
+
+	if !payment.CanTransitionTo(event.Status) {
+		s.logger.Warn("Ignoring payment event that does not apply to the payment status",
+			zap.String("eventID", event.EventID),
+			zap.Uint("paymentID", payment.ID),
+			zap.String("from", string(payment.Status)),
+			zap.String("to", string(event.Status)))
+		return nil
+	}
+
+	updates := map[string]any{
+		"status":        event.Status,
+		"last_event_id": event.EventID,
+	}
+	var order *entity.Order
+	switch event.Status {
+	case entity.PaymentSucceeded:
+		if event.Amount != payment.Amount || !strings.EqualFold(event.Currency, payment.Currency) {
+			s.logger.Error("Payment amount mismatch",
+				zap.Uint("paymentID", payment.ID),
+				zap.Int64("expected", payment.Amount),
+				zap.Int64("received", event.Amount))
+			return ierrors.ErrPaymentAmountMismatch
+		}
+
+		paidAt := event.OccurredAt
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		order, err = s.markOrderPaid(payment.OrderID, paidAt)
+		if err != nil {
+			return err
+		}
+		updates["paid_at"] = paidAt
+
+	case entity.PaymentFailed:
+		updates["failure_reason"] = event.FailureReason
+
+	case entity.PaymentRefunded:
+		refundedAt := event.OccurredAt
+		if refundedAt.IsZero() {
+			refundedAt = time.Now()
+		}
+		updates["refunded_at"] = refundedAt
+	}
+
+	record := &entity.PaymentWebhookEvent{EventID: event.EventID, PaymentID: payment.ID, Status: event.Status}
+	if err := s.paymentRepo.UpdateWithEvent(payment.ID, updates, record); err != nil {
+		if errors.Is(err, repository.ErrEventAlreadyApplied) {
+			// A concurrent delivery of the same event was applied first
+			s.logger.Info("Ignoring replayed payment event", zap.String("eventID", event.EventID))
+			return nil
+		}
+		return fmt.Errorf("failed to record payment event: %w", err)
+	}
+
+	s.logger.Info("Payment event applied",
+		zap.Uint("orderID", payment.OrderID),
+		zap.Uint("paymentID", payment.ID),
+		zap.String("status", string(event.Status)))
+
+	// A checkout settled after its order was cancelled or failed, by the expiry job for instance, is refunded
+	if order != nil && slices.Contains(entity.TerminalStatuses, order.Status) {
+		s.refundClosedOrder(order)
+	}
+	return nil
+}
+
+// refundClosedOrder refunds the payment of an order that was closed before its payment settled.
+// A failed refund leaves the payment REFUND_PENDING, to be retried.
+func (s *paymentService) refundClosedOrder(order *entity.Order) {
+	reason := fmt.Sprintf("order was %s before its payment settled", strings.ToLower(string(order.Status)))
+	refund, err := s.RefundOrder(order, reason)
+	if err != nil {
+		s.logger.Error("Failed to refund payment of a closed order",
+			zap.Uint("orderID", order.ID),
+			zap.String("status", string(order.Status)),
+			zap.Error(err))
+		return
+	}
+	if refund != nil {
+		s.logger.Warn("Payment settled for a closed order, refunded",
+			zap.Uint("orderID", order.ID),
+			zap.Uint("paymentID", refund.ID),
+			zap.String("refundStatus", string(refund.Status)))
+	}
+}
+
+// RefundOrder refunds the settled payment of an order, and returns nil when the order was not paid.
+// The payment is marked REFUND_PENDING before the provider is called, so that failed refunds can be found and retried.
+func (s *paymentService) RefundOrder(order *entity.Order, reason string) (*entity.Payment, error) {
+	payment, err := s.paymentRepo.FindSucceededByOrderID(order.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up payment of order %d: %w", order.ID, err)
+	}
+
+	if err := s.paymentRepo.Update(payment.ID, map[string]any{"status": entity.PaymentRefundPending, "refund_reason": reason}); err != nil {
+		return nil, fmt.Errorf("failed to record refund request: %w", err)
+	}
+	payment.Status = entity.PaymentRefundPending
+	payment.RefundReason = reason
+
+	result, err := s.gateway.Refund(RefundRequest{
+		SessionID: payment.SessionID,
+		Amount:    payment.Amount,
+		Currency:  payment.Currency,
+		Reason:    reason,
+	})
+	if err != nil {
+		s.logger.Error("Refund failed", zap.Uint("orderID", order.ID), zap.Uint("paymentID", payment.ID), zap.Error(err))
+		return payment, fmt.Errorf("failed to refund payment %d: %w", payment.ID, err)
+	}
+
+	updates := map[string]any{
+		"status":    result.Status,
+		"refund_id": result.RefundID,
+	}
+	payment.Status = result.Status
+	payment.RefundID = result.RefundID
+	if result.Status == entity.PaymentRefunded {
+		now := time.Now()
+		updates["refunded_at"] = now
+		payment.RefundedAt = &now
+	}
+	if err := s.paymentRepo.Update(payment.ID, updates); err != nil {
+		return payment, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	s.logger.Info("Refund issued",
+		zap.Uint("orderID", order.ID),
+		zap.Uint("paymentID", payment.ID),
+		zap.String("status", string(result.Status)))
+	return payment, nil
+}
+
+// markOrderPaid moves the order of a settled payment to PAID and returns it.
+// Orders that already left PENDING_PAYMENT (paid by a retried delivery, cancelled meanwhile) are left untouched.
+func (s *paymentService) markOrderPaid(orderID uint, paidAt time.Time) (*entity.Order, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ierrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("getting order by id %d: %w", orderID, err)
+	}
+
+	if order.Status != entity.StatusPendingPayment {
+		if order.Status != entity.StatusPaid {
+			s.logger.Warn("Payment settled for an order that is no longer awaiting payment",
+				zap.Uint("orderID", orderID),
+				zap.String("status", string(order.Status)))
+		}
+		return order, nil
+	}
+
+	err = s.stateMachine.Transition(order, entity.StatusPaid, entity.SystemActor, "payment succeeded", map[string]any{"paid_at": paidAt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark order %d as paid: %w", orderID, err)
+	}
+	return order, nil
+}
@@ -0,0 +1,528 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/config"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PaymentServiceTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	paymentRepo *mocks.MockPaymentRepository
+	orderRepo   *mocks.MockOrderRepository
+	gateway     *mocks.MockPaymentGateway
+	service     service.PaymentService
+}
+
+func (s *PaymentServiceTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.paymentRepo = mocks.NewMockPaymentRepository(s.ctrl)
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.gateway = mocks.NewMockPaymentGateway(s.ctrl)
+
+	notifier := mocks.NewMockNotifier(s.ctrl)
+	notifier.EXPECT().NotifyOrder(gomock.Any()).Return(nil).AnyTimes()
+	stateMachine := service.NewOrderStateMachine(s.orderRepo, service.NewOrderEventBus(service.DefaultOrderEventHistory), notifier, zap.NewNop())
+	s.service = service.NewPaymentService(s.paymentRepo, s.orderRepo, stateMachine, s.gateway, zap.NewNop())
+}
+
+func (s *PaymentServiceTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestPaymentService(t *testing.T) {
+	suite.Run(t, new(PaymentServiceTestSuite))
+}
+
+// ============================================================================
+// InitiatePayment Tests
+// ============================================================================
+
+func (s *PaymentServiceTestSuite) TestInitiatePayment_Success() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	order := &entity.Order{
+		ID:        orderID,
+		Code:      "ABC123",
+		UserUID:   userUID,
+		Status:    entity.StatusPendingPayment,
+		TotalCost: 450,
+		Currency:  "EUR",
+	}
+
+	s.orderRepo.EXPECT().FindByID(orderID).Return(order, nil)
+	s.paymentRepo.EXPECT().FindPendingByOrderID(orderID).Return(nil, gorm.ErrRecordNotFound)
+	s.gateway.EXPECT().
+		CreateCheckoutSession(service.CheckoutRequest{
+			OrderID:     orderID,
+			OrderCode:   "ABC123",
+			Amount:      450,
+			Currency:    "EUR",
+			CustomerUID: userUID,
+		}).
+		Return(&service.CheckoutSession{SessionID: "sess_1", CheckoutURL: "https://pay/sess_1"}, nil)
+	s.gateway.EXPECT().Name().Return("mock")
+	s.paymentRepo.EXPECT().Save(gomock.Any()).Return(nil)
+
+	// Act
+	payment, err := s.service.InitiatePayment(orderID, userUID)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("sess_1", payment.SessionID)
+	s.Equal("https://pay/sess_1", payment.CheckoutURL)
+	s.Equal(int64(450), payment.Amount)
+	s.Equal(entity.PaymentPending, payment.Status)
+	s.Equal("mock", payment.Provider)
+}
+
+func (s *PaymentServiceTestSuite) TestInitiatePayment_ReusesPendingSession() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	existing := &entity.Payment{ID: 7, OrderID: orderID, SessionID: "sess_1", Status: entity.PaymentPending}
+
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: userUID, Status: entity.StatusPendingPayment}, nil)
+	s.paymentRepo.EXPECT().FindPendingByOrderID(orderID).Return(existing, nil)
+
+	// Act
+	payment, err := s.service.InitiatePayment(orderID, userUID)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(existing, payment)
+}
+
+func (s *PaymentServiceTestSuite) TestInitiatePayment_NotOwner() {
+	// Arrange
+	orderID := uint(1)
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: "someone-else", Status: entity.StatusPendingPayment}, nil)
+
+	// Act
+	_, err := s.service.InitiatePayment(orderID, "test-user-123")
+
+	// Assert
+	s.Equal(ierrors.ErrOrderAccessDenied, err)
+}
+
+func (s *PaymentServiceTestSuite) TestInitiatePayment_OrderNotPayable() {
+	// Arrange
+	orderID := uint(1)
+	userUID := "test-user-123"
+	s.orderRepo.EXPECT().FindByID(orderID).Return(&entity.Order{ID: orderID, UserUID: userUID, Status: entity.StatusPaid}, nil)
+
+	// Act
+	_, err := s.service.InitiatePayment(orderID, userUID)
+
+	// Assert
+	s.Equal(ierrors.ErrOrderNotPayable, err)
+}
+
+func (s *PaymentServiceTestSuite) TestInitiatePayment_OrderNotFound() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(999)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := s.service.InitiatePayment(999, "test-user-123")
+
+	// Assert
+	s.Equal(ierrors.ErrOrderNotFound, err)
+}
+
+// ============================================================================
+// HandleWebhook Tests
+// ============================================================================
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_Succeeded() {
+	// Arrange
+	payload := []byte(`{}`)
+	occurredAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	event := &service.PaymentEvent{
+		EventID:    "evt_1",
+		SessionID:  "sess_1",
+		Status:     entity.PaymentSucceeded,
+		Amount:     450,
+		Currency:   "eur",
+		OccurredAt: occurredAt,
+	}
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentPending}
+
+	s.gateway.EXPECT().VerifyWebhook(payload, "sig").Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(false, nil)
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(&entity.Order{ID: 1, Status: entity.StatusPendingPayment}, nil)
+	s.orderRepo.EXPECT().
+		UpdateStatus(uint(1), entity.StatusPendingPayment, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, from entity.OrderStatus, updates map[string]any, history *entity.OrderStatusHistory) error {
+			s.Equal(entity.StatusPaid, updates["status"])
+			s.Equal(occurredAt, updates["paid_at"])
+			s.Equal(entity.SystemActor.UID, history.ActorUID)
+			return nil
+		})
+	s.paymentRepo.EXPECT().
+		UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, updates map[string]any, record *entity.PaymentWebhookEvent) error {
+			s.Equal(entity.PaymentSucceeded, updates["status"])
+			s.Equal("evt_1", updates["last_event_id"])
+			s.Equal(occurredAt, updates["paid_at"])
+			s.Equal("evt_1", record.EventID)
+			s.Equal(uint(7), record.PaymentID)
+			return nil
+		})
+
+	// Act
+	err := s.service.HandleWebhook(payload, "sig")
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_InvalidSignature() {
+	// Arrange
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), "bad").Return(nil, ierrors.ErrInvalidWebhookSignature)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "bad")
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidWebhookSignature)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_ReplayedEventIgnored() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded}
+	payment := &entity.Payment{ID: 7, SessionID: "sess_1", Status: entity.PaymentSucceeded}
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(true, nil)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_AmountMismatch() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 1, Currency: "EUR"}
+	payment := &entity.Payment{ID: 7, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentPending}
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(false, nil)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.Equal(ierrors.ErrPaymentAmountMismatch, err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_Failed() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_2", SessionID: "sess_1", Status: entity.PaymentFailed, FailureReason: "declined"}
+	payment := &entity.Payment{ID: 7, SessionID: "sess_1", Status: entity.PaymentPending}
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_2").Return(false, nil)
+	s.paymentRepo.EXPECT().
+		UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, updates map[string]any, record *entity.PaymentWebhookEvent) error {
+			s.Equal(entity.PaymentFailed, updates["status"])
+			s.Equal("declined", updates["failure_reason"])
+			s.Equal("evt_2", updates["last_event_id"])
+			return nil
+		})
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_PaymentNotFound() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "unknown", Status: entity.PaymentSucceeded}
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("unknown").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.Equal(ierrors.ErrPaymentNotFound, err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_RecordError() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 450, Currency: "EUR"}
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentPending}
+	dbErr := errors.New("db error")
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(false, nil)
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(&entity.Order{ID: 1, Status: entity.StatusPaid}, nil)
+	s.paymentRepo.EXPECT().UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).Return(dbErr)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.ErrorIs(err, dbErr)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_SuccessAfterRefundIgnored() {
+	// Arrange: the payment succeeded with evt_1, then a refund event arrives
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentSucceeded}
+	refund := &service.PaymentEvent{EventID: "evt_2", SessionID: "sess_1", Status: entity.PaymentRefunded}
+	s.gateway.EXPECT().VerifyWebhook([]byte(`refund`), "sig").Return(refund, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_2").Return(false, nil)
+	s.paymentRepo.EXPECT().
+		UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id uint, updates map[string]any, record *entity.PaymentWebhookEvent) error {
+			s.Equal(entity.PaymentRefunded, updates["status"])
+			payment.Status = entity.PaymentRefunded
+			return nil
+		})
+	s.Require().NoError(s.service.HandleWebhook([]byte(`refund`), "sig"))
+
+	// A replay of the success event, and a delayed success event with another ID
+	replayed := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 450, Currency: "EUR"}
+	delayed := &service.PaymentEvent{EventID: "evt_3", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 450, Currency: "EUR"}
+	s.gateway.EXPECT().VerifyWebhook([]byte(`replayed`), "sig").Return(replayed, nil)
+	s.gateway.EXPECT().VerifyWebhook([]byte(`delayed`), "sig").Return(delayed, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil).Times(2)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(true, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_3").Return(false, nil)
+	// No order nor payment update expected, the refunded payment stays refunded
+
+	// Act
+	replayedErr := s.service.HandleWebhook([]byte(`replayed`), "sig")
+	delayedErr := s.service.HandleWebhook([]byte(`delayed`), "sig")
+
+	// Assert
+	s.NoError(replayedErr)
+	s.NoError(delayedErr)
+	s.Equal(entity.PaymentRefunded, payment.Status)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_SucceededForCancelledOrderRefunded() {
+	// Arrange: the expiry job cancelled the order while its checkout was in progress
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 450, Currency: "EUR"}
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentPending}
+	order := &entity.Order{ID: 1, Status: entity.StatusCancelled}
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(false, nil)
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(order, nil)
+	// No order transition expected, the order stays cancelled
+	gomock.InOrder(
+		s.paymentRepo.EXPECT().UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).Return(nil),
+		s.paymentRepo.EXPECT().
+			FindSucceededByOrderID(uint(1)).
+			Return(&entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentSucceeded}, nil),
+		s.paymentRepo.EXPECT().
+			Update(uint(7), gomock.Any()).
+			DoAndReturn(func(id uint, updates map[string]any) error {
+				s.Equal(entity.PaymentRefundPending, updates["status"])
+				s.Contains(updates["refund_reason"], "cancelled")
+				return nil
+			}),
+		s.gateway.EXPECT().
+			Refund(gomock.Any()).
+			DoAndReturn(func(req service.RefundRequest) (*service.RefundResult, error) {
+				s.Equal(int64(450), req.Amount)
+				return &service.RefundResult{RefundID: "re_1", Status: entity.PaymentRefunded}, nil
+			}),
+		s.paymentRepo.EXPECT().
+			Update(uint(7), gomock.Any()).
+			DoAndReturn(func(id uint, updates map[string]any) error {
+				s.Equal(entity.PaymentRefunded, updates["status"])
+				return nil
+			}),
+	)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(entity.StatusCancelled, order.Status)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_RefundFailureForClosedOrderLeftPending() {
+	// Arrange
+	event := &service.PaymentEvent{EventID: "evt_1", SessionID: "sess_1", Status: entity.PaymentSucceeded, Amount: 450, Currency: "EUR"}
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentPending}
+
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_1").Return(false, nil)
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(&entity.Order{ID: 1, Status: entity.StatusFailed}, nil)
+	s.paymentRepo.EXPECT().UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).Return(nil)
+	s.paymentRepo.EXPECT().FindSucceededByOrderID(uint(1)).Return(&entity.Payment{ID: 7, OrderID: 1, Status: entity.PaymentSucceeded}, nil)
+	s.paymentRepo.EXPECT().
+		Update(uint(7), gomock.Any()).
+		DoAndReturn(func(id uint, updates map[string]any) error {
+			s.Equal(entity.PaymentRefundPending, updates["status"])
+			return nil
+		})
+	s.gateway.EXPECT().Refund(gomock.Any()).Return(nil, errors.New("provider unavailable"))
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert: the event is applied, the refund is left REFUND_PENDING for retry
+	s.NoError(err)
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook_ConcurrentDeliveryIgnored() {
+	// Arrange: the same event is applied by another delivery between the check and the write
+	event := &service.PaymentEvent{EventID: "evt_2", SessionID: "sess_1", Status: entity.PaymentFailed}
+	payment := &entity.Payment{ID: 7, SessionID: "sess_1", Status: entity.PaymentPending}
+	s.gateway.EXPECT().VerifyWebhook(gomock.Any(), gomock.Any()).Return(event, nil)
+	s.paymentRepo.EXPECT().FindBySessionID("sess_1").Return(payment, nil)
+	s.paymentRepo.EXPECT().HasEvent("evt_2").Return(false, nil)
+	s.paymentRepo.EXPECT().UpdateWithEvent(uint(7), gomock.Any(), gomock.Any()).Return(repository.ErrEventAlreadyApplied)
+
+	// Act
+	err := s.service.HandleWebhook([]byte(`{}`), "sig")
+
+	// Assert
+	s.NoError(err)
+}
+
+// ============================================================================
+// RefundOrder Tests
+// ============================================================================
+
+func (s *PaymentServiceTestSuite) TestRefundOrder_Success() {
+	// Arrange
+	order := &entity.Order{ID: 1}
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Amount: 450, Currency: "EUR", Status: entity.PaymentSucceeded}
+	s.paymentRepo.EXPECT().FindSucceededByOrderID(uint(1)).Return(payment, nil)
+	gomock.InOrder(
+		s.paymentRepo.EXPECT().
+			Update(uint(7), map[string]any{"status": entity.PaymentRefundPending, "refund_reason": "changed my mind"}).
+			Return(nil),
+		s.gateway.EXPECT().
+			Refund(service.RefundRequest{SessionID: "sess_1", Amount: 450, Currency: "EUR", Reason: "changed my mind"}).
+			Return(&service.RefundResult{RefundID: "re_1", Status: entity.PaymentRefunded}, nil),
+		s.paymentRepo.EXPECT().
+			Update(uint(7), gomock.Any()).
+			DoAndReturn(func(id uint, updates map[string]any) error {
+				s.Equal(entity.PaymentRefunded, updates["status"])
+				s.Equal("re_1", updates["refund_id"])
+				s.NotNil(updates["refunded_at"])
+				return nil
+			}),
+	)
+
+	// Act
+	refund, err := s.service.RefundOrder(order, "changed my mind")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(entity.PaymentRefunded, refund.Status)
+	s.NotNil(refund.RefundedAt)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundOrder_NotPaid() {
+	// Arrange
+	s.paymentRepo.EXPECT().FindSucceededByOrderID(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	refund, err := s.service.RefundOrder(&entity.Order{ID: 1}, "")
+
+	// Assert
+	s.NoError(err)
+	s.Nil(refund)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundOrder_GatewayErrorLeavesRefundPending() {
+	// Arrange
+	payment := &entity.Payment{ID: 7, OrderID: 1, SessionID: "sess_1", Status: entity.PaymentSucceeded}
+	s.paymentRepo.EXPECT().FindSucceededByOrderID(uint(1)).Return(payment, nil)
+	s.paymentRepo.EXPECT().Update(uint(7), gomock.Any()).Return(nil)
+	s.gateway.EXPECT().Refund(gomock.Any()).Return(nil, errors.New("provider unavailable"))
+
+	// Act
+	refund, err := s.service.RefundOrder(&entity.Order{ID: 1}, "")
+
+	// Assert
+	s.ErrorContains(err, "provider unavailable")
+	s.Equal(entity.PaymentRefundPending, refund.Status)
+}
+
+// ============================================================================
+// Mock gateway Tests
+// ============================================================================
+
+func (s *PaymentServiceTestSuite) TestMockGateway_SimulatedWebhookVerifies() {
+	// Arrange
+	gateway := service.NewMockPaymentGateway(config.PaymentConfig{WebhookSecret: "secret"}, zap.NewNop())
+	session, err := gateway.CreateCheckoutSession(service.CheckoutRequest{OrderID: 1, Amount: 450, Currency: "EUR"})
+	s.Require().NoError(err)
+	simulator := gateway.(service.PaymentSimulator)
+
+	// Act
+	payload, signature, err := simulator.SimulatePayment(session.SessionID, entity.PaymentSucceeded)
+	s.Require().NoError(err)
+	event, verifyErr := gateway.VerifyWebhook(payload, signature)
+	_, tamperErr := gateway.VerifyWebhook(append(payload, ' '), signature)
+	status, statusErr := gateway.GetPaymentStatus(session.SessionID)
+
+	// Assert
+	s.NoError(verifyErr)
+	s.Equal(session.SessionID, event.SessionID)
+	s.Equal(int64(450), event.Amount)
+	s.Equal(entity.PaymentSucceeded, event.Status)
+	s.ErrorIs(tamperErr, ierrors.ErrInvalidWebhookSignature)
+	s.NoError(statusErr)
+	s.Equal(entity.PaymentSucceeded, status)
+}
+
+// ============================================================================
+// Disabled gateway Tests
+// ============================================================================
+
+func (s *PaymentServiceTestSuite) TestDisabledGateway_PaymentsUnavailable() {
+	// Arrange
+	gateway, err := service.GetPaymentGateway(&config.Config{Payment: config.PaymentConfig{Provider: config.PaymentProviderNone}}, zap.NewNop())
+	s.Require().NoError(err)
+	notifier := mocks.NewMockNotifier(s.ctrl)
+	stateMachine := service.NewOrderStateMachine(s.orderRepo, service.NewOrderEventBus(service.DefaultOrderEventHistory), notifier, zap.NewNop())
+	paymentService := service.NewPaymentService(s.paymentRepo, s.orderRepo, stateMachine, gateway, zap.NewNop())
+	order := &entity.Order{ID: 1, UserUID: "test-user-123", Status: entity.StatusPendingPayment, TotalCost: 450, Currency: "EUR"}
+	s.orderRepo.EXPECT().FindByID(uint(1)).Return(order, nil)
+	s.paymentRepo.EXPECT().FindPendingByOrderID(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	payment, initiateErr := paymentService.InitiatePayment(1, "test-user-123")
+	webhookErr := paymentService.HandleWebhook([]byte(`{}`), "signature")
+
+	// Assert
+	s.Nil(payment)
+	s.ErrorIs(initiateErr, ierrors.ErrPaymentsDisabled)
+	s.ErrorIs(webhookErr, ierrors.ErrPaymentsDisabled)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// PickupThrottle counts failed pickup code verifications per print center
+// and blocks further attempts once too many codes were rejected.
+type PickupThrottle interface {
+	// Allow reports whether the center may try to verify another code
+	Allow(centerID uint) bool
+	// Fail records a rejected code for the center
+	Fail(centerID uint)
+}
+
+// Defaults for the pickup code throttle: 5 wrong codes every 15 minutes per center.
+const (
+	DefaultPickupMaxFailures = 5
+	DefaultPickupWindow      = 15 * time.Minute
+)
+
+type pickupAttempts struct {
+	failures    int
+	windowStart time.Time
+}
+
+type pickupThrottle struct {
+	mu          sync.Mutex
+	maxFailures int
+	window      time.Duration
+	attempts    map[uint]*pickupAttempts
+}
+
+// NewPickupThrottle creates an in-memory PickupThrottle allowing maxFailures wrong codes per center in each window.
+func NewPickupThrottle(maxFailures int, window time.Duration) PickupThrottle {
+	return &pickupThrottle{
+		maxFailures: maxFailures,
+		window:      window,
+		attempts:    make(map[uint]*pickupAttempts),
+	}
+}
+
+func (t *pickupThrottle) Allow(centerID uint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a, ok := t.attempts[centerID]
+	if !ok {
+		return true
+	}
+	if time.Since(a.windowStart) >= t.window {
+		delete(t.attempts, centerID)
+		return true
+	}
+	return a.failures < t.maxFailures
+}
+
+func (t *pickupThrottle) Fail(centerID uint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a, ok := t.attempts[centerID]
+	if !ok || time.Since(a.windowStart) >= t.window {
+		a = &pickupAttempts{windowStart: time.Now()}
+		t.attempts[centerID] = a
+	}
+	a.failures++
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type PickupTokenTestSuite struct {
+	suite.Suite
+	signer service.PickupTokenSigner
+	order  *entity.Order
+}
+
+func (s *PickupTokenTestSuite) SetupTest() {
+	s.signer = service.NewPickupTokenSigner([]byte("secret"), time.Hour)
+	s.order = &entity.Order{ID: 1, Code: "ABC123", PrintCenterID: 4}
+}
+
+func TestPickupToken(t *testing.T) {
+	suite.Run(t, new(PickupTokenTestSuite))
+}
+
+// ============================================================================
+// Sign / Verify Tests
+// ============================================================================
+
+func (s *PickupTokenTestSuite) TestSignAndVerify() {
+	// Act
+	token, expiresAt, err := s.signer.Sign(s.order)
+	s.Require().NoError(err)
+	claims, err := s.signer.Verify(token)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("ABC123", claims.Code)
+	s.Equal(uint(4), claims.CenterID)
+	s.Equal(expiresAt.Unix(), claims.ExpiresAt)
+}
+
+func (s *PickupTokenTestSuite) TestVerify_TamperedPayload() {
+	// Arrange: swap the payload of a token for the payload of another order
+	token, _, err := s.signer.Sign(s.order)
+	s.Require().NoError(err)
+	other, _, err := s.signer.Sign(&entity.Order{Code: "ZZZ999", PrintCenterID: 4})
+	s.Require().NoError(err)
+	tampered := other[:strings.LastIndex(other, ".")] + token[strings.LastIndex(token, "."):]
+
+	// Act
+	_, err = s.signer.Verify(tampered)
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidPickupCode, err)
+}
+
+func (s *PickupTokenTestSuite) TestVerify_OtherSecret() {
+	// Arrange
+	token, _, err := service.NewPickupTokenSigner([]byte("other"), time.Hour).Sign(s.order)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.signer.Verify(token)
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidPickupCode, err)
+}
+
+func (s *PickupTokenTestSuite) TestVerify_Expired() {
+	// Arrange
+	signer := service.NewPickupTokenSigner([]byte("secret"), -time.Minute)
+	token, _, err := signer.Sign(s.order)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = signer.Verify(token)
+
+	// Assert
+	s.Equal(ierrors.ErrPickupTokenExpired, err)
+}
+
+func (s *PickupTokenTestSuite) TestVerify_Malformed() {
+	for _, token := range []string{"", "ABC123", "ply1.", "ply1.abc", "ply1.!!!.sig"} {
+		s.Run(token, func() {
+			// Act
+			_, err := s.signer.Verify(token)
+
+			// Assert
+			s.Equal(ierrors.ErrInvalidPickupCode, err)
+		})
+	}
+}
+
+// ============================================================================
+// RenderQRCode Tests
+// ============================================================================
+
+func (s *PickupTokenTestSuite) TestRenderQRCode() {
+	// Arrange
+	token, _, err := s.signer.Sign(s.order)
+	s.Require().NoError(err)
+
+	// Act
+	png, err := service.RenderQRCode(token, service.QRFormatPNG)
+	s.Require().NoError(err)
+	svg, err := service.RenderQRCode(token, service.QRFormatSVG)
+	s.Require().NoError(err)
+
+	// Assert
+	s.True(bytes.HasPrefix(png, []byte("\x89PNG")))
+	s.True(bytes.HasPrefix(svg, []byte("<svg")))
+	s.Contains(string(svg), "h1v1h-1z")
+}
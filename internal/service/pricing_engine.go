@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+)
+
+//go:generate mockgen -destination=../mocks/mock_pricing_engine.go -package=mocks github.com/kimbasn/printly/internal/service PricingEngine
+
+// PricingEngine prices documents from the service catalog of a print center.
+type PricingEngine interface {
+	Quote(center *entity.PrintCenter, documents []entity.Document) (*dto.Quote, error)
+}
+
+// bytesPerEstimatedPage is used to estimate the page count of files that can not be paginated before printing.
+const bytesPerEstimatedPage = 50000
+
+type pricingEngine struct{}
+
+// NewPricingEngine creates a new instance of PricingEngine.
+func NewPricingEngine() PricingEngine {
+	return &pricingEngine{}
+}
+
+// Quote prices every document with the center service matching its paper size and color mode.
+// Volume tiers apply to the quantity ordered of each service over all the documents.
+func (e *pricingEngine) Quote(center *entity.PrintCenter, documents []entity.Document) (*dto.Quote, error) {
+	quote := &dto.Quote{
+		Currency: center.Currency,
+		Lines:    make([]dto.QuoteLine, len(documents)),
+	}
+	services := make([]*entity.Service, len(documents))
+	quantities := make(map[*entity.Service]int)
+
+	// 1. Match services and count the units to charge
+	for i := range documents {
+		doc := &documents[i]
+		options := doc.PrintOptions
+
+		service, ok := center.FindService(options.PaperSize, options.Color)
+		if !ok {
+			return nil, ierrors.New(ierrors.InvalidArgument,
+				fmt.Sprintf("%s: %s %s for document %s", ierrors.ErrServiceNotOffered.Error(), options.PaperSize, options.Color, doc.FileName))
+		}
+
+		pages, estimated, err := countPages(doc)
+		if err != nil {
+			return nil, ierrors.NewWithCause(ierrors.InvalidArgument,
+				fmt.Sprintf("%s for document %s: %s", ierrors.ErrInvalidPageRange.Error(), doc.FileName, err.Error()), err)
+		}
+
+		copies := max(options.Copies, 1)
+		sheetsPerCopy := pages
+		if options.DoubleSided {
+			sheetsPerCopy = (pages + 1) / 2
+		}
+
+		line := dto.QuoteLine{
+			FileName:    doc.FileName,
+			Service:     service.Name,
+			PaperSize:   options.PaperSize,
+			Color:       options.Color,
+			Pages:       pages,
+			Copies:      copies,
+			DoubleSided: options.DoubleSided,
+			Sheets:      sheetsPerCopy * copies,
+			Sides:       pages * copies,
+			PricingUnit: service.Unit(),
+			Estimated:   estimated,
+		}
+		line.Quantity = line.Sides
+		if line.PricingUnit == entity.PerSheet {
+			line.Quantity = line.Sheets
+		}
+
+		quote.Lines[i] = line
+		services[i] = service
+		quantities[service] += line.Quantity
+	}
+
+	// 2. Apply the unit price reached by the total quantity of each service
+	for i := range quote.Lines {
+		line := &quote.Lines[i]
+		line.UnitPrice = services[i].UnitPrice(quantities[services[i]])
+		line.Amount = line.UnitPrice * int64(line.Quantity)
+		quote.Total += line.Amount
+	}
+
+	return quote, nil
+}
+
+// countPages returns the number of pages selected for printing in a single copy of the document.
+// Formats that can not be paginated before printing are estimated from the file size.
+func countPages(doc *entity.Document) (pages int, estimated bool, err error) {
+	if doc.PageCount == 0 {
+		return max(int((doc.Size+bytesPerEstimatedPage-1)/bytesPerEstimatedPage), 1), true, nil
+	}
+
+	selected, err := doc.PrintOptions.SelectedPages(doc.PageCount)
+	if err != nil {
+		return 0, false, err
+	}
+	return len(selected), false, nil
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type PricingEngineTestSuite struct {
+	suite.Suite
+	engine service.PricingEngine
+}
+
+func (s *PricingEngineTestSuite) SetupTest() {
+	s.engine = service.NewPricingEngine()
+}
+
+func TestPricingEngine(t *testing.T) {
+	suite.Run(t, new(PricingEngineTestSuite))
+}
+
+func document(pageCount int, pages string, color entity.ColorMode, duplex bool, copies int) entity.Document {
+	return entity.Document{
+		FileName:  "doc.pdf",
+		PageCount: pageCount,
+		PrintOptions: entity.PrintOptions{
+			Pages:       pages,
+			Color:       color,
+			PaperSize:   entity.A4,
+			DoubleSided: duplex,
+			Copies:      copies,
+		},
+	}
+}
+
+// ============================================================================
+// Quote Tests
+// ============================================================================
+
+func (s *PricingEngineTestSuite) TestQuote_PerSideAndPerSheet() {
+	// Arrange
+	center := &entity.PrintCenter{
+		Currency: "XOF",
+		Services: []entity.Service{
+			{Name: "A4 B&W", PaperSize: "A4", ColorMode: entity.BlackAndWhite, Price: 10, PricingUnit: entity.PerSide},
+			{Name: "A4 color", PaperSize: "a4", ColorMode: entity.Color, Price: 50, PricingUnit: entity.PerSheet},
+		},
+	}
+	documents := []entity.Document{
+		document(5, "all", entity.BlackAndWhite, true, 2), // 10 sides
+		document(5, "all", entity.Color, true, 2),         // 3 sheets per copy
+	}
+
+	// Act
+	quote, err := s.engine.Quote(center, documents)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("XOF", quote.Currency)
+	s.Require().Len(quote.Lines, 2)
+
+	s.Equal("A4 B&W", quote.Lines[0].Service)
+	s.Equal(10, quote.Lines[0].Quantity)
+	s.Equal(6, quote.Lines[0].Sheets)
+	s.Equal(int64(100), quote.Lines[0].Amount)
+
+	s.Equal(entity.PerSheet, quote.Lines[1].PricingUnit)
+	s.Equal(6, quote.Lines[1].Quantity)
+	s.Equal(int64(300), quote.Lines[1].Amount)
+
+	s.Equal(int64(400), quote.Total)
+}
+
+func (s *PricingEngineTestSuite) TestQuote_VolumeTiersOverAllDocuments() {
+	// Arrange
+	center := &entity.PrintCenter{
+		Services: []entity.Service{
+			{
+				Name: "A4", PaperSize: "A4", Price: 10,
+				Tiers: []entity.PriceTier{
+					{MinQuantity: 100, Price: 5},
+					{MinQuantity: 50, Price: 8},
+				},
+			},
+		},
+	}
+	documents := []entity.Document{
+		document(40, "all", entity.BlackAndWhite, false, 1),
+		document(20, "all", entity.Color, false, 1),
+	}
+
+	// Act
+	quote, err := s.engine.Quote(center, documents)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(8), quote.Lines[0].UnitPrice)
+	s.Equal(int64(8), quote.Lines[1].UnitPrice)
+	s.Equal(int64(60*8), quote.Total)
+}
+
+func (s *PricingEngineTestSuite) TestQuote_PrefersDedicatedService() {
+	// Arrange
+	center := &entity.PrintCenter{
+		Services: []entity.Service{
+			{Name: "A4 any", PaperSize: "A4", Price: 10},
+			{Name: "A4 color", PaperSize: "A4", ColorMode: entity.Color, Price: 40},
+		},
+	}
+
+	// Act
+	quote, err := s.engine.Quote(center, []entity.Document{document(1, "all", entity.Color, false, 1)})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("A4 color", quote.Lines[0].Service)
+	s.Equal(int64(40), quote.Total)
+}
+
+func (s *PricingEngineTestSuite) TestQuote_SelectedPagesOnly() {
+	// Arrange
+	center := &entity.PrintCenter{Services: []entity.Service{{Name: "A4", PaperSize: "A4", Price: 10}}}
+
+	// Act
+	quote, err := s.engine.Quote(center, []entity.Document{document(10, "odd", entity.BlackAndWhite, false, 1)})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(5, quote.Lines[0].Pages)
+	s.Equal(int64(50), quote.Total)
+}
+
+func (s *PricingEngineTestSuite) TestQuote_EstimatedPageCount() {
+	// Arrange
+	center := &entity.PrintCenter{Services: []entity.Service{{Name: "A4", PaperSize: "A4", Price: 10}}}
+	doc := document(0, "all", entity.BlackAndWhite, false, 1)
+	doc.Size = 120000
+
+	// Act
+	quote, err := s.engine.Quote(center, []entity.Document{doc})
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(quote.Lines[0].Estimated)
+	s.Equal(3, quote.Lines[0].Pages)
+}
+
+func (s *PricingEngineTestSuite) TestQuote_ServiceNotOffered() {
+	// Arrange
+	center := &entity.PrintCenter{
+		Services: []entity.Service{{Name: "A4 B&W", PaperSize: "A4", ColorMode: entity.BlackAndWhite, Price: 10}},
+	}
+
+	// Act
+	_, err := s.engine.Quote(center, []entity.Document{document(1, "all", entity.Color, false, 1)})
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrServiceNotOffered)
+}
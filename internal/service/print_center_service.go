@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 	"github.com/kimbasn/printly/internal/repository"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -15,21 +17,27 @@ import (
 type PrintCenterService interface {
 	Register(center *entity.PrintCenter) (*entity.PrintCenter, error)
 	GetByID(id uint) (*entity.PrintCenter, error)
-	GetApproved() ([]entity.PrintCenter, error)
-	GetPending() ([]entity.PrintCenter, error)
-	GetAll() ([]entity.PrintCenter, error)
+	GetApproved(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error)
+	GetPending(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error)
+	GetAll(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error)
 	Update(id uint, updates map[string]interface{}) error
 	UpdateStatus(id uint, status entity.PrintCenterStatus) error
 	Delete(id uint) error
 }
 
 type printCenterService struct {
-	repo repository.PrintCenterRepository
+	repo     repository.PrintCenterRepository
+	notifier Notifier
+	logger   *zap.Logger
 }
 
 // NewPrintCenterService creates a new instance of PrintCenterService.
-func NewPrintCenterService(repo repository.PrintCenterRepository) PrintCenterService {
-	return &printCenterService{repo: repo}
+func NewPrintCenterService(repo repository.PrintCenterRepository, notifier Notifier, logger *zap.Logger) PrintCenterService {
+	return &printCenterService{
+		repo:     repo,
+		notifier: notifier,
+		logger:   logger,
+	}
 }
 
 // Register creates a new print center. It's initially set to 'pending' status.
@@ -62,31 +70,38 @@ func (s *printCenterService) GetByID(id uint) (*entity.PrintCenter, error) {
 	return center, nil
 }
 
-// GetAllPublic retrieves all approved print centers.
-func (s *printCenterService) GetApproved() ([]entity.PrintCenter, error) {
-	centers, err := s.repo.FindByStatus(entity.StatusApproved)
+// GetApproved retrieves one page of the approved print centers.
+func (s *printCenterService) GetApproved(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error) {
+	filter := repository.ListFilter{Statuses: []string{string(entity.StatusApproved)}}
+	centers, info, err := s.repo.List(listQuery(req, filter))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch approved print centers: %w", err)
+		return nil, dto.PageInfo{}, listError(err, "failed to fetch approved print centers")
 	}
-	return centers, nil
+	return centers, pageInfo(info), nil
 }
 
-// GetPending retrieves all print centers awaiting approval.
-func (s *printCenterService) GetPending() ([]entity.PrintCenter, error) {
-	centers, err := s.repo.FindByStatus(entity.StatusPending)
+// GetPending retrieves one page of the print centers awaiting approval.
+func (s *printCenterService) GetPending(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error) {
+	filter := repository.ListFilter{Statuses: []string{string(entity.StatusPending)}}
+	centers, info, err := s.repo.List(listQuery(req, filter))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pending print centers: %w", err)
+		return nil, dto.PageInfo{}, listError(err, "failed to fetch pending print centers")
 	}
-	return centers, nil
+	return centers, pageInfo(info), nil
 }
 
-// GetAll retrieves all print centers, regardless of status (for admin use).
-func (s *printCenterService) GetAll() ([]entity.PrintCenter, error) {
-	centers, err := s.repo.FindAll()
+// GetAll retrieves one page of the print centers, regardless of status unless filtered (for admin use).
+func (s *printCenterService) GetAll(req dto.ListRequest) ([]entity.PrintCenter, dto.PageInfo, error) {
+	statuses, err := listStatuses(req.Status, entity.PrintCenterStatuses)
+	if err != nil {
+		return nil, dto.PageInfo{}, err
+	}
+
+	centers, info, err := s.repo.List(listQuery(req, repository.ListFilter{Statuses: statuses}))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch all print centers: %w", err)
+		return nil, dto.PageInfo{}, listError(err, "failed to fetch all print centers")
 	}
-	return centers, nil
+	return centers, pageInfo(info), nil
 }
 
 // Update performs a partial update on a print center's properties.
@@ -111,8 +126,10 @@ func (s *printCenterService) Update(id uint, updates map[string]any) error {
 }
 
 // UpdateStatus updates the status of a print center (e.g., approve, suspend).
+// The center is notified when it gets approved.
 func (s *printCenterService) UpdateStatus(id uint, status entity.PrintCenterStatus) error {
-	if _, err := s.GetByID(id); err != nil {
+	center, err := s.GetByID(id)
+	if err != nil {
 		return err
 	}
 
@@ -121,7 +138,17 @@ func (s *printCenterService) UpdateStatus(id uint, status entity.PrintCenterStat
 		"updated_at": time.Now(),
 	}
 
-	return s.repo.Update(id, updates)
+	if err := s.repo.Update(id, updates); err != nil {
+		return err
+	}
+
+	if status == entity.StatusApproved && center.Status != entity.StatusApproved {
+		center.Status = status
+		if err := s.notifier.NotifyCenter(entity.NotifyCenterApproved, center); err != nil {
+			s.logger.Error("Failed to notify print center approval", zap.Uint("centerID", id), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 // Delete removes a print center from the database.
@@ -8,11 +8,14 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
 	"github.com/kimbasn/printly/internal/service"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -20,13 +23,15 @@ type PrintCenterServiceTestSuite struct {
 	suite.Suite
 	ctrl     *gomock.Controller
 	mockRepo *mocks.MockPrintCenterRepository
+	notifier *mocks.MockNotifier
 	service  service.PrintCenterService
 }
 
 func (s *PrintCenterServiceTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockRepo = mocks.NewMockPrintCenterRepository(s.ctrl)
-	s.service = service.NewPrintCenterService(s.mockRepo)
+	s.notifier = mocks.NewMockNotifier(s.ctrl)
+	s.service = service.NewPrintCenterService(s.mockRepo, s.notifier, zap.NewNop())
 }
 
 func (s *PrintCenterServiceTestSuite) TearDownTest() {
@@ -138,23 +143,24 @@ func (s *PrintCenterServiceTestSuite) TestGetApproved_Success() {
 		{ID: 1, Name: "Center 1", Status: entity.StatusApproved},
 		{ID: 2, Name: "Center 2", Status: entity.StatusApproved},
 	}
-	s.mockRepo.EXPECT().FindByStatus(entity.StatusApproved).Return(expectedCenters, nil)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"approved"}}}).Return(expectedCenters, repository.PageInfo{Total: 2}, nil)
 
 	// Act
-	result, err := s.service.GetApproved()
+	result, info, err := s.service.GetApproved(dto.ListRequest{})
 
 	// Assert
 	s.NoError(err)
 	s.Equal(expectedCenters, result)
 	s.Len(result, 2)
+	s.Equal(int64(2), info.Total)
 }
 
 func (s *PrintCenterServiceTestSuite) TestGetApproved_EmptyResult() {
 	// Arrange
-	s.mockRepo.EXPECT().FindByStatus(entity.StatusApproved).Return([]entity.PrintCenter{}, nil)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"approved"}}}).Return([]entity.PrintCenter{}, repository.PageInfo{}, nil)
 
 	// Act
-	result, err := s.service.GetApproved()
+	result, _, err := s.service.GetApproved(dto.ListRequest{})
 
 	// Assert
 	s.NoError(err)
@@ -164,10 +170,10 @@ func (s *PrintCenterServiceTestSuite) TestGetApproved_EmptyResult() {
 func (s *PrintCenterServiceTestSuite) TestGetApproved_DatabaseError() {
 	// Arrange
 	dbErr := errors.New("database error")
-	s.mockRepo.EXPECT().FindByStatus(entity.StatusApproved).Return(nil, dbErr)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"approved"}}}).Return(nil, repository.PageInfo{}, dbErr)
 
 	// Act
-	_, err := s.service.GetApproved()
+	_, _, err := s.service.GetApproved(dto.ListRequest{})
 
 	// Assert
 	s.Error(err)
@@ -184,10 +190,10 @@ func (s *PrintCenterServiceTestSuite) TestGetPending_Success() {
 		{ID: 1, Name: "Pending Center 1", Status: entity.StatusPending},
 		{ID: 2, Name: "Pending Center 2", Status: entity.StatusPending},
 	}
-	s.mockRepo.EXPECT().FindByStatus(entity.StatusPending).Return(expectedCenters, nil)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"pending"}}}).Return(expectedCenters, repository.PageInfo{Total: 2}, nil)
 
 	// Act
-	result, err := s.service.GetPending()
+	result, _, err := s.service.GetPending(dto.ListRequest{})
 
 	// Assert
 	s.NoError(err)
@@ -197,10 +203,10 @@ func (s *PrintCenterServiceTestSuite) TestGetPending_Success() {
 func (s *PrintCenterServiceTestSuite) TestGetPending_DatabaseError() {
 	// Arrange
 	dbErr := errors.New("database error")
-	s.mockRepo.EXPECT().FindByStatus(entity.StatusPending).Return(nil, dbErr)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Filter: repository.ListFilter{Statuses: []string{"pending"}}}).Return(nil, repository.PageInfo{}, dbErr)
 
 	// Act
-	_, err := s.service.GetPending()
+	_, _, err := s.service.GetPending(dto.ListRequest{})
 
 	// Assert
 	s.Error(err)
@@ -218,24 +224,76 @@ func (s *PrintCenterServiceTestSuite) TestGetAll_Success() {
 		{ID: 2, Name: "Center 2", Status: entity.StatusPending},
 		{ID: 3, Name: "Center 3", Status: entity.StatusSuspended},
 	}
-	s.mockRepo.EXPECT().FindAll().Return(expectedCenters, nil)
+	s.mockRepo.EXPECT().List(repository.ListQuery{Limit: 3}).Return(expectedCenters, repository.PageInfo{Total: 5, NextCursor: "next"}, nil)
 
 	// Act
-	result, err := s.service.GetAll()
+	result, info, err := s.service.GetAll(dto.ListRequest{Limit: 3})
 
 	// Assert
 	s.NoError(err)
 	s.Equal(expectedCenters, result)
 	s.Len(result, 3)
+	s.Equal(dto.PageInfo{Total: 5, NextCursor: "next"}, info)
+}
+
+func (s *PrintCenterServiceTestSuite) TestGetAll_StatusFilter() {
+	// Arrange
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	req := dto.ListRequest{Status: []string{"APPROVED", "suspended"}, Sort: "-name", CreatedFrom: &from}
+	s.mockRepo.EXPECT().List(repository.ListQuery{
+		Sort: "-name",
+		Filter: repository.ListFilter{
+			Statuses:    []string{"approved", "suspended"},
+			CreatedFrom: &from,
+		},
+	}).Return([]entity.PrintCenter{}, repository.PageInfo{}, nil)
+
+	// Act
+	_, _, err := s.service.GetAll(req)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *PrintCenterServiceTestSuite) TestGetAll_UnknownStatus() {
+	// Act
+	_, _, err := s.service.GetAll(dto.ListRequest{Status: []string{"closed"}})
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidListFilter)
+}
+
+func (s *PrintCenterServiceTestSuite) TestGetAll_InvalidCursor() {
+	// Arrange
+	s.mockRepo.EXPECT().List(repository.ListQuery{Cursor: "bogus"}).
+		Return(nil, repository.PageInfo{}, fmt.Errorf("failed to list print centers: %w", repository.ErrInvalidCursor))
+
+	// Act
+	_, _, err := s.service.GetAll(dto.ListRequest{Cursor: "bogus"})
+
+	// Assert
+	s.Equal(ierrors.ErrInvalidCursor, err)
+}
+
+func (s *PrintCenterServiceTestSuite) TestGetAll_UnsupportedSort() {
+	// Arrange
+	s.mockRepo.EXPECT().List(repository.ListQuery{Sort: "email"}).
+		Return(nil, repository.PageInfo{}, fmt.Errorf("failed to list print centers: %w", repository.ErrUnsupportedSort))
+
+	// Act
+	_, _, err := s.service.GetAll(dto.ListRequest{Sort: "email"})
+
+	// Assert
+	s.Equal(ierrors.ErrUnsupportedSort, err)
 }
 
 func (s *PrintCenterServiceTestSuite) TestGetAll_DatabaseError() {
 	// Arrange
 	dbErr := errors.New("database error")
-	s.mockRepo.EXPECT().FindAll().Return(nil, dbErr)
+	s.mockRepo.EXPECT().List(repository.ListQuery{}).Return(nil, repository.PageInfo{}, dbErr)
 
 	// Act
-	_, err := s.service.GetAll()
+	_, _, err := s.service.GetAll(dto.ListRequest{})
 
 	// Assert
 	s.Error(err)
@@ -331,12 +389,29 @@ func (s *PrintCenterServiceTestSuite) TestUpdateStatus_Success() {
 		s.Equal(newStatus, updates["status"])
 		return nil
 	})
+	s.notifier.EXPECT().NotifyCenter(entity.NotifyCenterApproved, existingCenter).Return(nil)
 
 	// Act
 	err := s.service.UpdateStatus(centerID, newStatus)
 
 	// Assert
 	s.NoError(err)
+	s.Equal(entity.StatusApproved, existingCenter.Status)
+}
+
+func (s *PrintCenterServiceTestSuite) TestUpdateStatus_OnlyApprovalIsNotified() {
+	// Arrange
+	var centerID uint = 1
+	s.mockRepo.EXPECT().FindByID(centerID).Return(&entity.PrintCenter{ID: 1, Status: entity.StatusApproved}, nil).Times(2)
+	s.mockRepo.EXPECT().Update(centerID, gomock.Any()).Return(nil).Times(2)
+
+	// Act
+	suspendErr := s.service.UpdateStatus(centerID, entity.StatusSuspended)
+	approveErr := s.service.UpdateStatus(centerID, entity.StatusApproved)
+
+	// Assert
+	s.NoError(suspendErr)
+	s.NoError(approveErr)
 }
 
 func (s *PrintCenterServiceTestSuite) TestUpdateStatus_NotFound() {
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// QRFormat is the image format of a rendered QR code.
+type QRFormat string
+
+const (
+	QRFormatPNG QRFormat = "png"
+	QRFormatSVG QRFormat = "svg"
+)
+
+// qrPNGSize is the width and height of rendered PNG QR codes, in pixels.
+const qrPNGSize = 320
+
+// ContentType returns the MIME type of the format.
+func (f QRFormat) ContentType() string {
+	if f == QRFormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// RenderQRCode encodes the content as a QR code image in the given format.
+func RenderQRCode(content string, format QRFormat) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	switch format {
+	case QRFormatPNG:
+		return code.PNG(qrPNGSize)
+	case QRFormatSVG:
+		return renderSVG(code.Bitmap()), nil
+	default:
+		return nil, fmt.Errorf("unsupported QR code format: %s", format)
+	}
+}
+
+// renderSVG draws one unit square per dark module, quiet zone included in the bitmap.
+func renderSVG(bitmap [][]bool) []byte {
+	size := len(bitmap)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, size, size)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return []byte(b.String())
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kimbasn/printly/internal/dto"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/repository"
+)
+
+//go:generate mockgen -destination=../mocks/mock_resumable_upload_service.go -package=mocks github.com/kimbasn/printly/internal/service ResumableUploadService
+
+// ResumableUploadService receives the files of direct upload orders over several requests, as
+// the tus resumable upload protocol does, so that a dropped connection resumes where it stopped.
+// Each request appends one part to storage; once the last byte is received, the parts are joined
+// and moved to the storage path of the document, which is then confirmed like any direct upload.
+// A file already stored for the document, e.g. through its signed URL, is never replaced.
+type ResumableUploadService interface {
+	CreateUpload(orderID, documentID uint, length int64, actor entity.Actor) (*entity.ResumableUpload, error)
+	GetUpload(id string, actor entity.Actor) (*entity.ResumableUpload, error)
+	AppendUpload(id string, offset int64, body io.Reader, actor entity.Actor) (*entity.ResumableUpload, error)
+	TerminateUpload(id string, actor entity.Actor) error
+	PurgeExpiredUploads(ctx context.Context) (*dto.ResumableUploadPurgeReport, error)
+}
+
+const (
+	// maxUploadParts bounds the number of requests an upload is sent in, hence the number of parts
+	// to join. A 50MB file in 1000 parts still means chunks of 50KB on average.
+	maxUploadParts = 1000
+	// uploadPurgeBatchSize bounds the number of expired uploads deleted in one run
+	uploadPurgeBatchSize = 200
+)
+
+type resumableUploadService struct {
+	uploadRepo     repository.ResumableUploadRepository
+	orderRepo      repository.OrderRepository
+	storageService StorageService
+	expiry         time.Duration
+	lockTTL        time.Duration
+	logger         *zap.Logger
+}
+
+// NewResumableUploadService creates a new instance of ResumableUploadService. Uploads expire with
+// their order, the given expiry after the order started awaiting its documents. A request appending
+// to an upload holds it for at most lockTTL, which should match the deadline of the upload routes.
+func NewResumableUploadService(uploadRepo repository.ResumableUploadRepository, orderRepo repository.OrderRepository, storageService StorageService, expiry, lockTTL time.Duration, logger *zap.Logger) ResumableUploadService {
+	return &resumableUploadService{
+		uploadRepo:     uploadRepo,
+		orderRepo:      orderRepo,
+		storageService: storageService,
+		expiry:         expiry,
+		lockTTL:        lockTTL,
+		logger:         logger,
+	}
+}
+
+// CreateUpload starts the upload of a document of an order awaiting its documents.
+// The length must be the size declared for the document, which must have no file stored yet.
+func (s *resumableUploadService) CreateUpload(orderID, documentID uint, length int64, actor entity.Actor) (*entity.ResumableUpload, error) {
+	s.logger.Info("Creating resumable upload", zap.Uint("orderID", orderID), zap.Uint("documentID", documentID), zap.String("actor", actor.UID))
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ierrors.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting order by id %d: %w", orderID, err)
+	}
+	if order.UserUID != actor.UID {
+		return nil, ierrors.ErrOrderAccessDenied
+	}
+	if order.Status != entity.StatusAwaitingDocument {
+		return nil, fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotAwaitingDocument, order.Status)
+	}
+
+	doc, err := awaitedDocument(order, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if length != doc.Size {
+		return nil, fmt.Errorf("%w: %s declared %d bytes, upload is %d bytes", ierrors.ErrUploadMismatch, doc.FileName, doc.Size, length)
+	}
+	if _, err := s.storageService.StatFile(doc.StoragePath); !errors.Is(err, ErrFileNotFound) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to check upload of document %d: %w", doc.ID, err)
+		}
+		return nil, ierrors.ErrDocumentAlreadyUploaded
+	}
+
+	// The order is cancelled when its documents are not received in time, and the upload with it
+	now := time.Now()
+	expiresAt := order.UpdatedAt.Add(s.expiry)
+	if !expiresAt.After(now) {
+		return nil, fmt.Errorf("%w: order has expired", ierrors.ErrOrderNotAwaitingDocument)
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+	upload := &entity.ResumableUpload{
+		ID:          id,
+		UserUID:     actor.UID,
+		OrderID:     order.ID,
+		DocumentID:  doc.ID,
+		StoragePath: doc.StoragePath,
+		Length:      length,
+		ExpiresAt:   expiresAt,
+	}
+	if err := s.uploadRepo.Save(upload); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Resumable upload created", zap.String("uploadID", id), zap.Int64("length", length))
+	return upload, nil
+}
+
+// GetUpload returns an upload of the actor, telling how many bytes were received so far.
+func (s *resumableUploadService) GetUpload(id string, actor entity.Actor) (*entity.ResumableUpload, error) {
+	upload, err := s.findUpload(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, ierrors.ErrUploadExpired
+	}
+	return upload, nil
+}
+
+// AppendUpload stores the body as the next part of an upload. The offset must be the number of bytes
+// received so far. When the client goes away mid-request, the bytes received are kept and the client
+// resumes from there. The parts are joined at the storage path of the document with the last byte.
+func (s *resumableUploadService) AppendUpload(id string, offset int64, body io.Reader, actor entity.Actor) (*entity.ResumableUpload, error) {
+	if _, err := s.GetUpload(id, actor); err != nil {
+		return nil, err
+	}
+
+	locked, err := s.uploadRepo.Lock(id, time.Now().Add(s.lockTTL))
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, ierrors.ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	// Read the progress again now that no other request can change it
+	upload, err := s.findUpload(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, fmt.Errorf("%w: %d bytes were received", ierrors.ErrUploadOffsetMismatch, upload.Offset)
+	}
+
+	// 1. Store the body as the next part, at most the bytes left
+	received := &partReader{r: io.LimitReader(body, upload.Length-upload.Offset)}
+	if upload.Offset < upload.Length {
+		if upload.Parts >= maxUploadParts {
+			return nil, ierrors.ErrTooManyUploadParts
+		}
+		part := uploadPartPath(upload, upload.Parts)
+		if err := s.storageService.WriteFile(part, received); err != nil {
+			return nil, fmt.Errorf("failed to store part %d of upload %s: %w", upload.Parts, id, err)
+		}
+		if received.n == 0 {
+			s.deletePart(part)
+		} else if received.err == nil && hasMoreData(body) {
+			s.deletePart(part)
+			return nil, ierrors.ErrUploadExceedsLength
+		}
+	} else if hasMoreData(body) {
+		return nil, ierrors.ErrUploadExceedsLength
+	}
+
+	// 2. Record the progress
+	if received.n > 0 {
+		upload.Offset += received.n
+		upload.Parts++
+		if err := s.uploadRepo.Update(id, map[string]any{"offset": upload.Offset, "parts": upload.Parts}); err != nil {
+			return nil, err
+		}
+	}
+	if received.err != nil {
+		s.logger.Warn("Resumable upload interrupted",
+			zap.String("uploadID", id),
+			zap.Int64("offset", upload.Offset),
+			zap.Error(received.err))
+		return upload, nil
+	}
+
+	// 3. Attach the file to its document with the last byte. A failed attempt is retried by
+	// sending an empty request at the final offset.
+	if upload.IsComplete() && upload.CompletedAt == nil {
+		if err := s.completeUpload(upload); err != nil {
+			return nil, err
+		}
+	}
+
+	return upload, nil
+}
+
+// completeUpload joins the parts of an upload in a staging file, moves it to the storage path of
+// its document, then deletes the parts. The file is only moved when the document has none yet, so
+// that a file being confirmed, or received through the signed URL, is never replaced.
+func (s *resumableUploadService) completeUpload(upload *entity.ResumableUpload) error {
+	// The parts of an order cancelled meanwhile are deleted when the upload expires
+	if err := s.checkAwaitingFile(upload); err != nil {
+		return err
+	}
+
+	parts := uploadPartPaths(upload)
+	staging := uploadStagingPath(upload)
+	if err := s.storageService.ComposeFiles(staging, parts); err != nil {
+		return fmt.Errorf("failed to join the parts of upload %s: %w", upload.ID, err)
+	}
+
+	// Joining takes a while, check again that the document is still waiting for its file
+	if err := s.checkAwaitingFile(upload); err != nil {
+		s.deletePart(staging)
+		return err
+	}
+	if err := s.storageService.MoveFile(staging, upload.StoragePath); err != nil {
+		s.deletePart(staging)
+		if errors.Is(err, ierrors.ErrUploadAlreadyReceived) {
+			return ierrors.ErrDocumentAlreadyUploaded
+		}
+		return fmt.Errorf("failed to move the file of upload %s: %w", upload.ID, err)
+	}
+
+	now := time.Now()
+	if err := s.uploadRepo.Update(upload.ID, map[string]any{"completed_at": now}); err != nil {
+		return err
+	}
+	upload.CompletedAt = &now
+
+	for _, part := range parts {
+		s.deletePart(part)
+	}
+
+	s.logger.Info("Resumable upload completed",
+		zap.String("uploadID", upload.ID),
+		zap.Uint("orderID", upload.OrderID),
+		zap.Uint("documentID", upload.DocumentID))
+	return nil
+}
+
+// TerminateUpload abandons an upload and deletes its parts. Terminating a completed upload only
+// forgets it, its file stays attached to the document.
+func (s *resumableUploadService) TerminateUpload(id string, actor entity.Actor) error {
+	s.logger.Info("Terminating resumable upload", zap.String("uploadID", id), zap.String("actor", actor.UID))
+
+	if _, err := s.findUpload(id, actor); err != nil {
+		return err
+	}
+
+	locked, err := s.uploadRepo.Lock(id, time.Now().Add(s.lockTTL))
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return ierrors.ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	upload, err := s.findUpload(id, actor)
+	if err != nil {
+		return err
+	}
+	return s.deleteUpload(upload)
+}
+
+// PurgeExpiredUploads deletes the expired uploads and the parts left by those never completed.
+// Uploads still receiving a request are left for the next run.
+func (s *resumableUploadService) PurgeExpiredUploads(ctx context.Context) (*dto.ResumableUploadPurgeReport, error) {
+	report := &dto.ResumableUploadPurgeReport{}
+
+	uploads, err := s.uploadRepo.FindExpired(time.Now(), uploadPurgeBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, upload := range uploads {
+		if ctx.Err() != nil {
+			break
+		}
+
+		locked, err := s.uploadRepo.Lock(upload.ID, time.Now().Add(s.lockTTL))
+		if err != nil {
+			report.Failures = append(report.Failures, fmt.Sprintf("upload %s: %v", upload.ID, err))
+			continue
+		}
+		if !locked {
+			continue
+		}
+
+		if err := s.deleteUpload(&upload); err != nil {
+			report.Failures = append(report.Failures, fmt.Sprintf("upload %s: %v", upload.ID, err))
+			s.unlock(upload.ID)
+			continue
+		}
+		report.DeletedUploads++
+		if upload.CompletedAt == nil {
+			report.DeletedParts += upload.Parts
+		}
+	}
+
+	s.logger.Info("Expired resumable uploads purged",
+		zap.Int("uploads", report.DeletedUploads),
+		zap.Int("parts", report.DeletedParts),
+		zap.Int("failures", len(report.Failures)))
+	return report, nil
+}
+
+// deleteUpload deletes the parts of an upload, unless they were joined already, then its record
+func (s *resumableUploadService) deleteUpload(upload *entity.ResumableUpload) error {
+	if upload.CompletedAt == nil {
+		for _, part := range append(uploadPartPaths(upload), uploadStagingPath(upload)) {
+			if err := s.storageService.DeleteFile(part); err != nil && !errors.Is(err, ErrFileNotFound) {
+				return fmt.Errorf("failed to delete part %s: %w", part, err)
+			}
+		}
+	}
+
+	if err := s.uploadRepo.Delete(upload.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkAwaitingFile checks that the order of an upload still awaits its documents and that
+// the document of the upload was not confirmed yet
+func (s *resumableUploadService) checkAwaitingFile(upload *entity.ResumableUpload) error {
+	order, err := s.orderRepo.FindByID(upload.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ierrors.ErrOrderNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("getting order by id %d: %w", upload.OrderID, err)
+	}
+	if order.Status != entity.StatusAwaitingDocument {
+		return fmt.Errorf("%w: order is %s", ierrors.ErrOrderNotAwaitingDocument, order.Status)
+	}
+	_, err = awaitedDocument(order, upload.DocumentID)
+	return err
+}
+
+// awaitedDocument returns a document of an order, unless its file was confirmed already
+func awaitedDocument(order *entity.Order, documentID uint) (*entity.Document, error) {
+	i := slices.IndexFunc(order.Documents, func(doc entity.Document) bool { return doc.ID == documentID })
+	if i < 0 {
+		return nil, ierrors.ErrDocumentNotFound
+	}
+	doc := &order.Documents[i]
+	if doc.UploadedAt != nil {
+		return nil, ierrors.ErrDocumentAlreadyUploaded
+	}
+	return doc, nil
+}
+
+// findUpload retrieves an upload of the actor
+func (s *resumableUploadService) findUpload(id string, actor entity.Actor) (*entity.ResumableUpload, error) {
+	upload, err := s.uploadRepo.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ierrors.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserUID != actor.UID {
+		return nil, ierrors.ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+func (s *resumableUploadService) unlock(id string) {
+	if err := s.uploadRepo.Unlock(id); err != nil {
+		s.logger.Error("Failed to unlock resumable upload", zap.String("uploadID", id), zap.Error(err))
+	}
+}
+
+func (s *resumableUploadService) deletePart(part string) {
+	if err := s.storageService.DeleteFile(part); err != nil && !errors.Is(err, ErrFileNotFound) {
+		s.logger.Error("Failed to delete upload part", zap.String("storagePath", part), zap.Error(err))
+	}
+}
+
+// partReader ends a part cleanly when reading the request fails, e.g. when the client goes away,
+// so that the bytes received so far are stored. The failure is kept in err.
+type partReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (p *partReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.n += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		p.err = err
+		return n, io.EOF
+	}
+	return n, err
+}
+
+// hasMoreData reports whether a request body has bytes left to read
+func hasMoreData(body io.Reader) bool {
+	n, _ := io.ReadFull(body, make([]byte, 1))
+	return n > 0
+}
+
+// uploadPartPath returns the storage path of a part, next to the file of the document. Parts are
+// named after their upload, so that several uploads of the same document never share parts.
+func uploadPartPath(upload *entity.ResumableUpload, part int) string {
+	return fmt.Sprintf("%s.%s.part-%04d", upload.StoragePath, upload.ID, part)
+}
+
+// uploadStagingPath returns the storage path the parts of an upload are joined at, before the
+// file is moved to the storage path of the document
+func uploadStagingPath(upload *entity.ResumableUpload) string {
+	return fmt.Sprintf("%s.%s.staging", upload.StoragePath, upload.ID)
+}
+
+// uploadPartPaths returns the storage paths of the parts stored so far, in order
+func uploadPartPaths(upload *entity.ResumableUpload) []string {
+	parts := make([]string, upload.Parts)
+	for i := range parts {
+		parts[i] = uploadPartPath(upload, i)
+	}
+	return parts
+}
+
+// newUploadID generates the random ID of an upload, which is part of its URL
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate upload id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
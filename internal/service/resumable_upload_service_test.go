@@ -0,0 +1,487 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kimbasn/printly/internal/entity"
+	ierrors "github.com/kimbasn/printly/internal/errors"
+	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/service"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ResumableUploadServiceTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	uploadRepo     *mocks.MockResumableUploadRepository
+	orderRepo      *mocks.MockOrderRepository
+	storageService *mocks.MockStorageService
+	service        service.ResumableUploadService
+	owner          entity.Actor
+}
+
+func (s *ResumableUploadServiceTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.uploadRepo = mocks.NewMockResumableUploadRepository(s.ctrl)
+	s.orderRepo = mocks.NewMockOrderRepository(s.ctrl)
+	s.storageService = mocks.NewMockStorageService(s.ctrl)
+	s.service = service.NewResumableUploadService(s.uploadRepo, s.orderRepo, s.storageService, 2*time.Hour, 10*time.Minute, zap.NewNop())
+	s.owner = entity.Actor{UID: "user-1", Role: entity.RoleUser}
+}
+
+func (s *ResumableUploadServiceTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestResumableUploadService(t *testing.T) {
+	suite.Run(t, new(ResumableUploadServiceTestSuite))
+}
+
+func uploadOrder(status entity.OrderStatus) *entity.Order {
+	return &entity.Order{
+		ID:        7,
+		UserUID:   "user-1",
+		Status:    status,
+		UpdatedAt: time.Now().Add(-30 * time.Minute),
+		Documents: []entity.Document{{ID: 70, OrderID: 7, FileName: "report.pdf", Size: 10, StoragePath: "user-1/report.pdf"}},
+	}
+}
+
+func resumableUpload(offset int64, parts int) *entity.ResumableUpload {
+	return &entity.ResumableUpload{
+		ID:          "abc",
+		UserUID:     "user-1",
+		OrderID:     7,
+		DocumentID:  70,
+		StoragePath: "user-1/report.pdf",
+		Length:      10,
+		Offset:      offset,
+		Parts:       parts,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+}
+
+// expectLocked expects the upload to be locked, read again under the lock, then unlocked
+func (s *ResumableUploadServiceTestSuite) expectLocked(upload *entity.ResumableUpload) {
+	s.uploadRepo.EXPECT().FindByID("abc").Return(upload, nil).Times(2)
+	s.uploadRepo.EXPECT().Lock("abc", gomock.Any()).Return(true, nil)
+	s.uploadRepo.EXPECT().Unlock("abc").Return(nil)
+}
+
+// expectPart expects a part to be written and captures its content
+func (s *ResumableUploadServiceTestSuite) expectPart(path string, content *bytes.Buffer) {
+	s.storageService.EXPECT().
+		WriteFile(path, gomock.Any()).
+		DoAndReturn(func(storagePath string, reader io.Reader) error {
+			_, err := io.Copy(content, reader)
+			return err
+		})
+}
+
+// ============================================================================
+// CreateUpload Tests
+// ============================================================================
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_Success() {
+	// Arrange
+	order := uploadOrder(entity.StatusAwaitingDocument)
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(order, nil)
+	s.storageService.EXPECT().StatFile("user-1/report.pdf").Return(nil, service.ErrFileNotFound)
+	s.uploadRepo.EXPECT().Save(gomock.Any()).Return(nil)
+
+	// Act
+	upload, err := s.service.CreateUpload(7, 70, 10, s.owner)
+
+	// Assert: the upload expires with its order
+	s.Require().NoError(err)
+	s.Len(upload.ID, 32)
+	s.Equal(uint(70), upload.DocumentID)
+	s.Equal("user-1/report.pdf", upload.StoragePath)
+	s.Equal(int64(10), upload.Length)
+	s.Equal(order.UpdatedAt.Add(2*time.Hour), upload.ExpiresAt)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_LengthMismatch() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil)
+
+	// Act
+	_, err := s.service.CreateUpload(7, 70, 11, s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadMismatch)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_UnknownDocument() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil)
+
+	// Act
+	_, err := s.service.CreateUpload(7, 71, 10, s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrDocumentNotFound)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_NotOwner() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil)
+
+	// Act
+	_, err := s.service.CreateUpload(7, 70, 10, entity.Actor{UID: "user-2", Role: entity.RoleUser})
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderAccessDenied)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_FileAlreadyStored() {
+	// Arrange: the file was uploaded through the signed URL of the document
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil)
+	s.storageService.EXPECT().StatFile("user-1/report.pdf").Return(&service.ObjectInfo{Size: 10}, nil)
+
+	// Act
+	_, err := s.service.CreateUpload(7, 70, 10, s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrDocumentAlreadyUploaded)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestCreateUpload_OrderNotAwaitingDocument() {
+	// Arrange
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusPendingPayment), nil)
+
+	// Act
+	_, err := s.service.CreateUpload(7, 70, 10, s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrOrderNotAwaitingDocument)
+}
+
+// ============================================================================
+// GetUpload Tests
+// ============================================================================
+
+func (s *ResumableUploadServiceTestSuite) TestGetUpload_Expired() {
+	// Arrange
+	upload := resumableUpload(4, 1)
+	upload.ExpiresAt = time.Now().Add(-time.Minute)
+	s.uploadRepo.EXPECT().FindByID("abc").Return(upload, nil)
+
+	// Act
+	_, err := s.service.GetUpload("abc", s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadExpired)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestGetUpload_OtherUser() {
+	// Arrange
+	s.uploadRepo.EXPECT().FindByID("abc").Return(resumableUpload(4, 1), nil)
+
+	// Act
+	_, err := s.service.GetUpload("abc", entity.Actor{UID: "user-2", Role: entity.RoleUser})
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadNotFound)
+}
+
+// ============================================================================
+// AppendUpload Tests
+// ============================================================================
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_StoresNextPart() {
+	// Arrange
+	s.expectLocked(resumableUpload(4, 1))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0001", &part)
+	s.uploadRepo.EXPECT().Update("abc", map[string]any{"offset": int64(7), "parts": 2}).Return(nil)
+
+	// Act
+	upload, err := s.service.AppendUpload("abc", 4, strings.NewReader("efg"), s.owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("efg", part.String())
+	s.Equal(int64(7), upload.Offset)
+	s.Nil(upload.CompletedAt)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_LastPartJoinsParts() {
+	// Arrange
+	s.expectLocked(resumableUpload(7, 2))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0002", &part)
+	s.uploadRepo.EXPECT().Update("abc", map[string]any{"offset": int64(10), "parts": 3}).Return(nil)
+	// The order is checked before joining the parts and again before moving the file
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil).Times(2)
+	parts := []string{"user-1/report.pdf.abc.part-0000", "user-1/report.pdf.abc.part-0001", "user-1/report.pdf.abc.part-0002"}
+	s.storageService.EXPECT().ComposeFiles("user-1/report.pdf.abc.staging", parts).Return(nil)
+	s.storageService.EXPECT().MoveFile("user-1/report.pdf.abc.staging", "user-1/report.pdf").Return(nil)
+	s.uploadRepo.EXPECT().Update("abc", gomock.Any()).Return(nil)
+	for _, path := range parts {
+		s.storageService.EXPECT().DeleteFile(path).Return(nil)
+	}
+
+	// Act
+	upload, err := s.service.AppendUpload("abc", 7, strings.NewReader("hij"), s.owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(upload.IsComplete())
+	s.NotNil(upload.CompletedAt)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_FileStoredMeanwhileKept() {
+	// Arrange: a file was received through the signed URL while the parts were joined
+	s.expectLocked(resumableUpload(10, 3))
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil).Times(2)
+	s.storageService.EXPECT().ComposeFiles("user-1/report.pdf.abc.staging", gomock.Any()).Return(nil)
+	s.storageService.EXPECT().MoveFile("user-1/report.pdf.abc.staging", "user-1/report.pdf").Return(ierrors.ErrUploadAlreadyReceived)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.staging").Return(nil)
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 10, strings.NewReader(""), s.owner)
+
+	// Assert: the stored file is not replaced and the upload is not completed
+	s.ErrorIs(err, ierrors.ErrDocumentAlreadyUploaded)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_DocumentConfirmedMeanwhile() {
+	// Arrange: the order was confirmed from a file received through the signed URL while the parts were joined
+	s.expectLocked(resumableUpload(10, 3))
+	confirmed := uploadOrder(entity.StatusAwaitingDocument)
+	uploadedAt := time.Now()
+	confirmed.Documents[0].UploadedAt = &uploadedAt
+	gomock.InOrder(
+		s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusAwaitingDocument), nil),
+		s.orderRepo.EXPECT().FindByID(uint(7)).Return(confirmed, nil),
+	)
+	s.storageService.EXPECT().ComposeFiles("user-1/report.pdf.abc.staging", gomock.Any()).Return(nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.staging").Return(nil)
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 10, strings.NewReader(""), s.owner)
+
+	// Assert: the file is never moved to the document
+	s.ErrorIs(err, ierrors.ErrDocumentAlreadyUploaded)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_KeepsBytesOfInterruptedRequest() {
+	// Arrange: the connection drops after 2 bytes
+	s.expectLocked(resumableUpload(4, 1))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0001", &part)
+	s.uploadRepo.EXPECT().Update("abc", map[string]any{"offset": int64(6), "parts": 2}).Return(nil)
+	body := io.MultiReader(strings.NewReader("ef"), iotest.ErrReader(io.ErrUnexpectedEOF))
+
+	// Act
+	upload, err := s.service.AppendUpload("abc", 4, body, s.owner)
+
+	// Assert: the client resumes from the bytes received
+	s.Require().NoError(err)
+	s.Equal("ef", part.String())
+	s.Equal(int64(6), upload.Offset)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_EmptyInterruptedRequestStoresNothing() {
+	// Arrange
+	s.expectLocked(resumableUpload(4, 1))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0001", &part)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0001").Return(nil)
+
+	// Act
+	upload, err := s.service.AppendUpload("abc", 4, iotest.ErrReader(io.ErrUnexpectedEOF), s.owner)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(4), upload.Offset)
+	s.Equal(1, upload.Parts)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_BodyPastLength() {
+	// Arrange
+	s.expectLocked(resumableUpload(4, 1))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0001", &part)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0001").Return(nil)
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 4, strings.NewReader("efghijk"), s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadExceedsLength)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_OffsetMismatch() {
+	// Arrange
+	s.expectLocked(resumableUpload(4, 1))
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 2, strings.NewReader("cd"), s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadOffsetMismatch)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_Locked() {
+	// Arrange
+	s.uploadRepo.EXPECT().FindByID("abc").Return(resumableUpload(4, 1), nil)
+	s.uploadRepo.EXPECT().Lock("abc", gomock.Any()).Return(false, nil)
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 4, strings.NewReader("efg"), s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadLocked)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestAppendUpload_OrderCancelledMeanwhile() {
+	// Arrange
+	s.expectLocked(resumableUpload(7, 2))
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.abc.part-0002", &part)
+	s.uploadRepo.EXPECT().Update("abc", gomock.Any()).Return(nil)
+	s.orderRepo.EXPECT().FindByID(uint(7)).Return(uploadOrder(entity.StatusCancelled), nil)
+
+	// Act
+	_, err := s.service.AppendUpload("abc", 7, strings.NewReader("hij"), s.owner)
+
+	// Assert: the parts are left for the cleanup of expired uploads
+	s.ErrorIs(err, ierrors.ErrOrderNotAwaitingDocument)
+}
+
+// ============================================================================
+// TerminateUpload Tests
+// ============================================================================
+
+func (s *ResumableUploadServiceTestSuite) TestTerminateUpload_DeletesParts() {
+	// Arrange
+	s.expectLocked(resumableUpload(7, 2))
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0000").Return(nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0001").Return(nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.staging").Return(service.ErrFileNotFound)
+	s.uploadRepo.EXPECT().Delete("abc").Return(nil)
+
+	// Act
+	err := s.service.TerminateUpload("abc", s.owner)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestTerminateUpload_KeepsPartsOfAnotherUploadOfTheDocument() {
+	// Arrange: the client lost the URL of the first upload and started a second one for the same document
+	first := resumableUpload(5, 1)
+	second := resumableUpload(0, 0)
+	second.ID = "def"
+	s.uploadRepo.EXPECT().FindByID("def").Return(second, nil).Times(2)
+	s.uploadRepo.EXPECT().Lock("def", gomock.Any()).Return(true, nil)
+	s.uploadRepo.EXPECT().Unlock("def").Return(nil)
+	var part bytes.Buffer
+	s.expectPart("user-1/report.pdf.def.part-0000", &part)
+	s.uploadRepo.EXPECT().Update("def", map[string]any{"offset": int64(5), "parts": 1}).Return(nil)
+	_, err := s.service.AppendUpload("def", 0, strings.NewReader("hello"), s.owner)
+	s.Require().NoError(err)
+
+	s.expectLocked(first)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0000").Return(nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.staging").Return(service.ErrFileNotFound)
+	s.uploadRepo.EXPECT().Delete("abc").Return(nil)
+	// No DeleteFile expected for the part of the second upload
+
+	// Act
+	err = s.service.TerminateUpload("abc", s.owner)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("hello", part.String())
+}
+
+func (s *ResumableUploadServiceTestSuite) TestTerminateUpload_CompletedKeepsFile() {
+	// Arrange
+	upload := resumableUpload(10, 3)
+	completedAt := time.Now()
+	upload.CompletedAt = &completedAt
+	s.expectLocked(upload)
+	s.uploadRepo.EXPECT().Delete("abc").Return(nil)
+
+	// Act
+	err := s.service.TerminateUpload("abc", s.owner)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestTerminateUpload_NotFound() {
+	// Arrange
+	s.uploadRepo.EXPECT().FindByID("abc").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := s.service.TerminateUpload("abc", s.owner)
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrUploadNotFound)
+}
+
+// ============================================================================
+// PurgeExpiredUploads Tests
+// ============================================================================
+
+func (s *ResumableUploadServiceTestSuite) TestPurgeExpiredUploads_DeletesPartsAndRecords() {
+	// Arrange
+	pending := *resumableUpload(4, 2)
+	completed := *resumableUpload(10, 3)
+	completed.ID = "def"
+	completedAt := time.Now()
+	completed.CompletedAt = &completedAt
+	busy := *resumableUpload(4, 1)
+	busy.ID = "ghi"
+
+	s.uploadRepo.EXPECT().FindExpired(gomock.Any(), gomock.Any()).Return([]entity.ResumableUpload{pending, completed, busy}, nil)
+	s.uploadRepo.EXPECT().Lock("abc", gomock.Any()).Return(true, nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0000").Return(nil)
+	// Already gone parts count as deleted
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0001").Return(service.ErrFileNotFound)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.staging").Return(service.ErrFileNotFound)
+	s.uploadRepo.EXPECT().Delete("abc").Return(nil)
+	s.uploadRepo.EXPECT().Lock("def", gomock.Any()).Return(true, nil)
+	s.uploadRepo.EXPECT().Delete("def").Return(nil)
+	// Still receiving a request, left for the next run
+	s.uploadRepo.EXPECT().Lock("ghi", gomock.Any()).Return(false, nil)
+
+	// Act
+	report, err := s.service.PurgeExpiredUploads(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(2, report.DeletedUploads)
+	s.Equal(2, report.DeletedParts)
+	s.Empty(report.Failures)
+}
+
+func (s *ResumableUploadServiceTestSuite) TestPurgeExpiredUploads_ReportsStorageFailures() {
+	// Arrange
+	s.uploadRepo.EXPECT().FindExpired(gomock.Any(), gomock.Any()).Return([]entity.ResumableUpload{*resumableUpload(4, 1)}, nil)
+	s.uploadRepo.EXPECT().Lock("abc", gomock.Any()).Return(true, nil)
+	s.storageService.EXPECT().DeleteFile("user-1/report.pdf.abc.part-0000").Return(errors.New("bucket unavailable"))
+	s.uploadRepo.EXPECT().Unlock("abc").Return(nil)
+
+	// Act
+	report, err := s.service.PurgeExpiredUploads(context.Background())
+
+	// Assert: the record is kept for the next run
+	s.Require().NoError(err)
+	s.Zero(report.DeletedUploads)
+	s.Len(report.Failures, 1)
+}
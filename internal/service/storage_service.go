@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -10,6 +11,8 @@ import (
 	"go.uber.org/zap"
 )
 
+//go:generate mockgen -destination=../mocks/mock_storage_service.go -package=mocks github.com/kimbasn/printly/internal/service StorageService
+
 // StorageService defines the interface for file storage operations
 type StorageService interface {
 	UploadFile(file multipart.File, filename, userUID string) (string, error)
@@ -17,8 +20,50 @@ type StorageService interface {
 	DeleteFile(storagePath string) error
 	GetFileURL(storagePath string) (string, error)
 	GetSignedURL(storagePath string, expiration time.Duration) (string, error)
+	// NewUploadPath reserves a unique storage path for a file the client uploads itself
+	NewUploadPath(filename, userUID string) (string, error)
+	// GetSignedUploadURL returns a URL the client can PUT the file to until it expires, with the
+	// headers the upload must be sent with. The URL only creates the file, it never replaces a
+	// file already stored at the path.
+	GetSignedUploadURL(storagePath, contentType string, expiration time.Duration) (*SignedUpload, error)
+	// StatFile describes a stored file
+	StatFile(storagePath string) (*ObjectInfo, error)
+	// OpenFile opens a stored file for reading. The caller must close it.
+	OpenFile(storagePath string) (io.ReadCloser, error)
+	// WriteFile stores the content of the reader at the given storage path, replacing any file there
+	WriteFile(storagePath string, reader io.Reader) error
+	// ComposeFiles stores the concatenation of the source files at the given storage path.
+	// The sources are left in place.
+	ComposeFiles(storagePath string, sources []string) error
+	// MoveFile moves a stored file to the given storage path, only if no file is stored there yet.
+	// Otherwise it fails with ierrors.ErrUploadAlreadyReceived and the source is left in place.
+	MoveFile(source, storagePath string) error
+	Type() StorageType
+}
+
+// SignedUploadReceiver is implemented by storage backends that receive the uploads of their
+// signed upload URLs themselves, instead of a cloud bucket receiving them.
+type SignedUploadReceiver interface {
+	// VerifySignedUpload checks the signature and expiry of an upload URL
+	VerifySignedUpload(storagePath, contentType string, expires int64, signature string) error
+	ReceiveSignedUpload(storagePath, contentType string, expires int64, signature string, body io.Reader) error
+}
+
+// SignedUpload is a URL the client uploads a file to, with the headers it must send
+type SignedUpload struct {
+	URL     string
+	Headers map[string]string
+}
+
+// ObjectInfo describes a stored file
+type ObjectInfo struct {
+	Size        int64
+	ContentType string // Empty when the backend does not record it
 }
 
+// ErrFileNotFound is returned by DeleteFile, StatFile and OpenFile when there is no file at the storage path
+var ErrFileNotFound = errors.New("file not found")
+
 // StorageType represents the type of storage backend
 type StorageType string
 
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/kimbasn/printly/internal/adapter"
+	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 
@@ -23,7 +24,7 @@ type UserService interface {
 	Register(user *entity.User, password string) (*entity.User, error)
 	GetByUID(uid string) (*entity.User, error)
 	Delete(uid string) error
-	GetAll() ([]entity.User, error)
+	GetAll(req dto.ListRequest) ([]entity.User, dto.PageInfo, error)
 	UpdateProfile(uid string, updates map[string]any) error
 	UpdateRole(uid string, role entity.Role) error
 }
@@ -131,10 +132,15 @@ func (s *userService) Delete(uid string) error {
 	return nil
 }
 
-func (s *userService) GetAll() ([]entity.User, error) {
-	users, err := s.repo.FindAll()
+// GetAll retrieves one page of users, filtered by role and registration date.
+func (s *userService) GetAll(req dto.ListRequest) ([]entity.User, dto.PageInfo, error) {
+	if req.Role != "" && !entity.Role(req.Role).IsValid() {
+		return nil, dto.PageInfo{}, fmt.Errorf("%w: unknown role %q", ierrors.ErrInvalidListFilter, req.Role)
+	}
+
+	users, info, err := s.repo.List(listQuery(req, repository.ListFilter{Role: req.Role}))
 	if err != nil {
-		return nil, fmt.Errorf("fetching all users: %w", err)
+		return nil, dto.PageInfo{}, listError(err, "fetching all users")
 	}
-	return users, nil
+	return users, pageInfo(info), nil
 }
@@ -14,9 +14,11 @@ import (
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 
+	"github.com/kimbasn/printly/internal/dto"
 	"github.com/kimbasn/printly/internal/entity"
 	ierrors "github.com/kimbasn/printly/internal/errors"
 	"github.com/kimbasn/printly/internal/mocks"
+	"github.com/kimbasn/printly/internal/repository"
 	"github.com/kimbasn/printly/internal/service"
 )
 
@@ -620,27 +622,50 @@ func (s *UserServiceTestSuite) TestGetAll_Success() {
 		},
 	}
 	s.mockRepo.EXPECT().
-		FindAll().
-		Return(expectedUsers, nil)
+		List(repository.ListQuery{}).
+		Return(expectedUsers, repository.PageInfo{Total: 2}, nil)
 
 	// Act
-	users, err := s.service.GetAll()
+	users, info, err := s.service.GetAll(dto.ListRequest{})
 
 	// Assert
 	s.NoError(err)
 	s.Equal(expectedUsers, users)
 	s.Len(users, 2)
+	s.Equal(int64(2), info.Total)
+}
+
+func (s *UserServiceTestSuite) TestGetAll_RoleFilter() {
+	// Arrange
+	s.mockRepo.EXPECT().
+		List(repository.ListQuery{Limit: 10, Sort: "email", Filter: repository.ListFilter{Role: "manager"}}).
+		Return([]entity.User{}, repository.PageInfo{}, nil)
+
+	// Act
+	_, _, err := s.service.GetAll(dto.ListRequest{Limit: 10, Sort: "email", Role: "manager"})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *UserServiceTestSuite) TestGetAll_UnknownRole() {
+	// Act
+	users, _, err := s.service.GetAll(dto.ListRequest{Role: "system"})
+
+	// Assert
+	s.ErrorIs(err, ierrors.ErrInvalidListFilter)
+	s.Nil(users)
 }
 
 func (s *UserServiceTestSuite) TestGetAll_EmptyResult() {
 	// Arrange
 	expectedUsers := []entity.User{}
 	s.mockRepo.EXPECT().
-		FindAll().
-		Return(expectedUsers, nil)
+		List(repository.ListQuery{}).
+		Return(expectedUsers, repository.PageInfo{}, nil)
 
 	// Act
-	users, err := s.service.GetAll()
+	users, _, err := s.service.GetAll(dto.ListRequest{})
 
 	// Assert
 	s.NoError(err)
@@ -652,11 +677,11 @@ func (s *UserServiceTestSuite) TestGetAll_DatabaseError() {
 	// Arrange
 	dbError := errors.New("database query error")
 	s.mockRepo.EXPECT().
-		FindAll().
-		Return(nil, dbError)
+		List(repository.ListQuery{}).
+		Return(nil, repository.PageInfo{}, dbError)
 
 	// Act
-	users, err := s.service.GetAll()
+	users, _, err := s.service.GetAll(dto.ListRequest{})
 
 	// Assert
 	s.Error(err)
@@ -0,0 +1,14 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kimbasn/printly/internal/entity"
+)
+
+// ValidatePageRange checks the syntax of a page range such as "1-3,5" or "odd".
+// Whether the pages exist can only be checked once the document page count is known.
+func ValidatePageRange(fl validator.FieldLevel) bool {
+	_, err := entity.ParsePageRange(fl.Field().String())
+	return err == nil
+}